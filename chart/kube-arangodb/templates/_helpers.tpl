@@ -21,6 +21,13 @@ Expand the name of the operator.
 {{- printf "arango-%s-operator" .Release.Name | trunc 63 | trimSuffix "-" -}}
 {{- end -}}
 
+{{/*
+Expand the name of the operator webhook service.
+*/}}
+{{- define "kube-arangodb.webhookName" -}}
+{{- printf "arango-%s-operator-webhook" .Release.Name | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+
 {{/*
 Combine name of the deployment.
 */}}
@@ -45,3 +52,10 @@ Create the name of the Operator Cluster resources
 {{- printf "%s-%s-rbac" (include "kube-arangodb.operatorName" .) .Release.Namespace | trunc 63 | trimSuffix "-" -}}
 {{- end -}}
 {{- end -}}
+
+{{/*
+Namespaces watched by the ArangoDeployment and ArangoDeploymentReplication operators (comma separated)
+*/}}
+{{- define "kube-arangodb.watchNamespaces" -}}
+{{- prepend .Values.operator.watch.namespaces .Release.Namespace | uniq | join "," -}}
+{{- end -}}
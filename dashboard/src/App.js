@@ -4,6 +4,7 @@ import ReactTimeout from 'react-timeout';
 
 import { withAuth } from './auth/Auth';
 import api, { isUnauthorized } from './api/api';
+import BackupOperator from './backup/BackupOperator';
 import DeploymentOperator from './deployment/DeploymentOperator';
 import DeploymentReplicationOperator from './replication/DeploymentReplicationOperator';
 import Loading from './util/Loading';
@@ -19,7 +20,7 @@ const PodInfoView = ({pod, namespace}) => (
   </Segment>
 );
 
-const OperatorsView = ({error, deployment, deploymentReplication, storage, pod, namespace, otherOperators}) => {
+const OperatorsView = ({error, deployment, deploymentReplication, storage, backup, pod, namespace, otherOperators}) => {
   let commonMenuItems = otherOperators.map((item) => <Menu.Item><a href={item.url}>{operatorType2Name(item.type)}</a></Menu.Item>);
   if (commonMenuItems.length > 0) {
     commonMenuItems = (<Menu.Item>
@@ -34,6 +35,8 @@ const OperatorsView = ({error, deployment, deploymentReplication, storage, pod,
     Operator = DeploymentReplicationOperator;
   else if (storage)
     Operator = StorageOperator;
+  else if (backup)
+    Operator = BackupOperator;
   return (
     <Operator
       podInfoView={<PodInfoView pod={pod} namespace={namespace} />}
@@ -51,6 +54,8 @@ const operatorType2Name = (oType) => {
       return "Deployment replications";
     case "storage":
       return "Storage";
+    case "backup":
+      return "Backups";
     default:
       return "";
   }
@@ -97,6 +102,7 @@ class App extends Component {
         deployment={this.state.operators.deployment}
         deploymentReplication={this.state.operators.deployment_replication}
         storage={this.state.operators.storage}
+        backup={this.state.operators.backup}
         otherOperators={this.state.operators.other || []}
         pod={this.state.operators.pod}
         namespace={this.state.operators.namespace}
@@ -23,10 +23,15 @@ class Auth extends Component {
   state = {
     authenticated: false,
     showLoading: true,
-    token: getSessionItem(tokenSessionKey) || ""
+    token: getSessionItem(tokenSessionKey) || "",
+    oidc: false
   };
 
   async componentDidMount() {
+    try {
+      const methods = await api.get('/login/methods');
+      this.setState({ oidc: methods.oidc });
+    } catch (e) {}
     try {
       api.token = this.state.token;
       await api.get('/api/operators');
@@ -83,7 +88,7 @@ class Auth extends Component {
       <LogoutContext.Provider value={this.handleLogout}>
         {(this.state.showLoading) ? <Loading/> : 
            (!this.state.authenticated) ? 
-            <Login doLogin={this.handleLogin} error={this.state.error}/> :
+            <Login doLogin={this.handleLogin} oidc={this.state.oidc} error={this.state.error}/> :
             this.props.children
         }
       </LogoutContext.Provider>
@@ -44,6 +44,10 @@ class Login extends Component {
           />
         </Modal.Content>
         <Modal.Actions>
+          {(this.props.oidc) ?
+            <Button as='a' href='/login/oidc'>
+              <Icon name='sign in' /> Login with SSO
+            </Button> : null}
           <Button color='green' disabled={((!this.state.username) || (!this.state.password))} onClick={this.handleLogin}>
             <Icon name='checkmark' /> Login
           </Button>
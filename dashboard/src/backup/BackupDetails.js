@@ -0,0 +1,132 @@
+import { Header, Loader, Segment } from 'semantic-ui-react';
+import { Link } from "react-router-dom";
+import React, { Component } from 'react';
+import ReactTimeout from 'react-timeout';
+
+import { Field, FieldContent as FC, FieldLabel as FL } from '../style/style';
+import { LoaderBox } from '../style/style';
+import { withAuth } from '../auth/Auth';
+import { formatSize } from './BackupTable';
+import api, { isUnauthorized } from '../api/api';
+import Loading from '../util/Loading';
+
+const TransferView = ({title, transfer}) => (
+  <Segment>
+    <Header>{title}</Header>
+    <Field>
+      <FL>Status</FL>
+      <FC>{transfer.status || "-"}</FC>
+    </Field>
+    <Field>
+      <FL>Repository</FL>
+      <FC><code>{transfer.repository_url || "-"}</code></FC>
+    </Field>
+  </Segment>
+);
+
+const DetailsView = ({backup, loading}) => (
+  <div>
+    <LoaderBox><Loader size="mini" active={loading} inline/></LoaderBox>
+    <Segment>
+      <Header>State</Header>
+      <Field>
+        <FL>State</FL>
+        <FC>{backup.state || "-"}</FC>
+      </Field>
+      <Field>
+        <FL>Since</FL>
+        <FC>{backup.state_time}</FC>
+      </Field>
+      <Field>
+        <FL>Message</FL>
+        <FC>{backup.message || "-"}</FC>
+      </Field>
+      <Field>
+        <FL>Progress</FL>
+        <FC>{backup.progress || "-"} {(backup.job_id) ? <code>(job {backup.job_id})</code> : null}</FC>
+      </Field>
+      <Field>
+        <FL>Available</FL>
+        <FC>{backup.available ? "Yes" : "No"}</FC>
+      </Field>
+    </Segment>
+    <Segment>
+      <Header>Backup</Header>
+      <Field>
+        <FL>Deployment</FL>
+        <FC>{backup.deployment}</FC>
+      </Field>
+      <Field>
+        <FL>Policy</FL>
+        <FC>{(backup.policy) ? <Link to={`/backup-policy/${backup.namespace}/${backup.policy}`}>{backup.policy}</Link> : "-"}</FC>
+      </Field>
+      <Field>
+        <FL>ID</FL>
+        <FC><code>{backup.backup_id || "-"}</code></FC>
+      </Field>
+      <Field>
+        <FL>Version</FL>
+        <FC>{backup.version || "-"}</FC>
+      </Field>
+      <Field>
+        <FL>Created</FL>
+        <FC>{backup.created_at || "-"}</FC>
+      </Field>
+      <Field>
+        <FL>Size</FL>
+        <FC>{formatSize(backup.size_in_bytes)}</FC>
+      </Field>
+      <Field>
+        <FL>DBServers</FL>
+        <FC>{backup.dbservers || "-"}</FC>
+      </Field>
+    </Segment>
+    <TransferView title="Upload" transfer={backup.upload}/>
+    <TransferView title="Download" transfer={backup.download}/>
+  </div>
+);
+
+class BackupDetails extends Component {
+  state = {
+    loading: true,
+    error: undefined
+  };
+
+  componentDidMount() {
+    this.reloadBackup();
+  }
+
+  reloadBackup = async() => {
+    try {
+      this.setState({
+        loading: true
+      });
+      const result = await api.get(`/api/backup/${this.props.name}?namespace=${encodeURIComponent(this.props.namespace)}`);
+      this.setState({
+        backup: result,
+        loading: false,
+        error: undefined
+      });
+    } catch (e) {
+      this.setState({
+        loading: false,
+        error: e.message
+      });
+      if (isUnauthorized(e)) {
+        this.props.doLogout();
+        return;
+      }
+    }
+    this.props.setTimeout(this.reloadBackup, 5000);
+  }
+
+  render() {
+    const backup = this.state.backup;
+    if (!backup) {
+      return (<Loading/>);
+    }
+    return (<DetailsView backup={backup} loading={this.state.loading}/>);
+  }
+}
+
+export default ReactTimeout(withAuth(BackupDetails));
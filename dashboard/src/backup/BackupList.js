@@ -0,0 +1,53 @@
+import React, { Component } from 'react';
+import ReactTimeout from 'react-timeout';
+
+import { withAuth } from '../auth/Auth';
+import api, { isUnauthorized } from '../api/api';
+import BackupTable from './BackupTable';
+import Loading from '../util/Loading';
+
+const EmptyView = () => (<div>No backups</div>);
+
+class BackupList extends Component {
+  state = {
+    items: null,
+    error: null,
+    loading: true
+  };
+
+  componentDidMount() {
+    this.reloadBackups();
+  }
+
+  reloadBackups = async() => {
+    try {
+      this.setState({loading: true});
+      const result = await api.get('/api/backup');
+      this.setState({
+        items: result.backups,
+        loading: false,
+        error: null
+      });
+    } catch (e) {
+      this.setState({error: e.message, loading: false});
+      if (isUnauthorized(e)) {
+        this.props.doLogout();
+        return;
+      }
+    }
+    this.props.setTimeout(this.reloadBackups, 5000);
+  }
+
+  render() {
+    const items = this.state.items;
+    if (!items) {
+      return (<Loading/>);
+    }
+    if (items.length === 0) {
+      return (<EmptyView/>);
+    }
+    return (<BackupTable items={items} loading={this.state.loading}/>);
+  }
+}
+
+export default ReactTimeout(withAuth(BackupList));
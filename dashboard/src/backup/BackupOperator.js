@@ -0,0 +1,92 @@
+import { BrowserRouter as Router, Route, Link } from "react-router-dom";
+import { Header, Menu, Message, Segment } from 'semantic-ui-react';
+import React, { Component } from 'react';
+
+import { StyledMenu, StyledContentBox } from '../style/style';
+import BackupDetails from './BackupDetails';
+import BackupList from './BackupList';
+import BackupPolicyDetails from './BackupPolicyDetails';
+import BackupPolicyList from './BackupPolicyList';
+import LogoutContext from '../auth/LogoutContext';
+
+const ListView = () => (
+  <div>
+    <Header dividing>
+      ArangoBackup resources
+    </Header>
+    <BackupList/>
+  </div>
+);
+
+const DetailView = ({match}) => (
+  <div>
+    <Header dividing>
+      ArangoBackup {match.params.name}
+    </Header>
+    <BackupDetails name={match.params.name} namespace={match.params.namespace}/>
+  </div>
+);
+
+const PolicyListView = () => (
+  <div>
+    <Header dividing>
+      ArangoBackupPolicy resources
+    </Header>
+    <BackupPolicyList/>
+  </div>
+);
+
+const PolicyDetailView = ({match}) => (
+  <div>
+    <Header dividing>
+      ArangoBackupPolicy {match.params.name}
+    </Header>
+    <BackupPolicyDetails name={match.params.name} namespace={match.params.namespace}/>
+  </div>
+);
+
+class BackupOperator extends Component {
+  render() {
+    return (
+      <Router>
+        <div>
+          <LogoutContext.Consumer>
+            {doLogout => 
+              <StyledMenu fixed="left" vertical>
+                <Menu.Item>
+                  <Menu.Header>Backup Operator</Menu.Header>
+                  <Menu.Menu>
+                    <Menu.Item>
+                      <Link to="/">Backups</Link>
+                    </Menu.Item>
+                    <Menu.Item>
+                      <Link to="/backup-policy">Backup policies</Link>
+                    </Menu.Item>
+                    <Menu.Item position="right" onClick={() => doLogout()}>
+                      Logout
+                    </Menu.Item>
+                  </Menu.Menu>
+                </Menu.Item>
+                {this.props.commonMenuItems}
+              </StyledMenu>
+            }
+          </LogoutContext.Consumer>
+          <StyledContentBox>
+            <Segment basic clearing>
+                <div>
+                  <Route exact path="/" component={ListView} />
+                  <Route path="/backup/:namespace/:name" component={DetailView} />
+                  <Route exact path="/backup-policy" component={PolicyListView} />
+                  <Route path="/backup-policy/:namespace/:name" component={PolicyDetailView} />
+                </div>
+            </Segment>
+            {this.props.podInfoView}
+            {(this.props.error) ? <Segment basic><Message error content={this.props.error}/></Segment> : null}
+          </StyledContentBox>
+        </div>
+      </Router>
+    );
+  }
+}
+
+export default BackupOperator;
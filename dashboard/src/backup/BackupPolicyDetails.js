@@ -0,0 +1,86 @@
+import { Header, Loader, Segment } from 'semantic-ui-react';
+import React, { Component } from 'react';
+import ReactTimeout from 'react-timeout';
+
+import { Field, FieldContent as FC, FieldLabel as FL } from '../style/style';
+import { LoaderBox } from '../style/style';
+import { withAuth } from '../auth/Auth';
+import api, { isUnauthorized } from '../api/api';
+import BackupTable from './BackupTable';
+import Loading from '../util/Loading';
+
+const DetailsView = ({policy, loading}) => (
+  <div>
+    <LoaderBox><Loader size="mini" active={loading} inline/></LoaderBox>
+    <Segment>
+      <Header>Schedule</Header>
+      <Field>
+        <FL>Schedule</FL>
+        <FC><code>{policy.schedule}</code></FC>
+      </Field>
+      <Field>
+        <FL>Next backup</FL>
+        <FC>{policy.next_schedule || "-"}</FC>
+      </Field>
+      <Field>
+        <FL>Message</FL>
+        <FC>{policy.message || "-"}</FC>
+      </Field>
+      <Field>
+        <FL>Deployments</FL>
+        <FC><code>{policy.deployment_selector || "all"}</code></FC>
+      </Field>
+      <Field>
+        <FL>Upload to</FL>
+        <FC><code>{policy.upload_repository_url || "-"}</code></FC>
+      </Field>
+    </Segment>
+    <Header>Backups</Header>
+    {(policy.backups.length > 0) ? <BackupTable items={policy.backups} loading={loading}/> : <div>No backups</div>}
+  </div>
+);
+
+class BackupPolicyDetails extends Component {
+  state = {
+    loading: true,
+    error: undefined
+  };
+
+  componentDidMount() {
+    this.reloadBackupPolicy();
+  }
+
+  reloadBackupPolicy = async() => {
+    try {
+      this.setState({
+        loading: true
+      });
+      const result = await api.get(`/api/backup-policy/${this.props.name}?namespace=${encodeURIComponent(this.props.namespace)}`);
+      this.setState({
+        policy: result,
+        loading: false,
+        error: undefined
+      });
+    } catch (e) {
+      this.setState({
+        loading: false,
+        error: e.message
+      });
+      if (isUnauthorized(e)) {
+        this.props.doLogout();
+        return;
+      }
+    }
+    this.props.setTimeout(this.reloadBackupPolicy, 5000);
+  }
+
+  render() {
+    const policy = this.state.policy;
+    if (!policy) {
+      return (<Loading/>);
+    }
+    return (<DetailsView policy={policy} loading={this.state.loading}/>);
+  }
+}
+
+export default ReactTimeout(withAuth(BackupPolicyDetails));
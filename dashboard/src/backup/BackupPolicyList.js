@@ -0,0 +1,129 @@
+import { Icon, Loader, Popup, Table } from 'semantic-ui-react';
+import { Link } from "react-router-dom";
+import React, { Component } from 'react';
+import ReactTimeout from 'react-timeout';
+
+import { LoaderBoxForTable as LoaderBox } from '../style/style';
+import { withAuth } from '../auth/Auth';
+import api, { isUnauthorized } from '../api/api';
+import CommandInstruction from '../util/CommandInstruction';
+import Loading from '../util/Loading';
+
+const HeaderView = ({loading}) => (
+  <Table.Header>
+    <Table.Row>
+      <Table.HeaderCell>State</Table.HeaderCell>
+      <Table.HeaderCell>Name</Table.HeaderCell>
+      <Table.HeaderCell>Schedule</Table.HeaderCell>
+      <Table.HeaderCell>Next backup</Table.HeaderCell>
+      <Table.HeaderCell>
+        Actions
+        <LoaderBox><Loader size="mini" active={loading} inline/></LoaderBox>
+      </Table.HeaderCell>
+    </Table.Row>
+  </Table.Header>
+);
+
+const RowView = ({name, namespace, stateColor, message, schedule, nextSchedule}) => (
+  <Table.Row>
+    <Table.Cell>
+      <Popup trigger={<Icon name={(stateColor==="green") ? "check" : "bell"} color={stateColor}/>}>
+        {message || getStateColorDescription(stateColor)}
+      </Popup>
+    </Table.Cell>
+    <Table.Cell>
+      <Link to={`/backup-policy/${namespace}/${name}`}>
+        {name}
+      </Link>
+    </Table.Cell>
+    <Table.Cell><code>{schedule}</code></Table.Cell>
+    <Table.Cell>{nextSchedule || "-"}</Table.Cell>
+    <Table.Cell>
+      <CommandInstruction 
+          trigger={<Icon link name="zoom"/>}
+          command={`kubectl describe ArangoBackupPolicy -n ${namespace} ${name}`}
+          title="Describe backup policy"
+          description="To get more information on the state of this backup policy, run:"
+        />
+    </Table.Cell>
+  </Table.Row>
+);
+
+const ListView = ({items, loading}) => (
+  <Table striped celled>
+    <HeaderView loading={loading}/>
+    <Table.Body>
+      {
+        items.map((item) => 
+          <RowView 
+            key={`${item.namespace}/${item.name}`}
+            name={item.name}
+            namespace={item.namespace}
+            stateColor={item.state_color}
+            message={item.message}
+            schedule={item.schedule}
+            nextSchedule={item.next_schedule}
+          />)
+      }
+    </Table.Body>
+  </Table>
+);
+
+const EmptyView = () => (<div>No backup policies</div>);
+
+function getStateColorDescription(stateColor) {
+  switch (stateColor) {
+    case "green":
+      return "Next backup is scheduled.";
+    case "yellow":
+      return "Backup policy is not scheduled yet.";
+    case "red":
+      return "The backup policy is in a bad state and manual intervention is likely needed.";
+    default:
+      return "State is not known.";
+  }
+}
+
+class BackupPolicyList extends Component {
+  state = {
+    items: null,
+    error: null,
+    loading: true
+  };
+
+  componentDidMount() {
+    this.reloadBackupPolicies();
+  }
+
+  reloadBackupPolicies = async() => {
+    try {
+      this.setState({loading: true});
+      const result = await api.get('/api/backup-policy');
+      this.setState({
+        items: result.policies,
+        loading: false,
+        error: null
+      });
+    } catch (e) {
+      this.setState({error: e.message, loading: false});
+      if (isUnauthorized(e)) {
+        this.props.doLogout();
+        return;
+      }
+    }
+    this.props.setTimeout(this.reloadBackupPolicies, 5000);
+  }
+
+  render() {
+    const items = this.state.items;
+    if (!items) {
+      return (<Loading/>);
+    }
+    if (items.length === 0) {
+      return (<EmptyView/>);
+    }
+    return (<ListView items={items} loading={this.state.loading}/>);
+  }
+}
+
+export default ReactTimeout(withAuth(BackupPolicyList));
@@ -0,0 +1,123 @@
+import { Icon, Loader, Popup, Table } from 'semantic-ui-react';
+import { Link } from "react-router-dom";
+import React from 'react';
+
+import { LoaderBoxForTable as LoaderBox } from '../style/style';
+import CommandInstruction from '../util/CommandInstruction';
+
+const HeaderView = ({loading}) => (
+  <Table.Header>
+    <Table.Row>
+      <Table.HeaderCell>State</Table.HeaderCell>
+      <Table.HeaderCell>Name</Table.HeaderCell>
+      <Table.HeaderCell>Deployment</Table.HeaderCell>
+      <Table.HeaderCell>Size</Table.HeaderCell>
+      <Table.HeaderCell>DBServers</Table.HeaderCell>
+      <Table.HeaderCell>Upload</Table.HeaderCell>
+      <Table.HeaderCell>Download</Table.HeaderCell>
+      <Table.HeaderCell>
+        Actions
+        <LoaderBox><Loader size="mini" active={loading} inline/></LoaderBox>
+      </Table.HeaderCell>
+    </Table.Row>
+  </Table.Header>
+);
+
+const RowView = ({name, namespace, stateColor, state, progress, deployment, size, dbservers, upload, download}) => (
+  <Table.Row>
+    <Table.Cell>
+      <Popup trigger={<Icon name={(stateColor==="green") ? "check" : "bell"} color={stateColor}/>}>
+        {getStateColorDescription(stateColor)}
+      </Popup>
+      {state || "-"}
+      {(progress) ? ` (${progress})` : null}
+    </Table.Cell>
+    <Table.Cell>
+      <Link to={`/backup/${namespace}/${name}`}>
+        {name}
+      </Link>
+    </Table.Cell>
+    <Table.Cell>{deployment}</Table.Cell>
+    <Table.Cell>{formatSize(size)}</Table.Cell>
+    <Table.Cell>{dbservers || "-"}</Table.Cell>
+    <Table.Cell>{upload.status || "-"}</Table.Cell>
+    <Table.Cell>{download.status || "-"}</Table.Cell>
+    <Table.Cell>
+      <CommandInstruction 
+          trigger={<Icon link name="zoom"/>}
+          command={createDescribeCommand(name, namespace)}
+          title="Describe backup"
+          description="To get more information on the state of this backup, run:"
+        />
+      <span style={{"float":"right"}}>
+        <CommandInstruction 
+          trigger={<Icon link name="trash"/>}
+          command={createDeleteCommand(name, namespace)}
+          title="Delete backup"
+          description="To delete this backup, run:"
+        />
+      </span>
+    </Table.Cell>
+  </Table.Row>
+);
+
+const BackupTable = ({items, loading}) => (
+  <Table striped celled>
+    <HeaderView loading={loading}/>
+    <Table.Body>
+      {
+        items.map((item) => 
+          <RowView 
+            key={`${item.namespace}/${item.name}`} 
+            name={item.name}
+            namespace={item.namespace}
+            stateColor={item.state_color}
+            state={item.state}
+            progress={item.progress}
+            deployment={item.deployment}
+            size={item.size_in_bytes}
+            dbservers={item.dbservers}
+            upload={item.upload}
+            download={item.download}
+          />)
+      }
+    </Table.Body>
+  </Table>
+);
+
+function createDeleteCommand(name, namespace) {
+  return `kubectl delete ArangoBackup -n ${namespace} ${name}`;
+}
+
+function createDescribeCommand(name, namespace) {
+  return `kubectl describe ArangoBackup -n ${namespace} ${name}`;
+}
+
+function getStateColorDescription(stateColor) {
+  switch (stateColor) {
+    case "green":
+      return "Backup is ready.";
+    case "yellow":
+      return "Backup is being created, uploaded or downloaded.";
+    case "red":
+      return "The backup is in a bad state and manual intervention is likely needed.";
+    default:
+      return "State is not known.";
+  }
+}
+
+// formatSize returns the given number of bytes in human readable form.
+export function formatSize(bytes) {
+  if (!bytes) {
+    return "-";
+  }
+  const units = ["B", "KiB", "MiB", "GiB", "TiB"];
+  let i = 0;
+  while (bytes >= 1024 && i < units.length - 1) {
+    bytes /= 1024;
+    i++;
+  }
+  return `${bytes.toFixed(i === 0 ? 0 : 1)} ${units[i]}`;
+}
+
+export default BackupTable;
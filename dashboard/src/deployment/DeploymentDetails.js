@@ -5,8 +5,10 @@ import ReactTimeout from 'react-timeout';
 import { LoaderBox } from '../style/style';
 import { withAuth } from '../auth/Auth.js';
 import api, { isUnauthorized } from '../api/api';
+import EventLog from './EventLog';
 import Loading from '../util/Loading';
 import MemberList from './MemberList';
+import ShardDistribution from './ShardDistribution';
 
 const MemberGroupsView = ({memberGroups, namespace}) => (
   <div>
@@ -34,7 +36,7 @@ class DeploymentDetails extends Component {
       this.setState({
         loading: true
       });
-      const result = await api.get(`/api/deployment/${this.props.name}`);
+      const result = await api.get(`/api/deployment/${this.props.name}?namespace=${encodeURIComponent(this.props.namespace)}`);
       this.setState({
         deployment: result,
         loading: false,
@@ -62,6 +64,8 @@ class DeploymentDetails extends Component {
       <div>
         <LoaderBox><Loader size="mini" active={this.state.loading} inline/></LoaderBox>
         <MemberGroupsView memberGroups={d.member_groups} namespace={d.namespace}/>
+        {(d.mode === "Cluster") && <ShardDistribution name={this.props.name} namespace={this.props.namespace}/>}
+        <EventLog name={this.props.name} namespace={this.props.namespace}/>
       </div>
       );
   }
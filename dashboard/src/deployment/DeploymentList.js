@@ -41,7 +41,7 @@ const NoDatabaseLinkView = () => (
   </Popup>
 );
 
-const RowView = ({name, mode, environment, stateColor, version, license, readyPodCount, podCount, readyVolumeCount, volumeCount, storageClasses, databaseURL, deleteCommand, describeCommand}) => (
+const RowView = ({name, namespace, mode, environment, stateColor, version, license, readyPodCount, podCount, readyVolumeCount, volumeCount, storageClasses, databaseURL, deleteCommand, describeCommand}) => (
   <Table.Row>
     <Table.Cell>
       <Popup trigger={<Icon name={(stateColor==="green") ? "check" : "bell"} color={stateColor}/>}>
@@ -49,7 +49,7 @@ const RowView = ({name, mode, environment, stateColor, version, license, readyPo
       </Popup>
     </Table.Cell>
     <Table.Cell>
-      <Link to={`/deployment/${name}`}>
+      <Link to={`/deployment/${namespace}/${name}`}>
         {name}
       </Link>
     </Table.Cell>
@@ -97,7 +97,7 @@ const ListView = ({items, loading}) => (
       {
         (items) ? items.map((item) => 
           <RowView 
-            key={item.name} 
+            key={`${item.namespace}/${item.name}`}
             name={item.name}
             namespace={item.namespace}
             mode={item.mode}
@@ -21,7 +21,7 @@ const DetailView = ({match}) => (
     <Header dividing>
       ArangoDeployment {match.params.name}
     </Header>
-    <DeploymentDetails name={match.params.name}/>
+    <DeploymentDetails name={match.params.name} namespace={match.params.namespace}/>
   </div>
 );
 
@@ -52,7 +52,7 @@ class DeploymentOperator extends Component {
             <Segment basic clearing>
                 <div>
                   <Route exact path="/" component={ListView} />
-                  <Route path="/deployment/:name" component={DetailView} />
+                  <Route path="/deployment/:namespace/:name" component={DetailView} />
                 </div>
             </Segment>
             {this.props.podInfoView}
@@ -0,0 +1,128 @@
+import { Header, Segment, Table } from 'semantic-ui-react';
+import React, { Component } from 'react';
+import ReactTimeout from 'react-timeout';
+
+import { withAuth } from '../auth/Auth.js';
+import api, { isUnauthorized } from '../api/api';
+
+const maxEvents = 50;
+
+const RowView = ({event}) => (
+  <Table.Row>
+    <Table.Cell>{event.time}</Table.Cell>
+    <Table.Cell>{event.type}</Table.Cell>
+    <Table.Cell>{describeEvent(event)}</Table.Cell>
+  </Table.Row>
+);
+
+const EventLogView = ({events}) => (
+  <Segment>
+    <Header>Live events</Header>
+    {(events.length === 0) ? <div>Waiting for events...</div> :
+      <Table compact striped celled>
+        <Table.Body>
+          {events.map((event) => <RowView key={event.id} event={event}/>)}
+        </Table.Body>
+      </Table>
+    }
+  </Segment>
+);
+
+function describeEvent(event) {
+  const data = event.data || {};
+  switch (event.type) {
+    case "status": {
+      const members = data.members || [];
+      return `Phase ${data.phase || "-"}, ${members.filter((m) => m.ready).length}/${members.length} members ready`;
+    }
+    case "plan":
+      return `${data.action_type} ${data.state} (${data.plan} plan)` + ((data.member_id) ? ` for ${data.group} ${data.member_id}` : "");
+    case "event":
+      return `${data.reason}: ${data.message}`;
+    default:
+      return "";
+  }
+}
+
+// parseEvents returns the events found in the given Server-Sent Events buffer and the remaining (incomplete) part of it.
+function parseEvents(buffer) {
+  const events = [];
+  const blocks = buffer.split("\n\n");
+  const rest = blocks.pop();
+  for (const block of blocks) {
+    const line = block.split("\n").find((l) => l.startsWith("data: "));
+    if (line) {
+      try {
+        events.push(JSON.parse(line.substring(6)));
+      } catch (e) {}
+    }
+  }
+  return [events, rest];
+}
+
+class EventLog extends Component {
+  state = {
+    events: []
+  };
+
+  lastEventID = undefined;
+  controller = undefined;
+
+  componentDidMount() {
+    this.stream();
+  }
+
+  componentWillUnmount() {
+    if (this.controller) {
+      this.controller.abort();
+    }
+  }
+
+  // stream reads events until the server closes the stream, then reconnects with the last received event ID.
+  stream = async() => {
+    this.controller = new AbortController();
+    try {
+      const headers = { 'Accept': 'text/event-stream' };
+      if (api.token) {
+        headers['Authorization'] = `bearer ${api.token}`;
+      }
+      if (this.lastEventID) {
+        headers['Last-Event-ID'] = `${this.lastEventID}`;
+      }
+      const result = await fetch(`/api/deployment/${this.props.name}/events?namespace=${encodeURIComponent(this.props.namespace)}`, {headers, signal: this.controller.signal});
+      if (result.status !== 200) {
+        await api.decodeResults(result);
+      }
+      const reader = result.body.getReader();
+      const decoder = new TextDecoder();
+      let buffer = "";
+      for (;;) {
+        const { done, value } = await reader.read();
+        if (done) {
+          break;
+        }
+        let events;
+        [events, buffer] = parseEvents(buffer + decoder.decode(value, {stream: true}));
+        if (events.length > 0) {
+          this.lastEventID = events[events.length - 1].id;
+          this.setState({events: events.reverse().concat(this.state.events).slice(0, maxEvents)});
+        }
+      }
+    } catch (e) {
+      if (e.name === 'AbortError') {
+        return;
+      }
+      if (isUnauthorized(e)) {
+        this.props.doLogout();
+        return;
+      }
+    }
+    this.props.setTimeout(this.stream, 1000);
+  }
+
+  render() {
+    return (<EventLogView events={this.state.events}/>);
+  }
+}
+
+export default ReactTimeout(withAuth(EventLog));
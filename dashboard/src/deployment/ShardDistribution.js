@@ -0,0 +1,155 @@
+import { Header, Label, Loader, Segment, Table } from 'semantic-ui-react';
+import React, { Component } from 'react';
+import ReactTimeout from 'react-timeout';
+
+import { LoaderBox } from '../style/style';
+import { withAuth } from '../auth/Auth.js';
+import api, { isUnauthorized } from '../api/api';
+
+const HealthLabel = ({health}) => {
+  switch (health) {
+    case "GOOD":
+      return (<Label color="green" size="tiny">{health}</Label>);
+    case "BAD":
+      return (<Label color="orange" size="tiny">{health}</Label>);
+    case "FAILED":
+      return (<Label color="red" size="tiny">{health}</Label>);
+    default:
+      return (<Label size="tiny">{health || "?"}</Label>);
+  }
+};
+
+const ServersView = ({servers}) => (
+  <Table celled compact>
+    <Table.Header>
+      <Table.Row>
+        <Table.HeaderCell>DBServer</Table.HeaderCell>
+        <Table.HeaderCell>Health</Table.HeaderCell>
+        <Table.HeaderCell>Shards</Table.HeaderCell>
+        <Table.HeaderCell>Leaders</Table.HeaderCell>
+        <Table.HeaderCell>Followers</Table.HeaderCell>
+        <Table.HeaderCell>Out of sync</Table.HeaderCell>
+      </Table.Row>
+    </Table.Header>
+    <Table.Body>
+      {Object.keys(servers).sort().map((id) => {
+        const s = servers[id];
+        return (
+          <Table.Row key={id}>
+            <Table.Cell><code>{id}</code>{s.short_name ? ` (${s.short_name})` : ""}</Table.Cell>
+            <Table.Cell><HealthLabel health={s.health}/></Table.Cell>
+            <Table.Cell>{s.leaders + s.followers}</Table.Cell>
+            <Table.Cell>{s.leaders}</Table.Cell>
+            <Table.Cell>{s.followers}</Table.Cell>
+            <Table.Cell negative={s.out_of_sync > 0}>{s.out_of_sync}</Table.Cell>
+          </Table.Row>
+        );
+      })}
+    </Table.Body>
+  </Table>
+);
+
+const OutOfSyncView = ({shards}) => (
+  <Table celled compact>
+    <Table.Header>
+      <Table.Row>
+        <Table.HeaderCell>Database</Table.HeaderCell>
+        <Table.HeaderCell>Collection</Table.HeaderCell>
+        <Table.HeaderCell>Shard</Table.HeaderCell>
+        <Table.HeaderCell>Planned</Table.HeaderCell>
+        <Table.HeaderCell>In sync</Table.HeaderCell>
+        <Table.HeaderCell>Out of sync</Table.HeaderCell>
+      </Table.Row>
+    </Table.Header>
+    <Table.Body>
+      {shards.map((s) => (
+        <Table.Row key={`${s.database}/${s.collection}/${s.shard}`}>
+          <Table.Cell>{s.database}</Table.Cell>
+          <Table.Cell>{s.collection}</Table.Cell>
+          <Table.Cell><code>{s.shard}</code></Table.Cell>
+          <Table.Cell>{(s.planned || []).join(", ")}</Table.Cell>
+          <Table.Cell>{(s.current || []).join(", ")}</Table.Cell>
+          <Table.Cell negative>{(s.out_of_sync || []).join(", ")}</Table.Cell>
+        </Table.Row>
+      ))}
+    </Table.Body>
+  </Table>
+);
+
+const JobsView = ({jobs}) => (
+  <Table celled compact>
+    <Table.Header>
+      <Table.Row>
+        <Table.HeaderCell>ID</Table.HeaderCell>
+        <Table.HeaderCell>Type</Table.HeaderCell>
+        <Table.HeaderCell>State</Table.HeaderCell>
+        <Table.HeaderCell>Created</Table.HeaderCell>
+        <Table.HeaderCell>Target</Table.HeaderCell>
+      </Table.Row>
+    </Table.Header>
+    <Table.Body>
+      {jobs.map((j) => (
+        <Table.Row key={j.jobId}>
+          <Table.Cell><code>{j.jobId}</code></Table.Cell>
+          <Table.Cell>{j.type}</Table.Cell>
+          <Table.Cell>{j.state}</Table.Cell>
+          <Table.Cell>{j.timeCreated || "-"}</Table.Cell>
+          <Table.Cell>{[j.database, j.collection, j.shard, j.server, j.fromServer && `${j.fromServer} -> ${j.toServer}`].filter((x) => x).join(" ")}</Table.Cell>
+        </Table.Row>
+      ))}
+    </Table.Body>
+  </Table>
+);
+
+class ShardDistribution extends Component {
+  state = {
+    loading: true,
+    error: undefined
+  };
+
+  componentDidMount() {
+    this.reloadShards();
+  }
+
+  reloadShards = async() => {
+    try {
+      this.setState({
+        loading: true
+      });
+      const result = await api.get(`/api/deployment/${this.props.name}/shards?namespace=${encodeURIComponent(this.props.namespace)}`);
+      this.setState({
+        shards: result,
+        loading: false,
+        error: undefined
+      });
+    } catch (e) {
+      this.setState({
+        loading: false,
+        error: e.message
+      });
+      if (isUnauthorized(e)) {
+        this.props.doLogout();
+        return;
+      }
+    }
+    this.props.setTimeout(this.reloadShards, 10000);
+  }
+
+  render() {
+    const d = this.state.shards;
+    return (
+      <Segment>
+        <Header>Shards {d && d.maintenance && <Label color="yellow" size="tiny">Maintenance</Label>}</Header>
+        <LoaderBox><Loader size="mini" active={this.state.loading} inline/></LoaderBox>
+        {this.state.error && <div>{this.state.error}</div>}
+        {d && <ServersView servers={d.servers}/>}
+        {d && <Header as="h4">Out of sync shards</Header>}
+        {d && ((d.out_of_sync.length > 0) ? <OutOfSyncView shards={d.out_of_sync}/> : <div>All shards in sync</div>)}
+        {d && <Header as="h4">Pending jobs</Header>}
+        {d && ((d.pending_jobs.length > 0) ? <JobsView jobs={d.pending_jobs}/> : <div>No pending jobs</div>)}
+      </Segment>
+    );
+  }
+}
+
+export default ReactTimeout(withAuth(ShardDistribution));
@@ -1,4 +1,4 @@
-import { Header, Loader, Segment } from 'semantic-ui-react';
+import { Header, Icon, Loader, Segment } from 'semantic-ui-react';
 import React, { Component } from 'react';
 import ReactTimeout from 'react-timeout';
 
@@ -8,7 +8,29 @@ import { withAuth } from '../auth/Auth';
 import api, { isUnauthorized } from '../api/api';
 import Loading from '../util/Loading';
 
-const EndpointView = ({title, deploymentName, masterEndpoint, authKeyfileSecretName, authUserSecretName, tlsCACert, tlsCACertSecretName}) => (
+const HealthView = ({reachable, consecutiveFailures, lastSuccessTime, lastError}) => (
+  <div>
+    <Header sub>Health</Header>
+    <Field>
+      <FL>Reachable</FL>
+      <FC>{reachable ? <Icon name="check" color="green"/> : <Icon name="times" color="red"/>}</FC>
+    </Field>
+    <Field>
+      <FL>Consecutive failures</FL>
+      <FC>{consecutiveFailures || 0}</FC>
+    </Field>
+    <Field>
+      <FL>Last success</FL>
+      <FC>{lastSuccessTime ? new Date(lastSuccessTime).toLocaleString() : "-"}</FC>
+    </Field>
+    <Field>
+      <FL>Last error</FL>
+      <FC>{lastError ? <code>{lastError}</code> : "-"}</FC>
+    </Field>
+  </div>
+);
+
+const EndpointView = ({title, deploymentName, masterEndpoint, authKeyfileSecretName, authUserSecretName, tlsCACert, tlsCACertSecretName, health}) => (
   <Segment>
     <Header>{title}</Header>
     <Field>
@@ -36,6 +58,12 @@ const EndpointView = ({title, deploymentName, masterEndpoint, authKeyfileSecretN
       <FL>TLS CA Certificate</FL>
       <FC><code>{tlsCACertSecretName || "-"}</code></FC>
     </Field>
+    <HealthView
+      reachable={health.reachable}
+      consecutiveFailures={health.consecutive_failures}
+      lastSuccessTime={health.last_success_time}
+      lastError={health.last_error}
+    />
   </Segment>
 );
 
@@ -50,6 +78,7 @@ const DetailsView = ({replication, loading}) => (
       authUserSecretName={replication.source.auth_user_secret_name}
       tlsCACert={replication.source.tls_ca_cert}
       tlsCACertSecretName={replication.source.tls_ca_cert_secret_name}
+      health={replication.source}
     />
     <EndpointView
       title="Destination"
@@ -59,6 +88,7 @@ const DetailsView = ({replication, loading}) => (
       authUserSecretName={replication.destination.auth_user_secret_name}
       tlsCACert={replication.destination.tls_ca_cert}
       tlsCACertSecretName={replication.destination.tls_ca_cert_secret_name}
+      health={replication.destination}
     />
   </div>
 );
@@ -78,7 +108,7 @@ class DeploymentReplicationDetails extends Component {
       this.setState({
         loading: true
       });
-      const result = await api.get(`/api/deployment-replication/${this.props.name}`);
+      const result = await api.get(`/api/deployment-replication/${this.props.name}?namespace=${encodeURIComponent(this.props.namespace)}`);
       this.setState({
         replication: result,
         loading: false,
@@ -24,7 +24,7 @@ const HeaderView = ({loading}) => (
   </Table.Header>
 );
 
-const RowView = ({name, mode, stateColor, source, destination, deleteCommand, describeCommand}) => (
+const RowView = ({name, namespace, mode, stateColor, source, destination, deleteCommand, describeCommand}) => (
   <Table.Row>
     <Table.Cell>
       <Popup trigger={<Icon name={(stateColor==="green") ? "check" : "bell"} color={stateColor}/>}>
@@ -32,7 +32,7 @@ const RowView = ({name, mode, stateColor, source, destination, deleteCommand, de
       </Popup>
     </Table.Cell>
     <Table.Cell>
-      <Link to={`/deployment-replication/${name}`}>
+      <Link to={`/deployment-replication/${namespace}/${name}`}>
         {name}
       </Link>
     </Table.Cell>
@@ -68,7 +68,7 @@ const ListView = ({items, loading}) => (
       {
         (items) ? items.map((item) => 
           <RowView 
-            key={item.name} 
+            key={`${item.namespace}/${item.name}`}
             name={item.name}
             namespace={item.namespace}
             stateColor={item.state_color}
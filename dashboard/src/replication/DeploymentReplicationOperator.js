@@ -21,7 +21,7 @@ const DetailView = ({match}) => (
     <Header dividing>
       ArangoDeploymentReplication {match.params.name}
     </Header>
-    <DeploymentReplicationDetails name={match.params.name}/>
+    <DeploymentReplicationDetails name={match.params.name} namespace={match.params.namespace}/>
   </div>
 );
 
@@ -52,7 +52,7 @@ class DeploymentReplicationOperator extends Component {
             <Segment basic clearing>
                 <div>
                   <Route exact path="/" component={ListView} />
-                  <Route path="/deployment-replication/:name" component={DetailView} />
+                  <Route path="/deployment-replication/:namespace/:name" component={DetailView} />
                 </div>
             </Segment>
             {this.props.podInfoView}
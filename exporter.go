@@ -49,6 +49,14 @@ var (
 		timeout  time.Duration
 
 		keyfile string
+
+		cacheTTL    time.Duration
+		staleTTL    time.Duration
+		maxFailures int
+
+		labels []string
+		allow  []string
+		deny   []string
 	}
 )
 
@@ -62,6 +70,14 @@ func init() {
 	f.StringVar(&exporterInput.jwtFile, "arangodb.jwt-file", "", "File containing the JWT for authentication with ArangoDB server")
 	f.DurationVar(&exporterInput.timeout, "arangodb.timeout", time.Second*15, "Timeout of statistics requests for ArangoDB")
 
+	f.DurationVar(&exporterInput.cacheTTL, "cache.ttl", 0, "Time for which ArangoDB metrics are served from the cache. Cache is disabled if 0")
+	f.DurationVar(&exporterInput.staleTTL, "cache.stale-ttl", 0, "Time after cache TTL for which cached ArangoDB metrics are served if ArangoDB is not available")
+	f.IntVar(&exporterInput.maxFailures, "health.max-failures", 3, "Number of consecutive failed ArangoDB requests after which health endpoint fails. Disabled if 0")
+
+	f.StringArrayVar(&exporterInput.labels, "metrics.label", nil, "Label added to every exposed sample (key=value)")
+	f.StringArrayVar(&exporterInput.allow, "metrics.allow", nil, "Regular expression of metric names which are exposed. All metrics are exposed if not set")
+	f.StringArrayVar(&exporterInput.deny, "metrics.deny", nil, "Regular expression of metric names which are not exposed")
+
 	cmdMain.AddCommand(cmdExporter)
 }
 
@@ -84,6 +100,16 @@ func onSigterm(f func()) {
 }
 
 func cmdExporterCheckE() error {
+	labels, err := exporter.ParseLabels(exporterInput.labels)
+	if err != nil {
+		return err
+	}
+
+	relabel, err := exporter.NewRelabel(labels, exporterInput.allow, exporterInput.deny)
+	if err != nil {
+		return err
+	}
+
 	p, err := exporter.NewPassthru(exporterInput.endpoint, func() (string, error) {
 		if exporterInput.jwtFile == "" {
 			return "", nil
@@ -95,7 +121,11 @@ func cmdExporterCheckE() error {
 		}
 
 		return string(data), nil
-	}, false, 15*time.Second)
+	}, false, exporterInput.timeout, relabel, exporter.CacheConfig{
+		TTL:         exporterInput.cacheTTL,
+		StaleTTL:    exporterInput.staleTTL,
+		MaxFailures: exporterInput.maxFailures,
+	})
 	if err != nil {
 		return err
 	}
@@ -30,8 +30,10 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	operatorHTTP "github.com/arangodb/kube-arangodb/pkg/util/http"
@@ -67,12 +69,16 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/client"
 	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/scheme"
 	"github.com/arangodb/kube-arangodb/pkg/logging"
+	"github.com/arangodb/kube-arangodb/pkg/metrics"
 	"github.com/arangodb/kube-arangodb/pkg/operator"
 	"github.com/arangodb/kube-arangodb/pkg/server"
 	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/eventstream"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	"github.com/arangodb/kube-arangodb/pkg/util/probe"
 	"github.com/arangodb/kube-arangodb/pkg/util/retry"
+	"github.com/arangodb/kube-arangodb/pkg/util/tracing"
+	"github.com/arangodb/kube-arangodb/pkg/webhook"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog"
 )
@@ -80,11 +86,14 @@ import (
 const (
 	defaultServerHost           = "0.0.0.0"
 	defaultServerPort           = 8528
+	defaultWebhookPort          = 8529
+	defaultWebhookServicePort   = 443
 	defaultLogLevel             = "debug"
 	defaultAdminSecretName      = "arangodb-operator-dashboard"
 	defaultAlpineImage          = "alpine:3.7"
 	defaultMetricsExporterImage = "arangodb/arangodb-exporter:0.1.6"
 	defaultArangoImage          = "arangodb/arangodb:latest"
+	tracingShutdownTimeout      = 5 * time.Second
 
 	UBIImageEnv             util.EnvironmentVariable = "RELATED_IMAGE_UBI"
 	ArangoImageEnv          util.EnvironmentVariable = "RELATED_IMAGE_DATABASE"
@@ -108,6 +117,16 @@ var (
 		tlsSecretName   string
 		adminSecretName string // Name of basic authentication secret containing the admin username+password of the dashboard
 		allowAnonymous  bool   // If set, anonymous access to dashboard is allowed
+		oidc            struct {
+			issuerURL        string
+			clientID         string
+			clientSecretName string
+			redirectURL      string
+			usernameClaim    string
+			groupsClaim      string
+			scopes           []string
+		}
+		roleBindings []string // Mapping of OIDC groups to dashboard roles
 	}
 	operatorOptions struct {
 		enableDeployment            bool // Run deployment operator
@@ -128,11 +147,40 @@ var (
 	chaosOptions struct {
 		allowed bool
 	}
+	watchOptions struct {
+		namespaces        []string
+		namespaceSelector string
+		shardName         string
+		shardSelector     string
+		shardCount        int
+		shardIndex        int
+	}
+	leaderElectionOptions struct {
+		lockType      string
+		leaseDuration time.Duration
+		renewDeadline time.Duration
+		retryPeriod   time.Duration
+	}
+	webhookOptions struct {
+		enabled     bool
+		port        int
+		serviceName string
+		servicePort int
+		secretName  string
+		mutation    bool
+		conversion  bool
+	}
+	tracingOptions struct {
+		endpoint    string
+		insecure    bool
+		sampleRatio float64
+	}
 	livenessProbe              probe.LivenessProbe
 	deploymentProbe            probe.ReadyProbe
 	deploymentReplicationProbe probe.ReadyProbe
 	storageProbe               probe.ReadyProbe
 	backupProbe                probe.ReadyProbe
+	eventStream                = eventstream.NewStream(eventstream.DefaultHistorySize)
 )
 
 func init() {
@@ -143,6 +191,21 @@ func init() {
 	f.StringVar(&serverOptions.tlsSecretName, "server.tls-secret-name", "", "Name of secret containing tls.crt & tls.key for HTTPS server (if empty, self-signed certificate is used)")
 	f.StringVar(&serverOptions.adminSecretName, "server.admin-secret-name", defaultAdminSecretName, "Name of secret containing username + password for login to the dashboard")
 	f.BoolVar(&serverOptions.allowAnonymous, "server.allow-anonymous-access", false, "Allow anonymous access to the dashboard")
+	f.StringVar(&serverOptions.oidc.issuerURL, "server.oidc.issuer-url", "", "URL of the OpenID Connect issuer used for login to the dashboard (if empty, OIDC login is disabled)")
+	f.StringVar(&serverOptions.oidc.clientID, "server.oidc.client-id", "", "OpenID Connect client ID of the dashboard")
+	f.StringVar(&serverOptions.oidc.clientSecretName, "server.oidc.client-secret-name", "", "Name of secret containing the OpenID Connect client secret in the client-secret key")
+	f.StringVar(&serverOptions.oidc.redirectURL, "server.oidc.redirect-url", "", "Public URL of the dashboard OpenID Connect callback (https://<host>/login/oidc/callback)")
+	f.StringVar(&serverOptions.oidc.usernameClaim, "server.oidc.username-claim", "email", "ID token claim used as username")
+	f.StringVar(&serverOptions.oidc.groupsClaim, "server.oidc.groups-claim", "groups", "ID token claim containing groups of the user")
+	f.StringSliceVar(&serverOptions.oidc.scopes, "server.oidc.scopes", []string{"profile", "email"}, "Additional scopes requested from the OpenID Connect issuer")
+	f.StringArrayVar(&serverOptions.roleBindings, "server.role-binding", nil, "Dashboard role of the OIDC group, in format <group>=<viewer|operator|admin>[:<namespace>,...]")
+	f.BoolVar(&webhookOptions.enabled, "webhook.enabled", false, "Serve validating admission webhooks of the enabled operators")
+	f.IntVar(&webhookOptions.port, "webhook.port", defaultWebhookPort, "Port the admission webhooks listen on")
+	f.StringVar(&webhookOptions.serviceName, "webhook.service-name", "", "Name of the Service in front of the admission webhooks")
+	f.IntVar(&webhookOptions.servicePort, "webhook.service-port", defaultWebhookServicePort, "Port of the Service in front of the admission webhooks")
+	f.StringVar(&webhookOptions.secretName, "webhook.secret-name", "", "Name of secret containing the certificate of the admission webhooks, created when missing (defaults to <service-name>-certificate)")
+	f.BoolVar(&webhookOptions.mutation, "webhook.mutation", false, "Serve mutating admission webhooks, which apply the defaults to the specs of the resources (requires --webhook.enabled)")
+	f.BoolVar(&webhookOptions.conversion, "webhook.conversion", false, "Serve conversion webhooks and set them in the CustomResourceDefinitions of ArangoDeployments, ArangoMembers and ArangoDeploymentReplications (requires --webhook.enabled)")
 	f.StringArrayVar(&logLevels, "log.level", []string{defaultLogLevel}, fmt.Sprintf("Set log levels in format <level> or <logger>=<level>. Possible loggers: %s", strings.Join(logging.LoggerNames(), ", ")))
 	f.BoolVar(&operatorOptions.enableDeployment, "operator.deployment", false, "Enable to run the ArangoDeployment operator")
 	f.BoolVar(&operatorOptions.enableDeploymentReplication, "operator.deployment-replication", false, "Enable to run the ArangoDeploymentReplication operator")
@@ -154,8 +217,21 @@ func init() {
 	f.StringVar(&operatorOptions.metricsExporterImage, "operator.metrics-exporter-image", MetricsExporterImageEnv.GetOrDefault(defaultMetricsExporterImage), "Docker image used for metrics containers by default")
 	f.StringVar(&operatorOptions.arangoImage, "operator.arango-image", ArangoImageEnv.GetOrDefault(defaultArangoImage), "Docker image used for arango by default")
 	f.BoolVar(&chaosOptions.allowed, "chaos.allowed", false, "Set to allow chaos in deployments. Only activated when allowed and enabled in deployment")
+	f.StringVar(&tracingOptions.endpoint, "tracing.otlp-endpoint", "", "Address (host:port) of the OTLP gRPC receiver to which traces are exported. Tracing is disabled when empty")
+	f.BoolVar(&tracingOptions.insecure, "tracing.otlp-insecure", false, "Disable TLS for the connection to the OTLP receiver")
+	f.Float64Var(&tracingOptions.sampleRatio, "tracing.sample-ratio", 1, "Fraction of the reconciliation traces which are sampled (0-1)")
 	f.BoolVar(&operatorOptions.singleMode, "mode.single", false, "Enable single mode in Operator. WARNING: There should be only one replica of Operator, otherwise Operator can take unexpected actions")
 	f.StringVar(&operatorOptions.scope, "scope", scope.DefaultScope.String(), "Define scope on which Operator works. Legacy - pre 1.1.0 scope with limited cluster access")
+	f.StringSliceVar(&watchOptions.namespaces, "watch.namespaces", nil, "Namespaces watched for ArangoDeployments and ArangoDeploymentReplications (if empty, namespace of the operator is watched)")
+	f.StringVar(&watchOptions.namespaceSelector, "watch.namespace-selector", "", "Label selector of namespaces watched for ArangoDeployments and ArangoDeploymentReplications")
+	f.StringVar(&watchOptions.shardName, "shard.name", "", "Name of the operator shard, used as suffix of the leader election locks (defaults to shard-<index> with --shard.count)")
+	f.StringVar(&watchOptions.shardSelector, "shard.selector", "", "Label selector of ArangoDeployments and ArangoDeploymentReplications handled by this operator")
+	f.IntVar(&watchOptions.shardCount, "shard.count", 0, "Number of operator shards, resources are split between shards by the hash of namespace and name (0 disables hash sharding)")
+	f.IntVar(&watchOptions.shardIndex, "shard.index", 0, "Index of this operator shard (0 - shard.count-1)")
+	f.StringVar(&leaderElectionOptions.lockType, "leader.lock-type", operator.DefaultLeaderElectionLockType, "Type of the resource used as leader election lock (leases, endpointsleases, endpoints)")
+	f.DurationVar(&leaderElectionOptions.leaseDuration, "leader.lease-duration", operator.DefaultLeaderElectionLeaseDuration, "Duration non-leaders wait before trying to acquire the leadership")
+	f.DurationVar(&leaderElectionOptions.renewDeadline, "leader.renew-deadline", operator.DefaultLeaderElectionRenewDeadline, "Duration the leader retries to renew the leadership before giving up")
+	f.DurationVar(&leaderElectionOptions.retryPeriod, "leader.retry-period", operator.DefaultLeaderElectionRetryPeriod, "Duration between attempts to acquire or renew the leadership")
 	f.DurationVar(&timeouts.k8s, "timeout.k8s", time.Second*3, "The request timeout to the kubernetes")
 	f.DurationVar(&timeouts.arangoD, "timeout.arangod", time.Second*10, "The request timeout to the ArangoDB")
 	features.Init(&cmdMain)
@@ -202,6 +278,9 @@ func cmdMainRun(cmd *cobra.Command, args []string) {
 	klog.Info("nice to meet you")
 	klog.Flush()
 
+	// Collect metrics of the Kubernetes API requests
+	metrics.RegisterKubernetesClientMetrics()
+
 	// Check operating mode
 	if !operatorOptions.enableDeployment && !operatorOptions.enableDeploymentReplication && !operatorOptions.enableStorage && !operatorOptions.enableBackup {
 		if !operatorOptions.versionOnly {
@@ -235,6 +314,19 @@ func cmdMainRun(cmd *cobra.Command, args []string) {
 			cliLog.Fatal().Err(err).Msg("Failed to get hostname")
 		}
 
+		// Configure tracing, before any client is created
+		shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+			Endpoint:       tracingOptions.endpoint,
+			Insecure:       tracingOptions.insecure,
+			SampleRatio:    tracingOptions.sampleRatio,
+			ServiceName:    "arangodb-operator",
+			ServiceVersion: string(version.GetVersionV1().Version),
+			InstanceID:     name,
+		})
+		if err != nil {
+			cliLog.Fatal().Err(err).Msg("Failed to configure tracing")
+		}
+
 		// Create kubernetes client
 		kubecli, err := k8sutil.NewKubeClient()
 		if err != nil {
@@ -252,6 +344,23 @@ func cmdMainRun(cmd *cobra.Command, args []string) {
 			cliLog.Fatal().Err(err).Msg("Failed to create operator")
 		}
 
+		oidcConfig := server.OIDCConfig{
+			IssuerURL:        serverOptions.oidc.issuerURL,
+			ClientID:         serverOptions.oidc.clientID,
+			ClientSecretName: serverOptions.oidc.clientSecretName,
+			RedirectURL:      serverOptions.oidc.redirectURL,
+			UsernameClaim:    serverOptions.oidc.usernameClaim,
+			GroupsClaim:      serverOptions.oidc.groupsClaim,
+			Scopes:           serverOptions.oidc.scopes,
+		}
+		if err := oidcConfig.Validate(); err != nil {
+			cliLog.Fatal().Err(err).Msg("Invalid OIDC configuration")
+		}
+		roleBindings, err := server.ParseRoleBindings(serverOptions.roleBindings)
+		if err != nil {
+			cliLog.Fatal().Err(err).Msg("Invalid role binding")
+		}
+
 		listenAddr := net.JoinHostPort(serverOptions.host, strconv.Itoa(serverOptions.port))
 		if svr, err := server.NewServer(kubecli.CoreV1(), server.Config{
 			Namespace:          namespace,
@@ -262,8 +371,12 @@ func cmdMainRun(cmd *cobra.Command, args []string) {
 			PodIP:              ip,
 			AdminSecretName:    serverOptions.adminSecretName,
 			AllowAnonymous:     serverOptions.allowAnonymous,
+			OIDC:               oidcConfig,
+			RoleBindings:       roleBindings,
 		}, server.Dependencies{
 			Log:           logService.MustGetLogger(logging.LoggerNameServer),
+			AuditLog:      cliLog.With().Str("component", "audit").Logger(),
+			LogService:    logService,
 			LivenessProbe: &livenessProbe,
 			Deployment: server.OperatorDependency{
 				Enabled: cfg.EnableDeployment,
@@ -281,7 +394,8 @@ func cmdMainRun(cmd *cobra.Command, args []string) {
 				Enabled: cfg.EnableBackup,
 				Probe:   &backupProbe,
 			},
-			Operators: o,
+			Operators:   o,
+			EventStream: eventStream,
 
 			Secrets: secrets,
 		}); err != nil {
@@ -290,10 +404,66 @@ func cmdMainRun(cmd *cobra.Command, args []string) {
 			go utilsError.LogError(cliLog, "error while starting service", svr.Run)
 		}
 
+		if webhookOptions.conversion && !webhookOptions.enabled {
+			cliLog.Fatal().Msg("Option --webhook.conversion requires --webhook.enabled")
+		}
+		if webhookOptions.mutation && !webhookOptions.enabled {
+			cliLog.Fatal().Msg("Option --webhook.mutation requires --webhook.enabled")
+		}
+		var webhookServer *webhook.Server
+		if webhookOptions.enabled {
+			if svr, err := webhook.NewServer(webhook.Config{
+				Namespace:                   namespace,
+				Address:                     net.JoinHostPort(serverOptions.host, strconv.Itoa(webhookOptions.port)),
+				ServiceName:                 webhookOptions.serviceName,
+				ServicePort:                 int32(webhookOptions.servicePort),
+				SecretName:                  webhookOptions.secretName,
+				EnableDeployment:            cfg.EnableDeployment,
+				EnableDeploymentReplication: cfg.EnableDeploymentReplication,
+				EnableStorage:               cfg.EnableStorage,
+				EnableBackup:                cfg.EnableBackup,
+				EnableConversion:            webhookOptions.conversion,
+				EnableMutation:              webhookOptions.mutation,
+				WatchNamespaces:             cfg.Watch.Namespaces,
+				WatchNamespaceSelector:      cfg.Watch.NamespaceSelector,
+			}, webhook.Dependencies{
+				Log:        logService.MustGetLogger(logging.LoggerNameWebhook),
+				KubeCli:    kubecli,
+				KubeExtCli: deps.KubeExtCli,
+			}); err != nil {
+				cliLog.Fatal().Err(err).Msg("Failed to create webhook server")
+			} else {
+				webhookServer = svr
+				go utilsError.LogError(cliLog, "error while starting webhook service", svr.Run)
+			}
+		}
+
 		//	startChaos(context.Background(), cfg.KubeCli, cfg.Namespace, chaosLevel)
 
-		// Start operator
-		o.Run()
+		// Start operator, leadership is released on SIGTERM to speed up the failover
+		ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+		defer cancel()
+
+		o.Run(ctx)
+		cliLog.Info().Msg("Operator stopped")
+
+		// Stop the webhooks and set the conversion of the CRDs back to None, as the API server can not call them anymore
+		if webhookServer != nil {
+			webhookCtx, webhookCancel := context.WithTimeout(context.Background(), k8sutil.GetRequestTimeout())
+			defer webhookCancel()
+
+			if err := webhookServer.Shutdown(webhookCtx); err != nil {
+				cliLog.Warn().Err(err).Msg("Failed to stop webhook server")
+			}
+		}
+
+		// Flush spans of the last reconciliations
+		tracingCtx, tracingCancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer tracingCancel()
+
+		if err := shutdownTracing(tracingCtx); err != nil {
+			cliLog.Warn().Err(err).Msg("Failed to flush tracing spans")
+		}
 	} else {
 		if err := startVersionProcess(); err != nil {
 			cliLog.Fatal().Err(err).Msg("Failed to create HTTP server")
@@ -337,6 +507,11 @@ func newOperatorConfigAndDeps(id, namespace, name string) (operator.Config, oper
 		return operator.Config{}, operator.Dependencies{}, maskAny(err)
 	}
 
+	kubeDynamicCli, err := k8sutil.NewKubeDynamicClient()
+	if err != nil {
+		return operator.Config{}, operator.Dependencies{}, maskAny(fmt.Errorf("Failed to create k8s dynamic client: %s", err))
+	}
+
 	image, serviceAccount, err := getMyPodInfo(kubecli, namespace, name)
 	if err != nil {
 		return operator.Config{}, operator.Dependencies{}, maskAny(fmt.Errorf("Failed to get my pod's service account: %s", err))
@@ -372,12 +547,35 @@ func newOperatorConfigAndDeps(id, namespace, name string) (operator.Config, oper
 		ArangoImage:                 operatorOptions.arangoImage,
 		SingleMode:                  operatorOptions.singleMode,
 		Scope:                       scope,
+		LeaderElection: operator.LeaderElectionConfig{
+			LockType:      leaderElectionOptions.lockType,
+			LeaseDuration: leaderElectionOptions.leaseDuration,
+			RenewDeadline: leaderElectionOptions.renewDeadline,
+			RetryPeriod:   leaderElectionOptions.retryPeriod,
+		},
+		Watch: operator.WatchConfig{
+			Namespaces:        watchOptions.namespaces,
+			NamespaceSelector: watchOptions.namespaceSelector,
+			Shard: operator.ShardConfig{
+				Name:     watchOptions.shardName,
+				Selector: watchOptions.shardSelector,
+				Count:    watchOptions.shardCount,
+				Index:    watchOptions.shardIndex,
+			},
+		},
+	}
+	if err := cfg.LeaderElection.Validate(); err != nil {
+		return operator.Config{}, operator.Dependencies{}, maskAny(err)
+	}
+	if err := cfg.Watch.Validate(cfg.Scope); err != nil {
+		return operator.Config{}, operator.Dependencies{}, maskAny(err)
 	}
 	deps := operator.Dependencies{
 		LogService:                 logService,
 		KubeCli:                    kubecli,
 		KubeExtCli:                 kubeExtCli,
 		KubeMonitoringCli:          kubeMonCli,
+		KubeDynamicCli:             kubeDynamicCli,
 		CRCli:                      crCli,
 		EventRecorder:              eventRecorder,
 		LivenessProbe:              &livenessProbe,
@@ -385,6 +583,7 @@ func newOperatorConfigAndDeps(id, namespace, name string) (operator.Config, oper
 		DeploymentReplicationProbe: &deploymentReplicationProbe,
 		StorageProbe:               &storageProbe,
 		BackupProbe:                &backupProbe,
+		EventStream:                eventStream,
 	}
 
 	return cfg, deps, nil
@@ -31,6 +31,10 @@ const (
 	ArangoBackupPolicyResourceKind   = "ArangoBackupPolicy"
 	ArangoBackupPolicyResourcePlural = "arangobackuppolicies"
 
+	ArangoVolumeSnapshotCRDName        = ArangoVolumeSnapshotResourcePlural + "." + ArangoBackupGroupName
+	ArangoVolumeSnapshotResourceKind   = "ArangoVolumeSnapshot"
+	ArangoVolumeSnapshotResourcePlural = "arangovolumesnapshots"
+
 	ArangoBackupGroupName = "backup.arangodb.com"
 )
 
@@ -38,4 +42,6 @@ var (
 	ArangoBackupShortNames = []string{"arangobackup"}
 
 	ArangoBackupPolicyShortNames = []string{"arangobackuppolicy"}
+
+	ArangoVolumeSnapshotShortNames = []string{"arangovolumesnapshot"}
 )
@@ -56,3 +56,33 @@ type ArangoBackupSpecDownload struct {
 
 	ID string `json:"id"`
 }
+
+// ResetImmutableFields replaces all immutable fields in the given target with values from the source spec.
+// It returns a list of fields that have been reset.
+// Field names are relative to `spec.`.
+func (a ArangoBackupSpec) ResetImmutableFields(target *ArangoBackupSpec) []string {
+	var result []string
+	if a.Deployment.Name != target.Deployment.Name {
+		target.Deployment.Name = a.Deployment.Name
+		result = append(result, "deployment.name")
+	}
+	if !a.Download.Equal(target.Download) {
+		target.Download = a.Download.DeepCopy()
+		result = append(result, "download")
+	}
+	return result
+}
+
+func (a *ArangoBackupSpecDownload) Equal(b *ArangoBackupSpecDownload) bool {
+	if a == b {
+		return true
+	}
+
+	if a == nil && b != nil || a != nil && b == nil {
+		return false
+	}
+
+	return a.ID == b.ID &&
+		a.RepositoryURL == b.RepositoryURL &&
+		a.CredentialsSecretName == b.CredentialsSecretName
+}
@@ -52,6 +52,8 @@ func addKnownTypes(s *runtime.Scheme) error {
 		&ArangoBackupList{},
 		&ArangoBackupPolicy{},
 		&ArangoBackupPolicyList{},
+		&ArangoVolumeSnapshot{},
+		&ArangoVolumeSnapshotList{},
 	)
 	metav1.AddToGroupVersion(s, SchemeGroupVersion)
 	return nil
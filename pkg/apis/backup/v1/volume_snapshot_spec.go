@@ -0,0 +1,42 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import "github.com/arangodb/kube-arangodb/pkg/util"
+
+// ArangoVolumeSnapshotSpec defines which deployment should be snapshotted.
+type ArangoVolumeSnapshotSpec struct {
+	// Deployment
+	Deployment ArangoVolumeSnapshotSpecDeployment `json:"deployment,omitempty"`
+
+	// VolumeSnapshotClassName defines the VolumeSnapshotClass used for all VolumeSnapshots.
+	// If empty, default class for the CSI driver is used.
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// GetVolumeSnapshotClassName returns the VolumeSnapshotClass name or empty string if not set
+func (a ArangoVolumeSnapshotSpec) GetVolumeSnapshotClassName() string {
+	return util.StringOrDefault(a.VolumeSnapshotClassName)
+}
+
+type ArangoVolumeSnapshotSpecDeployment struct {
+	Name string `json:"name,omitempty"`
+}
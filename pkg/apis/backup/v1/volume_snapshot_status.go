@@ -0,0 +1,103 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArangoVolumeSnapshotState defines the state of the ArangoVolumeSnapshot
+type ArangoVolumeSnapshotState string
+
+const (
+	// ArangoVolumeSnapshotStateNone is the state of a newly created snapshot
+	ArangoVolumeSnapshotStateNone ArangoVolumeSnapshotState = ""
+	// ArangoVolumeSnapshotStateCreating is set while VolumeSnapshots are taken and not yet ready to use
+	ArangoVolumeSnapshotStateCreating ArangoVolumeSnapshotState = "Creating"
+	// ArangoVolumeSnapshotStateReady is set when all VolumeSnapshots are ready to use
+	ArangoVolumeSnapshotStateReady ArangoVolumeSnapshotState = "Ready"
+	// ArangoVolumeSnapshotStateFailed is set when any of VolumeSnapshots failed
+	ArangoVolumeSnapshotStateFailed ArangoVolumeSnapshotState = "Failed"
+)
+
+// IsFinal returns true if state will not change anymore
+func (a ArangoVolumeSnapshotState) IsFinal() bool {
+	return a == ArangoVolumeSnapshotStateReady || a == ArangoVolumeSnapshotStateFailed
+}
+
+// ArangoVolumeSnapshotStatus contains the status part of
+// an ArangoVolumeSnapshot.
+type ArangoVolumeSnapshotStatus struct {
+	// State holds the current high level state of the snapshot
+	State ArangoVolumeSnapshotState `json:"state,omitempty"`
+
+	Time meta.Time `json:"time,omitempty"`
+
+	// Message for the state this object is in.
+	Message string `json:"message,omitempty"`
+
+	// Mode of the deployment at the time snapshot was taken
+	Mode string `json:"mode,omitempty"`
+
+	// Volumes keeps the VolumeSnapshot taken for each member
+	Volumes ArangoVolumeSnapshotVolumes `json:"volumes,omitempty"`
+}
+
+// ArangoVolumeSnapshotVolumes is a list of member VolumeSnapshots
+type ArangoVolumeSnapshotVolumes []ArangoVolumeSnapshotVolume
+
+// IsReady returns true if all VolumeSnapshots are ready to use
+func (a ArangoVolumeSnapshotVolumes) IsReady() bool {
+	for _, v := range a {
+		if !v.ReadyToUse {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ForGroup returns VolumeSnapshots taken for members of the given group (role)
+func (a ArangoVolumeSnapshotVolumes) ForGroup(group string) ArangoVolumeSnapshotVolumes {
+	var r ArangoVolumeSnapshotVolumes
+
+	for _, v := range a {
+		if v.Group == group {
+			r = append(r, v)
+		}
+	}
+
+	return r
+}
+
+// ArangoVolumeSnapshotVolume keeps information about VolumeSnapshot of a single member
+type ArangoVolumeSnapshotVolume struct {
+	// Group is a role of the member
+	Group string `json:"group"`
+	// ID of the member
+	ID string `json:"id"`
+	// PersistentVolumeClaimName is a source PVC of the snapshot
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+	// VolumeSnapshotName is a name of the created VolumeSnapshot
+	VolumeSnapshotName string `json:"volumeSnapshotName"`
+	// ReadyToUse is true if VolumeSnapshot can be used as a PVC data source
+	ReadyToUse bool `json:"readyToUse,omitempty"`
+}
@@ -430,3 +430,160 @@ func (in *ArangoBackupTemplate) DeepCopy() *ArangoBackupTemplate {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoVolumeSnapshot) DeepCopyInto(out *ArangoVolumeSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoVolumeSnapshot.
+func (in *ArangoVolumeSnapshot) DeepCopy() *ArangoVolumeSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoVolumeSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArangoVolumeSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoVolumeSnapshotList) DeepCopyInto(out *ArangoVolumeSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArangoVolumeSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoVolumeSnapshotList.
+func (in *ArangoVolumeSnapshotList) DeepCopy() *ArangoVolumeSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoVolumeSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArangoVolumeSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoVolumeSnapshotSpec) DeepCopyInto(out *ArangoVolumeSnapshotSpec) {
+	*out = *in
+	out.Deployment = in.Deployment
+	if in.VolumeSnapshotClassName != nil {
+		in, out := &in.VolumeSnapshotClassName, &out.VolumeSnapshotClassName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoVolumeSnapshotSpec.
+func (in *ArangoVolumeSnapshotSpec) DeepCopy() *ArangoVolumeSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoVolumeSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoVolumeSnapshotSpecDeployment) DeepCopyInto(out *ArangoVolumeSnapshotSpecDeployment) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoVolumeSnapshotSpecDeployment.
+func (in *ArangoVolumeSnapshotSpecDeployment) DeepCopy() *ArangoVolumeSnapshotSpecDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoVolumeSnapshotSpecDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoVolumeSnapshotStatus) DeepCopyInto(out *ArangoVolumeSnapshotStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make(ArangoVolumeSnapshotVolumes, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoVolumeSnapshotStatus.
+func (in *ArangoVolumeSnapshotStatus) DeepCopy() *ArangoVolumeSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoVolumeSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoVolumeSnapshotVolume) DeepCopyInto(out *ArangoVolumeSnapshotVolume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoVolumeSnapshotVolume.
+func (in *ArangoVolumeSnapshotVolume) DeepCopy() *ArangoVolumeSnapshotVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoVolumeSnapshotVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ArangoVolumeSnapshotVolumes) DeepCopyInto(out *ArangoVolumeSnapshotVolumes) {
+	{
+		in := &in
+		*out = make(ArangoVolumeSnapshotVolumes, len(*in))
+		copy(*out, *in)
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoVolumeSnapshotVolumes.
+func (in ArangoVolumeSnapshotVolumes) DeepCopy() ArangoVolumeSnapshotVolumes {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoVolumeSnapshotVolumes)
+	in.DeepCopyInto(out)
+	return *out
+}
@@ -27,5 +27,7 @@ const (
 	ArangoDeploymentPodMaintenanceAnnotation = ArangoDeploymentAnnotationPrefix + "/maintenance"
 	ArangoDeploymentPodRotateAnnotation      = ArangoDeploymentAnnotationPrefix + "/rotate"
 	ArangoDeploymentPodReplaceAnnotation     = ArangoDeploymentAnnotationPrefix + "/replace"
+	ArangoDeploymentPodDebugAnnotation       = ArangoDeploymentAnnotationPrefix + "/debug"
+	ArangoDeploymentPodOverridesAnnotation   = ArangoDeploymentAnnotationPrefix + "/overrides"
 	ArangoDeploymentPlanCleanAnnotation      = "plan." + ArangoDeploymentAnnotationPrefix + "/clean"
 )
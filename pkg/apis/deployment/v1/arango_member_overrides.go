@@ -0,0 +1,101 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+)
+
+// ArangoMemberOverridesSpec defines changes of the server group spec applied only to the pod of a single member.
+type ArangoMemberOverridesSpec struct {
+	// NodeSelector is merged into the node selector of the server group
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations replace the tolerations of the server group
+	Tolerations []core.Toleration `json:"tolerations,omitempty"`
+	// Resources are merged into the resource requests and limits of the server group
+	Resources *core.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// Apply returns a copy of the server group spec with the overrides applied.
+func (a *ArangoMemberOverridesSpec) Apply(groupSpec ServerGroupSpec) ServerGroupSpec {
+	if a == nil {
+		return groupSpec
+	}
+
+	result := groupSpec.DeepCopy()
+
+	if len(a.NodeSelector) > 0 {
+		if result.NodeSelector == nil {
+			result.NodeSelector = map[string]string{}
+		}
+		for k, v := range a.NodeSelector {
+			result.NodeSelector[k] = v
+		}
+	}
+
+	if a.Tolerations != nil {
+		result.Tolerations = make([]core.Toleration, len(a.Tolerations))
+		for id := range a.Tolerations {
+			a.Tolerations[id].DeepCopyInto(&result.Tolerations[id])
+		}
+	}
+
+	if a.Resources != nil {
+		result.Resources.Limits = mergeResourceList(result.Resources.Limits, a.Resources.Limits)
+		result.Resources.Requests = mergeResourceList(result.Resources.Requests, a.Resources.Requests)
+	}
+
+	return *result
+}
+
+// Checksum returns the checksum of the overrides.
+func (a *ArangoMemberOverridesSpec) Checksum() string {
+	if a == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%0x", sha256.Sum256(data))
+}
+
+func mergeResourceList(target, source core.ResourceList) core.ResourceList {
+	if len(source) == 0 {
+		return target
+	}
+
+	if target == nil {
+		target = core.ResourceList{}
+	}
+
+	for k, v := range source {
+		target[k] = v.DeepCopy()
+	}
+
+	return target
+}
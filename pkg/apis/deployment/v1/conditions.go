@@ -85,6 +85,10 @@ const (
 	ConditionTypeUpdateFailed ConditionType = "UpdateFailed"
 	// ConditionTypeTopologyAware indicates that the member is deployed with TopologyAwareness.
 	ConditionTypeTopologyAware ConditionType = "TopologyAware"
+	// ConditionTypeNodeDrain indicates that the node of the member pod is cordoned, so the pod will be evicted.
+	ConditionTypeNodeDrain ConditionType = "NodeDrain"
+	// ConditionTypeNodeDrainPrepared indicates that the member is prepared for the eviction of its pod.
+	ConditionTypeNodeDrainPrepared ConditionType = "NodeDrainPrepared"
 )
 
 // Condition represents one current condition of a deployment or deployment member.
@@ -0,0 +1,41 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentNodeDrainMode(t *testing.T) {
+	var empty *DeploymentNodeDrainMode
+	assert.Equal(t, DeploymentNodeDrainModeDisabled, empty.Get())
+	assert.False(t, empty.Get().IsEnabled())
+	assert.Nil(t, empty.Validate())
+
+	assert.True(t, DeploymentNodeDrainModeResignLeadership.New().Get().IsEnabled())
+	assert.True(t, DeploymentNodeDrainModeCleanOut.New().Get().IsEnabled())
+	assert.Nil(t, DeploymentNodeDrainModeResignLeadership.New().Validate())
+	assert.Nil(t, DeploymentNodeDrainModeCleanOut.New().Validate())
+
+	assert.Error(t, DeploymentNodeDrainMode("unknown").New().Validate())
+}
@@ -125,6 +125,9 @@ type DeploymentSpec struct {
 
 	RestoreEncryptionSecret *string `json:"restoreEncryptionSecret,omitempty"`
 
+	// RestoreFromVolumeSnapshot defines name of the ArangoVolumeSnapshot used as a data source for member volumes
+	RestoreFromVolumeSnapshot *string `json:"restoreFromVolumeSnapshot,omitempty"`
+
 	// AllowUnsafeUpgrade determines if upgrade on missing member or with not in sync shards is allowed
 	AllowUnsafeUpgrade *bool `json:"allowUnsafeUpgrade,omitempty"`
 
@@ -151,6 +154,9 @@ type DeploymentSpec struct {
 
 	MemberPropagationMode *DeploymentMemberPropagationMode `json:"memberPropagationMode,omitempty"`
 
+	// NodeDrainMode define how DBServers are prepared when the node of their pod is cordoned
+	NodeDrainMode *DeploymentNodeDrainMode `json:"nodeDrainMode,omitempty"`
+
 	Chaos ChaosSpec `json:"chaos"`
 
 	Recovery *ArangoDeploymentRecoverySpec `json:"recovery,omitempty"`
@@ -198,6 +204,16 @@ func (s *DeploymentSpec) HasRestoreFrom() bool {
 	return s.RestoreFrom != nil
 }
 
+// GetRestoreFromVolumeSnapshot returns the volume snapshot name or empty string if not set
+func (s *DeploymentSpec) GetRestoreFromVolumeSnapshot() string {
+	return util.StringOrDefault(s.RestoreFromVolumeSnapshot)
+}
+
+// HasRestoreFromVolumeSnapshot returns true if RestoreFromVolumeSnapshot is set
+func (s *DeploymentSpec) HasRestoreFromVolumeSnapshot() bool {
+	return s.RestoreFromVolumeSnapshot != nil
+}
+
 // Equal compares two DeploymentSpec
 func (s *DeploymentSpec) Equal(other *DeploymentSpec) bool {
 	return reflect.DeepEqual(s, other)
@@ -459,6 +475,9 @@ func (s *DeploymentSpec) Validate() error {
 	if err := s.Bootstrap.Validate(); err != nil {
 		return errors.WithStack(err)
 	}
+	if err := s.NodeDrainMode.Validate(); err != nil {
+		return errors.WithStack(errors.Wrap(err, "spec.nodeDrainMode"))
+	}
 	return nil
 }
 
@@ -489,6 +508,10 @@ func (s DeploymentSpec) ResetImmutableFields(target *DeploymentSpec) []string {
 		target.DisableIPv6 = util.NewBoolOrNil(s.DisableIPv6)
 		resetFields = append(resetFields, "disableIPv6")
 	}
+	if s.GetRestoreFromVolumeSnapshot() != target.GetRestoreFromVolumeSnapshot() {
+		target.RestoreFromVolumeSnapshot = util.NewStringOrNil(s.RestoreFromVolumeSnapshot)
+		resetFields = append(resetFields, "restoreFromVolumeSnapshot")
+	}
 	if l := s.ExternalAccess.ResetImmutableFields("externalAccess", &target.ExternalAccess); l != nil {
 		resetFields = append(resetFields, l...)
 	}
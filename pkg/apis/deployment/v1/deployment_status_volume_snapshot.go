@@ -0,0 +1,98 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+// DeploymentStatusVolumeSnapshotRestore keeps information about ArangoVolumeSnapshot used as a data source
+type DeploymentStatusVolumeSnapshotRestore struct {
+	// Name of the ArangoVolumeSnapshot
+	Name string `json:"name"`
+	// Volumes keeps list of VolumeSnapshots which are assigned to the members
+	Volumes DeploymentStatusVolumeSnapshotRestoreVolumes `json:"volumes,omitempty"`
+}
+
+func (d *DeploymentStatusVolumeSnapshotRestore) Equal(b *DeploymentStatusVolumeSnapshotRestore) bool {
+	if d == nil && b == nil {
+		return true
+	}
+
+	if d == nil || b == nil {
+		return false
+	}
+
+	return d.Name == b.Name && d.Volumes.Equal(b.Volumes)
+}
+
+// GetVolumeSnapshotName returns VolumeSnapshot name assigned to the member
+func (d *DeploymentStatusVolumeSnapshotRestore) GetVolumeSnapshotName(group ServerGroup, id string) (string, bool) {
+	if d == nil {
+		return "", false
+	}
+
+	for _, v := range d.Volumes {
+		if v.Group == group && v.ID == id {
+			return v.VolumeSnapshotName, true
+		}
+	}
+
+	return "", false
+}
+
+// GetIDs returns IDs of the members from the given group which are restored from VolumeSnapshots
+func (d *DeploymentStatusVolumeSnapshotRestore) GetIDs(group ServerGroup) []string {
+	if d == nil {
+		return nil
+	}
+
+	var ids []string
+
+	for _, v := range d.Volumes {
+		if v.Group == group {
+			ids = append(ids, v.ID)
+		}
+	}
+
+	return ids
+}
+
+type DeploymentStatusVolumeSnapshotRestoreVolumes []DeploymentStatusVolumeSnapshotRestoreVolume
+
+func (d DeploymentStatusVolumeSnapshotRestoreVolumes) Equal(b DeploymentStatusVolumeSnapshotRestoreVolumes) bool {
+	if len(d) != len(b) {
+		return false
+	}
+
+	for id := range d {
+		if d[id] != b[id] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type DeploymentStatusVolumeSnapshotRestoreVolume struct {
+	// Group of the member
+	Group ServerGroup `json:"group"`
+	// ID of the member
+	ID string `json:"id"`
+	// VolumeSnapshotName defines name of the VolumeSnapshot used as a PVC data source
+	VolumeSnapshotName string `json:"volumeSnapshotName"`
+}
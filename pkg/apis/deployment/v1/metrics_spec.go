@@ -23,7 +23,10 @@
 package v1
 
 import (
+	"regexp"
+
 	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	v1 "k8s.io/api/core/v1"
 )
@@ -75,6 +78,14 @@ type MetricsSpec struct {
 	TLS            *bool                     `json:"tls,omitempty"`
 
 	Port *uint16 `json:"port,omitempty"`
+
+	// Relabel adds deployment, role, member_id and zone labels to the metrics exposed by the internal exporter
+	Relabel *bool `json:"relabel,omitempty"`
+	// Allow contains regular expressions of metric names exposed by the internal exporter.
+	// All metrics are exposed if not set
+	Allow []string `json:"allow,omitempty"`
+	// Deny contains regular expressions of metric names which are not exposed by the internal exporter
+	Deny []string `json:"deny,omitempty"`
 }
 
 func (s *MetricsSpec) IsTLS() bool {
@@ -93,6 +104,15 @@ func (s *MetricsSpec) GetPort() uint16 {
 	return *s.Port
 }
 
+// IsRelabel returns whether labels are added to the metrics by the internal exporter
+func (s *MetricsSpec) IsRelabel() bool {
+	if s == nil {
+		return false
+	}
+
+	return util.BoolOrDefault(s.Relabel, false)
+}
+
 // IsEnabled returns whether metrics are enabled or not
 func (s *MetricsSpec) IsEnabled() bool {
 	return util.BoolOrDefault(s.Enabled, false)
@@ -139,6 +159,15 @@ func (s *MetricsSpec) SetDefaultsFrom(source MetricsSpec) {
 	if s.Authentication.JWTTokenSecretName == nil {
 		s.Authentication.JWTTokenSecretName = util.NewStringOrNil(source.Authentication.JWTTokenSecretName)
 	}
+	if s.Relabel == nil {
+		s.Relabel = util.NewBoolOrNil(source.Relabel)
+	}
+	if s.Allow == nil {
+		s.Allow = source.Allow
+	}
+	if s.Deny == nil {
+		s.Deny = source.Deny
+	}
 	setDefaultsFromResourceList(&s.Resources.Limits, source.Resources.Limits)
 	setDefaultsFromResourceList(&s.Resources.Requests, source.Resources.Requests)
 }
@@ -152,6 +181,12 @@ func (s *MetricsSpec) Validate() error {
 		}
 	}
 
+	for _, e := range append(append([]string{}, s.Allow...), s.Deny...) {
+		if _, err := regexp.Compile(e); err != nil {
+			return errors.Wrapf(err, "Invalid metric name expression %s", e)
+		}
+	}
+
 	return nil
 }
 
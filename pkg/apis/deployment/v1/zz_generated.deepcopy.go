@@ -171,6 +171,54 @@ func (in *ArangoMember) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoMemberDebugSpec) DeepCopyInto(out *ArangoMemberDebugSpec) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Envs != nil {
+		in, out := &in.Envs, &out.Envs
+		*out = make(ServerGroupEnvVars, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoMemberDebugSpec.
+func (in *ArangoMemberDebugSpec) DeepCopy() *ArangoMemberDebugSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoMemberDebugSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoMemberDebugStatus) DeepCopyInto(out *ArangoMemberDebugStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoMemberDebugStatus.
+func (in *ArangoMemberDebugStatus) DeepCopy() *ArangoMemberDebugStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoMemberDebugStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArangoMemberList) DeepCopyInto(out *ArangoMemberList) {
 	*out = *in
@@ -204,6 +252,41 @@ func (in *ArangoMemberList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoMemberOverridesSpec) DeepCopyInto(out *ArangoMemberOverridesSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoMemberOverridesSpec.
+func (in *ArangoMemberOverridesSpec) DeepCopy() *ArangoMemberOverridesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoMemberOverridesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArangoMemberPodTemplate) DeepCopyInto(out *ArangoMemberPodTemplate) {
 	*out = *in
@@ -233,6 +316,16 @@ func (in *ArangoMemberSpec) DeepCopyInto(out *ArangoMemberSpec) {
 		*out = new(ArangoMemberPodTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(ArangoMemberOverridesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(ArangoMemberDebugSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -261,6 +354,11 @@ func (in *ArangoMemberStatus) DeepCopyInto(out *ArangoMemberStatus) {
 		*out = new(ArangoMemberPodTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(ArangoMemberDebugStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -554,6 +652,11 @@ func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RestoreFromVolumeSnapshot != nil {
+		in, out := &in.RestoreFromVolumeSnapshot, &out.RestoreFromVolumeSnapshot
+		*out = new(string)
+		**out = **in
+	}
 	if in.AllowUnsafeUpgrade != nil {
 		in, out := &in.AllowUnsafeUpgrade, &out.AllowUnsafeUpgrade
 		*out = new(bool)
@@ -588,6 +691,11 @@ func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
 		*out = new(DeploymentMemberPropagationMode)
 		**out = **in
 	}
+	if in.NodeDrainMode != nil {
+		in, out := &in.NodeDrainMode, &out.NodeDrainMode
+		*out = new(DeploymentNodeDrainMode)
+		**out = **in
+	}
 	in.Chaos.DeepCopyInto(&out.Chaos)
 	if in.Recovery != nil {
 		in, out := &in.Recovery, &out.Recovery
@@ -694,6 +802,11 @@ func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
 		*out = new(TopologyStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VolumeSnapshotRestore != nil {
+		in, out := &in.VolumeSnapshotRestore, &out.VolumeSnapshotRestore
+		*out = new(DeploymentStatusVolumeSnapshotRestore)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -898,6 +1011,63 @@ func (in *DeploymentStatusMembers) DeepCopy() *DeploymentStatusMembers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusVolumeSnapshotRestore) DeepCopyInto(out *DeploymentStatusVolumeSnapshotRestore) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make(DeploymentStatusVolumeSnapshotRestoreVolumes, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusVolumeSnapshotRestore.
+func (in *DeploymentStatusVolumeSnapshotRestore) DeepCopy() *DeploymentStatusVolumeSnapshotRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusVolumeSnapshotRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatusVolumeSnapshotRestoreVolume) DeepCopyInto(out *DeploymentStatusVolumeSnapshotRestoreVolume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusVolumeSnapshotRestoreVolume.
+func (in *DeploymentStatusVolumeSnapshotRestoreVolume) DeepCopy() *DeploymentStatusVolumeSnapshotRestoreVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusVolumeSnapshotRestoreVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in DeploymentStatusVolumeSnapshotRestoreVolumes) DeepCopyInto(out *DeploymentStatusVolumeSnapshotRestoreVolumes) {
+	{
+		in := &in
+		*out = make(DeploymentStatusVolumeSnapshotRestoreVolumes, len(*in))
+		copy(*out, *in)
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatusVolumeSnapshotRestoreVolumes.
+func (in DeploymentStatusVolumeSnapshotRestoreVolumes) DeepCopy() DeploymentStatusVolumeSnapshotRestoreVolumes {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatusVolumeSnapshotRestoreVolumes)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentUpgradeSpec) DeepCopyInto(out *DeploymentUpgradeSpec) {
 	*out = *in
@@ -1227,6 +1397,21 @@ func (in *MetricsSpec) DeepCopyInto(out *MetricsSpec) {
 		*out = new(uint16)
 		**out = **in
 	}
+	if in.Relabel != nil {
+		in, out := &in.Relabel, &out.Relabel
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -0,0 +1,128 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+)
+
+// ArangoMemberDebugSpec defines temporary changes of the server container of a single member.
+// Member is restarted when the debug spec is added, changed or removed.
+type ArangoMemberDebugSpec struct {
+	// Args are appended to the command of the server container
+	Args []string `json:"args,omitempty"`
+	// Envs are set in the server container, existing envs with the same name are overridden
+	Envs ServerGroupEnvVars `json:"envs,omitempty"`
+	// TTL after which the operator removes the debug spec and the member is restarted without it.
+	// Debug spec is kept until it is removed manually if TTL is not set.
+	TTL *Duration `json:"ttl,omitempty"`
+}
+
+// GetTTL returns the TTL of the debug spec, 0 if not set.
+func (a *ArangoMemberDebugSpec) GetTTL() time.Duration {
+	if a == nil || a.TTL == nil {
+		return 0
+	}
+
+	return a.TTL.AsDuration()
+}
+
+// Validate the debug spec.
+func (a *ArangoMemberDebugSpec) Validate() error {
+	if a == nil {
+		return nil
+	}
+
+	for _, arg := range a.Args {
+		if !strings.HasPrefix(arg, "--") {
+			return errors.WithStack(errors.Wrapf(ValidationError, "Invalid arg '%s', expected '--' prefix", arg))
+		}
+	}
+
+	for _, env := range a.Envs {
+		if env.Name == "" {
+			return errors.WithStack(errors.Wrapf(ValidationError, "Env name cannot be empty"))
+		}
+	}
+
+	if a.TTL != nil {
+		if err := a.TTL.Validate(); err != nil {
+			return err
+		}
+		if a.TTL.AsDuration() < 0 {
+			return errors.WithStack(errors.Wrapf(ValidationError, "TTL cannot be negative"))
+		}
+	}
+
+	return nil
+}
+
+// Checksum returns the checksum of the changes applied to the server container.
+// TTL is not part of the checksum, so it can be changed without restart.
+func (a *ArangoMemberDebugSpec) Checksum() string {
+	if a == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(ArangoMemberDebugSpec{
+		Args: a.Args,
+		Envs: a.Envs,
+	})
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%0x", sha256.Sum256(data))
+}
+
+// ArangoMemberDebugStatus keeps the state of the debug spec applied to the member.
+type ArangoMemberDebugStatus struct {
+	// Checksum of the debug spec
+	Checksum string `json:"checksum,omitempty"`
+	// StartTime is the time when the debug spec with the checksum was discovered
+	StartTime meta.Time `json:"startTime,omitempty"`
+}
+
+// IsExpired returns true when the TTL of the debug spec passed.
+func (a *ArangoMemberDebugStatus) IsExpired(spec *ArangoMemberDebugSpec, now time.Time) bool {
+	if a == nil || spec == nil || spec.TTL == nil || a.Checksum != spec.Checksum() {
+		return false
+	}
+
+	return !now.Before(a.StartTime.Add(spec.GetTTL()))
+}
+
+// ExpiresIn returns the time left until the TTL of the debug spec passes, 0 if it never expires.
+func (a *ArangoMemberDebugStatus) ExpiresIn(spec *ArangoMemberDebugSpec, now time.Time) time.Duration {
+	if a == nil || spec == nil || spec.TTL == nil || a.Checksum != spec.Checksum() {
+		return 0
+	}
+
+	return a.StartTime.Add(spec.GetTTL()).Sub(now)
+}
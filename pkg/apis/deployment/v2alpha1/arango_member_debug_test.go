@@ -0,0 +1,73 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestArangoMemberDebugSpecValidate(t *testing.T) {
+	var empty *ArangoMemberDebugSpec
+	assert.Nil(t, empty.Validate())
+	assert.Nil(t, (&ArangoMemberDebugSpec{Args: []string{"--log.level=debug"}, TTL: NewDuration("1h")}).Validate())
+	assert.Nil(t, (&ArangoMemberDebugSpec{Envs: ServerGroupEnvVars{{Name: "GODEBUG", Value: "1"}}}).Validate())
+
+	assert.Error(t, (&ArangoMemberDebugSpec{Args: []string{"log.level=debug"}}).Validate())
+	assert.Error(t, (&ArangoMemberDebugSpec{Envs: ServerGroupEnvVars{{Value: "1"}}}).Validate())
+	assert.Error(t, (&ArangoMemberDebugSpec{TTL: NewDuration("1x")}).Validate())
+	assert.Error(t, (&ArangoMemberDebugSpec{TTL: NewDuration("-1h")}).Validate())
+}
+
+func TestArangoMemberDebugSpecChecksum(t *testing.T) {
+	var empty *ArangoMemberDebugSpec
+	assert.Equal(t, "", empty.Checksum())
+
+	a := &ArangoMemberDebugSpec{Args: []string{"--log.level=debug"}}
+	b := &ArangoMemberDebugSpec{Args: []string{"--log.level=debug"}, TTL: NewDuration("1h")}
+	c := &ArangoMemberDebugSpec{Args: []string{"--log.level=trace"}}
+
+	assert.NotEqual(t, "", a.Checksum())
+	assert.Equal(t, a.Checksum(), b.Checksum())
+	assert.NotEqual(t, a.Checksum(), c.Checksum())
+}
+
+func TestArangoMemberDebugStatusExpiration(t *testing.T) {
+	now := time.Now()
+	spec := &ArangoMemberDebugSpec{Args: []string{"--log.level=debug"}, TTL: NewDuration("1h")}
+	status := &ArangoMemberDebugStatus{Checksum: spec.Checksum(), StartTime: meta.NewTime(now)}
+
+	assert.False(t, status.IsExpired(spec, now))
+	assert.Equal(t, time.Hour, status.ExpiresIn(spec, now))
+	assert.True(t, status.IsExpired(spec, now.Add(time.Hour)))
+
+	// Without TTL spec never expires
+	noTTL := &ArangoMemberDebugSpec{Args: spec.Args}
+	assert.False(t, status.IsExpired(noTTL, now.Add(time.Hour)))
+	assert.Equal(t, time.Duration(0), status.ExpiresIn(noTTL, now))
+
+	// Changed spec is not expired until its start time is recorded
+	changed := &ArangoMemberDebugSpec{Args: []string{"--log.level=trace"}, TTL: NewDuration("1h")}
+	assert.False(t, status.IsExpired(changed, now.Add(time.Hour)))
+}
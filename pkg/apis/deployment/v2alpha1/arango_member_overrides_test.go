@@ -0,0 +1,84 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestArangoMemberOverridesSpecApply(t *testing.T) {
+	groupSpec := ServerGroupSpec{
+		NodeSelector: map[string]string{"zone": "a", "disk": "ssd"},
+		Tolerations:  []core.Toleration{{Key: "group"}},
+		Resources: core.ResourceRequirements{
+			Limits: core.ResourceList{
+				core.ResourceCPU:    resource.MustParse("1"),
+				core.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+
+	t.Run("Nil", func(t *testing.T) {
+		var overrides *ArangoMemberOverridesSpec
+		assert.Equal(t, groupSpec, overrides.Apply(groupSpec))
+		assert.Equal(t, "", overrides.Checksum())
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		overrides := &ArangoMemberOverridesSpec{
+			NodeSelector: map[string]string{"zone": "b", "kubernetes.io/hostname": "node-1"},
+			Tolerations:  []core.Toleration{{Key: "member"}},
+			Resources: &core.ResourceRequirements{
+				Limits: core.ResourceList{
+					core.ResourceMemory: resource.MustParse("4Gi"),
+				},
+				Requests: core.ResourceList{
+					core.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		}
+
+		result := overrides.Apply(groupSpec)
+
+		assert.Equal(t, map[string]string{"zone": "b", "disk": "ssd", "kubernetes.io/hostname": "node-1"}, result.NodeSelector)
+		assert.Equal(t, []core.Toleration{{Key: "member"}}, result.Tolerations)
+		assert.Equal(t, "1", result.Resources.Limits.Cpu().String())
+		assert.Equal(t, "4Gi", result.Resources.Limits.Memory().String())
+		assert.Equal(t, "2Gi", result.Resources.Requests.Memory().String())
+		assert.NotEqual(t, "", overrides.Checksum())
+
+		// Group spec is not modified
+		assert.Equal(t, "a", groupSpec.NodeSelector["zone"])
+		assert.Len(t, groupSpec.NodeSelector, 2)
+		assert.Equal(t, []core.Toleration{{Key: "group"}}, groupSpec.Tolerations)
+		assert.Equal(t, "1Gi", groupSpec.Resources.Limits.Memory().String())
+	})
+
+	t.Run("Empty tolerations", func(t *testing.T) {
+		overrides := &ArangoMemberOverridesSpec{Tolerations: []core.Toleration{}}
+
+		assert.Empty(t, overrides.Apply(groupSpec).Tolerations)
+	})
+}
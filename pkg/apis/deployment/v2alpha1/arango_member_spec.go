@@ -32,4 +32,10 @@ type ArangoMemberSpec struct {
 	DeploymentUID types.UID   `json:"deploymentUID,omitempty"`
 
 	Template *ArangoMemberPodTemplate `json:"template,omitempty"`
+
+	// Overrides define changes of the server group spec applied only to this member
+	Overrides *ArangoMemberOverridesSpec `json:"overrides,omitempty"`
+
+	// Debug defines temporary changes of the server container, used to restart the member with additional args or envs
+	Debug *ArangoMemberDebugSpec `json:"debug,omitempty"`
 }
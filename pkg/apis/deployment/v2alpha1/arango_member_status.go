@@ -26,4 +26,6 @@ type ArangoMemberStatus struct {
 	Conditions ConditionList `json:"conditions,omitempty"`
 
 	Template *ArangoMemberPodTemplate `json:"template,omitempty"`
+
+	Debug *ArangoMemberDebugStatus `json:"debug,omitempty"`
 }
@@ -0,0 +1,71 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/apis/shared"
+)
+
+// Objects are stored in v1, so v1 is the hub of the conversion. Other versions convert only to and from v1.
+// The v2alpha1 types have the same fields as the v1 types, so fields are copied by their JSON representation.
+// Fields which are renamed, restructured or dropped in this version have to be converted explicitly
+// after the copy, TestConversionFieldParity fails until then.
+
+// ConvertTo converts the ArangoDeployment to the hub version.
+func (d *ArangoDeployment) ConvertTo(hub *v1.ArangoDeployment) error {
+	*hub = v1.ArangoDeployment{}
+	if err := shared.ConvertFields(d, hub); err != nil {
+		return err
+	}
+	hub.APIVersion = v1.SchemeGroupVersion.String()
+	return nil
+}
+
+// ConvertFrom converts the ArangoDeployment from the hub version.
+func (d *ArangoDeployment) ConvertFrom(hub *v1.ArangoDeployment) error {
+	*d = ArangoDeployment{}
+	if err := shared.ConvertFields(hub, d); err != nil {
+		return err
+	}
+	d.APIVersion = SchemeGroupVersion.String()
+	return nil
+}
+
+// ConvertTo converts the ArangoMember to the hub version.
+func (a *ArangoMember) ConvertTo(hub *v1.ArangoMember) error {
+	*hub = v1.ArangoMember{}
+	if err := shared.ConvertFields(a, hub); err != nil {
+		return err
+	}
+	hub.APIVersion = v1.SchemeGroupVersion.String()
+	return nil
+}
+
+// ConvertFrom converts the ArangoMember from the hub version.
+func (a *ArangoMember) ConvertFrom(hub *v1.ArangoMember) error {
+	*a = ArangoMember{}
+	if err := shared.ConvertFields(hub, a); err != nil {
+		return err
+	}
+	a.APIVersion = SchemeGroupVersion.String()
+	return nil
+}
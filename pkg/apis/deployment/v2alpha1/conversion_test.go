@@ -0,0 +1,127 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"testing"
+
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/apis/shared/conversiontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testConversionObjectMeta() meta.ObjectMeta {
+	return meta.ObjectMeta{
+		Name:            "example",
+		Namespace:       "default",
+		UID:             "2b3f4e5d-0000-0000-0000-000000000000",
+		ResourceVersion: "42",
+		Generation:      3,
+		Labels:          map[string]string{"app": "arangodb"},
+		Annotations:     map[string]string{"note": "value"},
+		Finalizers:      []string{"database.arangodb.com/remove-child-finalizers"},
+	}
+}
+
+func TestConversionFieldParity(t *testing.T) {
+	// A field which differs between the versions needs an explicit conversion, see conversion.go
+	assert.Empty(t, conversiontest.FieldDiff(v1.ArangoDeployment{}, ArangoDeployment{}))
+	assert.Empty(t, conversiontest.FieldDiff(v1.ArangoMember{}, ArangoMember{}))
+}
+
+func TestArangoDeploymentConversionRoundTrip(t *testing.T) {
+	t.Run("From hub", func(t *testing.T) {
+		var hub v1.ArangoDeployment
+		conversiontest.Fill(&hub)
+		hub.TypeMeta = meta.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: "ArangoDeployment"}
+		hub.ObjectMeta = testConversionObjectMeta()
+
+		var d ArangoDeployment
+		require.NoError(t, d.ConvertFrom(&hub))
+		assert.Equal(t, SchemeGroupVersion.String(), d.APIVersion)
+		assert.Equal(t, "ArangoDeployment", d.Kind)
+		assert.Equal(t, hub.ObjectMeta, d.ObjectMeta)
+
+		var result v1.ArangoDeployment
+		require.NoError(t, d.ConvertTo(&result))
+		assert.Equal(t, hub, result)
+	})
+
+	t.Run("To hub", func(t *testing.T) {
+		var d ArangoDeployment
+		conversiontest.Fill(&d)
+		d.TypeMeta = meta.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "ArangoDeployment"}
+		d.ObjectMeta = testConversionObjectMeta()
+
+		var hub v1.ArangoDeployment
+		require.NoError(t, d.ConvertTo(&hub))
+		assert.Equal(t, v1.SchemeGroupVersion.String(), hub.APIVersion)
+
+		var result ArangoDeployment
+		require.NoError(t, result.ConvertFrom(&hub))
+		assert.Equal(t, d, result)
+	})
+
+	t.Run("Target is reset", func(t *testing.T) {
+		hub := v1.ArangoDeployment{
+			Spec: v1.DeploymentSpec{Image: nil},
+		}
+		d := ArangoDeployment{
+			Spec: DeploymentSpec{Image: new(string)},
+		}
+		require.NoError(t, d.ConvertFrom(&hub))
+		assert.Nil(t, d.Spec.Image)
+	})
+}
+
+func TestArangoMemberConversionRoundTrip(t *testing.T) {
+	t.Run("From hub", func(t *testing.T) {
+		var hub v1.ArangoMember
+		conversiontest.Fill(&hub)
+		hub.TypeMeta = meta.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: "ArangoMember"}
+		hub.ObjectMeta = testConversionObjectMeta()
+
+		var m ArangoMember
+		require.NoError(t, m.ConvertFrom(&hub))
+		assert.Equal(t, SchemeGroupVersion.String(), m.APIVersion)
+
+		var result v1.ArangoMember
+		require.NoError(t, m.ConvertTo(&result))
+		assert.Equal(t, hub, result)
+	})
+
+	t.Run("To hub", func(t *testing.T) {
+		var m ArangoMember
+		conversiontest.Fill(&m)
+		m.TypeMeta = meta.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "ArangoMember"}
+		m.ObjectMeta = testConversionObjectMeta()
+
+		var hub v1.ArangoMember
+		require.NoError(t, m.ConvertTo(&hub))
+		assert.Equal(t, v1.SchemeGroupVersion.String(), hub.APIVersion)
+
+		var result ArangoMember
+		require.NoError(t, result.ConvertFrom(&hub))
+		assert.Equal(t, m, result)
+	})
+}
@@ -0,0 +1,70 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import "github.com/arangodb/kube-arangodb/pkg/util/errors"
+
+// DeploymentNodeDrainMode defines how DBServers are prepared when the node of their pod is cordoned
+type DeploymentNodeDrainMode string
+
+func (d *DeploymentNodeDrainMode) Get() DeploymentNodeDrainMode {
+	if d == nil {
+		return DeploymentNodeDrainModeDefault
+	}
+
+	return *d
+}
+
+func (d DeploymentNodeDrainMode) New() *DeploymentNodeDrainMode {
+	return &d
+}
+
+func (d DeploymentNodeDrainMode) String() string {
+	return string(d)
+}
+
+// IsEnabled returns true when DBServers are prepared for the node drain
+func (d DeploymentNodeDrainMode) IsEnabled() bool {
+	return d != DeploymentNodeDrainModeDisabled
+}
+
+// Validate the node drain mode.
+func (d *DeploymentNodeDrainMode) Validate() error {
+	switch v := d.Get(); v {
+	case DeploymentNodeDrainModeDisabled, DeploymentNodeDrainModeResignLeadership, DeploymentNodeDrainModeCleanOut:
+		return nil
+	default:
+		return errors.WithStack(errors.Wrapf(ValidationError, "Unknown node drain mode: '%s'", v.String()))
+	}
+}
+
+const (
+	// DeploymentNodeDrainModeDefault Define default node drain mode
+	DeploymentNodeDrainModeDefault = DeploymentNodeDrainModeDisabled
+	// DeploymentNodeDrainModeDisabled define mode in which cordoned nodes are ignored
+	DeploymentNodeDrainModeDisabled DeploymentNodeDrainMode = "disabled"
+	// DeploymentNodeDrainModeResignLeadership define mode in which DBServers resign leadership of their shards
+	// before their pods are evicted from cordoned nodes
+	DeploymentNodeDrainModeResignLeadership DeploymentNodeDrainMode = "resign-leadership"
+	// DeploymentNodeDrainModeCleanOut define mode in which DBServers on cordoned nodes are cleaned out
+	// and replaced with new members
+	DeploymentNodeDrainModeCleanOut DeploymentNodeDrainMode = "clean-out"
+)
@@ -83,6 +83,9 @@ type DeploymentStatus struct {
 	Agency *DeploymentStatusAgencyInfo `json:"agency,omitempty"`
 
 	Topology *TopologyStatus `json:"topology,omitempty"`
+
+	// VolumeSnapshotRestore keeps information about volumes restored from ArangoVolumeSnapshot
+	VolumeSnapshotRestore *DeploymentStatusVolumeSnapshotRestore `json:"volumeSnapshotRestore,omitempty"`
 }
 
 // Equal checks for equality
@@ -101,7 +104,8 @@ func (ds *DeploymentStatus) Equal(other DeploymentStatus) bool {
 		ds.Plan.Equal(other.Plan) &&
 		ds.AcceptedSpec.Equal(other.AcceptedSpec) &&
 		ds.SecretHashes.Equal(other.SecretHashes) &&
-		ds.Agency.Equal(other.Agency)
+		ds.Agency.Equal(other.Agency) &&
+		ds.VolumeSnapshotRestore.Equal(other.VolumeSnapshotRestore)
 }
 
 // IsForceReload returns true if ForceStatusReload is set to true
@@ -0,0 +1,55 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DeploymentStatusVolumeSnapshotRestore(t *testing.T) {
+	var empty *DeploymentStatusVolumeSnapshotRestore
+
+	require.True(t, empty.Equal(nil))
+	require.Nil(t, empty.GetIDs(ServerGroupAgents))
+	_, ok := empty.GetVolumeSnapshotName(ServerGroupAgents, "A")
+	require.False(t, ok)
+
+	v := &DeploymentStatusVolumeSnapshotRestore{
+		Name: "snapshot",
+		Volumes: DeploymentStatusVolumeSnapshotRestoreVolumes{
+			{Group: ServerGroupAgents, ID: "A", VolumeSnapshotName: "snapshot-agent-a"},
+			{Group: ServerGroupDBServers, ID: "D", VolumeSnapshotName: "snapshot-dbserver-d"},
+		},
+	}
+
+	require.False(t, v.Equal(empty))
+	require.True(t, v.Equal(v.DeepCopy()))
+	require.Equal(t, []string{"D"}, v.GetIDs(ServerGroupDBServers))
+
+	name, ok := v.GetVolumeSnapshotName(ServerGroupAgents, "A")
+	require.True(t, ok)
+	require.Equal(t, "snapshot-agent-a", name)
+
+	_, ok = v.GetVolumeSnapshotName(ServerGroupDBServers, "A")
+	require.False(t, ok)
+}
@@ -172,6 +172,10 @@ const (
 	// ActionTypeRuntimeContainerArgsLogLevelUpdate updates the container's executor arguments.
 	ActionTypeRuntimeContainerArgsLogLevelUpdate ActionType = "RuntimeContainerArgsLogLevelUpdate"
 
+	// Volume Snapshots
+	// ActionTypeVolumeSnapshotCreate creates VolumeSnapshots for all member volumes
+	ActionTypeVolumeSnapshotCreate ActionType = "VolumeSnapshotCreate"
+
 	// Topology
 	ActionTypeTopologyEnable           ActionType = "TopologyEnable"
 	ActionTypeTopologyDisable          ActionType = "TopologyDisable"
@@ -22,6 +22,8 @@
 
 package v1
 
+import meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 // CollectionStatus contains the status of a single collection.
 type CollectionStatus struct {
 	// Name of the collection
@@ -29,4 +31,8 @@ type CollectionStatus struct {
 	// Replication status per shard.
 	// The list is ordered by shard index (0..noShards-1)
 	Shards []ShardStatus `json:"shards,omitempty"`
+	// ShardsNotInSync is the number of shards which are not running
+	ShardsNotInSync int `json:"shardsNotInSync,omitempty"`
+	// Delay is the highest delay of the shards
+	Delay *meta.Duration `json:"delay,omitempty"`
 }
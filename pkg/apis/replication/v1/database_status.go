@@ -22,6 +22,8 @@
 
 package v1
 
+import meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 // DatabaseStatus contains the status of a single database.
 type DatabaseStatus struct {
 	// Name of the database
@@ -29,4 +31,8 @@ type DatabaseStatus struct {
 	// Collections holds the replication status of each collection in the database.
 	// List is ordered by name of the collection.
 	Collections []CollectionStatus `json:"collections,omitempty"`
+	// ShardsNotInSync is the number of shards in all collections which are not running
+	ShardsNotInSync int `json:"shardsNotInSync,omitempty"`
+	// Delay is the highest delay of the shards in all collections
+	Delay *meta.Duration `json:"delay,omitempty"`
 }
@@ -0,0 +1,69 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+)
+
+// LagSpec contains the service level objective for the lag of the replication.
+// Thresholds which are not set are not checked.
+type LagSpec struct {
+	// MaxDelay is the highest allowed delay of a destination shard.
+	MaxDelay *meta.Duration `json:"maxDelay,omitempty"`
+	// MaxShardsNotInSync is the highest allowed number of destination shards that are not in sync.
+	MaxShardsNotInSync *int `json:"maxShardsNotInSync,omitempty"`
+}
+
+// Validate the given spec, returning an error on validation
+// problems or nil if all ok.
+func (s *LagSpec) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.MaxDelay != nil && s.MaxDelay.Duration <= 0 {
+		return errors.WithStack(errors.Wrapf(ValidationError, "maxDelay must be positive"))
+	}
+	if s.MaxShardsNotInSync != nil && *s.MaxShardsNotInSync < 0 {
+		return errors.WithStack(errors.Wrapf(ValidationError, "maxShardsNotInSync cannot be negative"))
+	}
+	return nil
+}
+
+// IsExceeded returns true with a reason when the given delay or number of shards
+// not in sync exceeds a threshold.
+func (s *LagSpec) IsExceeded(delay time.Duration, shardsNotInSync int) (bool, string) {
+	if s == nil {
+		return false, ""
+	}
+	if s.MaxDelay != nil && delay > s.MaxDelay.Duration {
+		return true, fmt.Sprintf("Delay %s exceeds %s", delay, s.MaxDelay.Duration)
+	}
+	if s.MaxShardsNotInSync != nil && shardsNotInSync > *s.MaxShardsNotInSync {
+		return true, fmt.Sprintf("%d shards not in sync, %d allowed", shardsNotInSync, *s.MaxShardsNotInSync)
+	}
+	return false, ""
+}
@@ -0,0 +1,71 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+func TestLagSpecValidate(t *testing.T) {
+	var s *LagSpec
+	assert.NoError(t, s.Validate())
+
+	assert.NoError(t, (&LagSpec{MaxDelay: &meta.Duration{Duration: time.Minute}, MaxShardsNotInSync: util.NewInt(0)}).Validate())
+	assert.True(t, IsValidation((&LagSpec{MaxDelay: &meta.Duration{}}).Validate()))
+	assert.True(t, IsValidation((&LagSpec{MaxShardsNotInSync: util.NewInt(-1)}).Validate()))
+}
+
+func TestLagSpecIsExceeded(t *testing.T) {
+	var s *LagSpec
+	exceeded, _ := s.IsExceeded(time.Hour, 100)
+	assert.False(t, exceeded)
+
+	s = &LagSpec{MaxDelay: &meta.Duration{Duration: time.Minute}}
+	exceeded, _ = s.IsExceeded(time.Minute, 100)
+	assert.False(t, exceeded)
+	exceeded, reason := s.IsExceeded(2*time.Minute, 0)
+	assert.True(t, exceeded)
+	assert.Equal(t, "Delay 2m0s exceeds 1m0s", reason)
+
+	s.MaxShardsNotInSync = util.NewInt(1)
+	exceeded, reason = s.IsExceeded(0, 2)
+	assert.True(t, exceeded)
+	assert.Equal(t, "2 shards not in sync, 1 allowed", reason)
+}
+
+func TestEndpointStatusLag(t *testing.T) {
+	s := EndpointStatus{
+		Databases: []DatabaseStatus{
+			{Name: "a", ShardsNotInSync: 1, Delay: &meta.Duration{Duration: time.Second}},
+			{Name: "b", ShardsNotInSync: 2, Delay: &meta.Duration{Duration: time.Minute}},
+			{Name: "c"},
+		},
+	}
+	delay, shardsNotInSync := s.Lag()
+	assert.Equal(t, time.Minute, delay)
+	assert.Equal(t, 3, shardsNotInSync)
+}
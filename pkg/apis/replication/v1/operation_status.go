@@ -0,0 +1,67 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// OperationState is a strongly typed state of a replication operation
+type OperationState string
+
+const (
+	// OperationStateRunning indicates that the operation is in progress
+	OperationStateRunning OperationState = "Running"
+	// OperationStateCompleted indicates that the direction of the replication has been reversed
+	OperationStateCompleted OperationState = "Completed"
+	// OperationStateAborted indicates that the operation was withdrawn or could not
+	// be finished before the synchronization was canceled.
+	OperationStateAborted OperationState = "Aborted"
+)
+
+// OperationStatus contains the status of the last replication operation.
+type OperationStatus struct {
+	// Type of the operation
+	Type OperationType `json:"type"`
+	// ID of the operation, taken from the spec
+	ID string `json:"id"`
+	// State of the operation
+	State OperationState `json:"state"`
+	// Message contains a human readable description of the progress
+	Message string `json:"message,omitempty"`
+	// StartTime is the time the operation was started
+	StartTime metav1.Time `json:"startTime"`
+	// FinishTime is the time the operation was completed or aborted
+	FinishTime *metav1.Time `json:"finishTime,omitempty"`
+	// SourceWritesStopped is set when the source deployment has been put into read-only mode
+	SourceWritesStopped bool `json:"sourceWritesStopped,omitempty"`
+	// DestinationWritesStopped is set when the old source, which became the destination after the switchover,
+	// is kept in read-only mode until the synchronization in the new direction is active
+	DestinationWritesStopped bool `json:"destinationWritesStopped,omitempty"`
+}
+
+// IsRunning returns true when the operation is in progress.
+func (s *OperationStatus) IsRunning() bool {
+	return s != nil && s.State == OperationStateRunning
+}
+
+// Is returns true when the status belongs to the given operation spec.
+func (s *OperationStatus) Is(spec *OperationSpec) bool {
+	return s != nil && spec != nil && s.ID == spec.ID && s.Type == spec.Type
+}
@@ -31,9 +31,27 @@ const (
 	// DeploymentReplicationPhaseFailed indicates that a deployment replication is in a failed state
 	// from which automatic recovery is impossible. Inspect `Reason` for more info.
 	DeploymentReplicationPhaseFailed DeploymentReplicationPhase = "Failed"
+	// DeploymentReplicationPhaseStoppingWrites indicates that the source deployment is being put into read-only mode
+	DeploymentReplicationPhaseStoppingWrites DeploymentReplicationPhase = "StoppingWrites"
+	// DeploymentReplicationPhaseWaitingForSync indicates that the operator waits until all destination shards are in sync
+	DeploymentReplicationPhaseWaitingForSync DeploymentReplicationPhase = "WaitingForSync"
+	// DeploymentReplicationPhaseCancellingSync indicates that the synchronization is being canceled
+	DeploymentReplicationPhaseCancellingSync DeploymentReplicationPhase = "CancellingSync"
+	// DeploymentReplicationPhaseReconfiguring indicates that the direction of the replication is being reversed
+	DeploymentReplicationPhaseReconfiguring DeploymentReplicationPhase = "Reconfiguring"
 )
 
 // IsFailed returns true if given state is DeploymentStateFailed
 func (cs DeploymentReplicationPhase) IsFailed() bool {
 	return cs == DeploymentReplicationPhaseFailed
 }
+
+// IsOperation returns true if given phase is part of a switchover or failover operation
+func (cs DeploymentReplicationPhase) IsOperation() bool {
+	switch cs {
+	case DeploymentReplicationPhaseStoppingWrites, DeploymentReplicationPhaseWaitingForSync,
+		DeploymentReplicationPhaseCancellingSync, DeploymentReplicationPhaseReconfiguring:
+		return true
+	}
+	return false
+}
@@ -38,7 +38,19 @@ type DeploymentReplicationStatus struct {
 	// Destination contains the detailed status of the destination endpoint
 	Destination EndpointStatus `json:"destination"`
 
+	// SourceHealth contains the reachability of the source syncmaster
+	SourceHealth *EndpointHealth `json:"sourceHealth,omitempty"`
+	// DestinationHealth contains the reachability of the destination syncmaster
+	DestinationHealth *EndpointHealth `json:"destinationHealth,omitempty"`
+
 	// CancelFailures records the number of times that the configuration was canceled
 	// which resulted in an error.
 	CancelFailures int `json:"cancel-failures,omitempty"`
+
+	// SecretHashes keeps a sha256 hash of the secrets used to configure the synchronization,
+	// so the synchronization can be configured again when they are rotated.
+	SecretHashes *SecretHashes `json:"secret-hashes,omitempty"`
+
+	// Operation contains the status of the last switchover or failover operation
+	Operation *OperationStatus `json:"operation,omitempty"`
 }
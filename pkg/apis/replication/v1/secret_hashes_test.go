@@ -0,0 +1,42 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretHashesEqual(t *testing.T) {
+	var empty *SecretHashes
+	sh := &SecretHashes{SourceKeyfile: "a", SourceTLSCA: "b"}
+
+	assert.False(t, empty.Equal(sh))
+	assert.False(t, sh.Equal(empty))
+	assert.True(t, sh.Equal(sh))
+	assert.True(t, sh.Equal(&SecretHashes{SourceKeyfile: "a", SourceTLSCA: "b"}))
+	assert.False(t, sh.Equal(&SecretHashes{SourceKeyfile: "c", SourceTLSCA: "b"}))
+	assert.False(t, sh.Equal(&SecretHashes{SourceKeyfile: "a", SourceTLSCA: "c"}))
+	assert.False(t, sh.Equal(&SecretHashes{SourceKeyfile: "a", SourceTLSCA: "b", SourceJWT: "c"}))
+	assert.False(t, sh.Equal(&SecretHashes{SourceKeyfile: "a", SourceTLSCA: "b", DestinationJWT: "c"}))
+	assert.False(t, sh.Equal(&SecretHashes{SourceKeyfile: "a", SourceTLSCA: "b", DestinationTLSCA: "c"}))
+}
@@ -25,6 +25,7 @@
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -97,6 +98,11 @@ func (in *CollectionStatus) DeepCopyInto(out *CollectionStatus) {
 		*out = make([]ShardStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -160,6 +166,11 @@ func (in *DatabaseStatus) DeepCopyInto(out *DatabaseStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -178,6 +189,16 @@ func (in *DeploymentReplicationSpec) DeepCopyInto(out *DeploymentReplicationSpec
 	*out = *in
 	in.Source.DeepCopyInto(&out.Source)
 	in.Destination.DeepCopyInto(&out.Destination)
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(OperationSpec)
+		**out = **in
+	}
+	if in.Lag != nil {
+		in, out := &in.Lag, &out.Lag
+		*out = new(LagSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -203,6 +224,26 @@ func (in *DeploymentReplicationStatus) DeepCopyInto(out *DeploymentReplicationSt
 	}
 	in.Source.DeepCopyInto(&out.Source)
 	in.Destination.DeepCopyInto(&out.Destination)
+	if in.SourceHealth != nil {
+		in, out := &in.SourceHealth, &out.SourceHealth
+		*out = new(EndpointHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DestinationHealth != nil {
+		in, out := &in.DestinationHealth, &out.DestinationHealth
+		*out = new(EndpointHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretHashes != nil {
+		in, out := &in.SecretHashes, &out.SecretHashes
+		*out = new(SecretHashes)
+		**out = **in
+	}
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(OperationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -242,6 +283,30 @@ func (in *EndpointAuthenticationSpec) DeepCopy() *EndpointAuthenticationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointHealth) DeepCopyInto(out *EndpointHealth) {
+	*out = *in
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointHealth.
+func (in *EndpointHealth) DeepCopy() *EndpointHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EndpointSpec) DeepCopyInto(out *EndpointSpec) {
 	*out = *in
@@ -314,6 +379,85 @@ func (in *EndpointTLSSpec) DeepCopy() *EndpointTLSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LagSpec) DeepCopyInto(out *LagSpec) {
+	*out = *in
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxShardsNotInSync != nil {
+		in, out := &in.MaxShardsNotInSync, &out.MaxShardsNotInSync
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LagSpec.
+func (in *LagSpec) DeepCopy() *LagSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LagSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationSpec) DeepCopyInto(out *OperationSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationSpec.
+func (in *OperationSpec) DeepCopy() *OperationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationStatus) DeepCopyInto(out *OperationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationStatus.
+func (in *OperationStatus) DeepCopy() *OperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretHashes) DeepCopyInto(out *SecretHashes) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretHashes.
+func (in *SecretHashes) DeepCopy() *SecretHashes {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretHashes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShardStatus) DeepCopyInto(out *ShardStatus) {
 	*out = *in
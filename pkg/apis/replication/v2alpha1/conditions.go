@@ -33,6 +33,12 @@ type ConditionType string
 const (
 	// ConditionTypeConfigured indicates that the replication has been configured.
 	ConditionTypeConfigured ConditionType = "Configured"
+	// ConditionTypeLagExceeded indicates that the destination is behind the thresholds set in `spec.lag`.
+	ConditionTypeLagExceeded ConditionType = "LagExceeded"
+	// ConditionTypeSourceUnreachable indicates that the operator cannot reach the source syncmaster.
+	ConditionTypeSourceUnreachable ConditionType = "SourceUnreachable"
+	// ConditionTypeDestinationUnreachable indicates that the operator cannot reach the destination syncmaster.
+	ConditionTypeDestinationUnreachable ConditionType = "DestinationUnreachable"
 )
 
 // Condition represents one current condition of a deployment or deployment member.
@@ -0,0 +1,80 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"testing"
+
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	"github.com/arangodb/kube-arangodb/pkg/apis/shared/conversiontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testConversionObjectMeta() meta.ObjectMeta {
+	return meta.ObjectMeta{
+		Name:            "replication",
+		Namespace:       "default",
+		UID:             "2b3f4e5d-0000-0000-0000-000000000000",
+		ResourceVersion: "42",
+		Labels:          map[string]string{"app": "arangodb"},
+		Finalizers:      []string{"replication.database.arangodb.com/stop-sync"},
+	}
+}
+
+func TestConversionFieldParity(t *testing.T) {
+	// A field which differs between the versions needs an explicit conversion, see conversion.go
+	assert.Empty(t, conversiontest.FieldDiff(v1.ArangoDeploymentReplication{}, ArangoDeploymentReplication{}))
+}
+
+func TestArangoDeploymentReplicationConversionRoundTrip(t *testing.T) {
+	t.Run("From hub", func(t *testing.T) {
+		var hub v1.ArangoDeploymentReplication
+		conversiontest.Fill(&hub)
+		hub.TypeMeta = meta.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: "ArangoDeploymentReplication"}
+		hub.ObjectMeta = testConversionObjectMeta()
+
+		var r ArangoDeploymentReplication
+		require.NoError(t, r.ConvertFrom(&hub))
+		assert.Equal(t, SchemeGroupVersion.String(), r.APIVersion)
+		assert.Equal(t, hub.ObjectMeta, r.ObjectMeta)
+
+		var result v1.ArangoDeploymentReplication
+		require.NoError(t, r.ConvertTo(&result))
+		assert.Equal(t, hub, result)
+	})
+
+	t.Run("To hub", func(t *testing.T) {
+		var r ArangoDeploymentReplication
+		conversiontest.Fill(&r)
+		r.TypeMeta = meta.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "ArangoDeploymentReplication"}
+		r.ObjectMeta = testConversionObjectMeta()
+
+		var hub v1.ArangoDeploymentReplication
+		require.NoError(t, r.ConvertTo(&hub))
+		assert.Equal(t, v1.SchemeGroupVersion.String(), hub.APIVersion)
+
+		var result ArangoDeploymentReplication
+		require.NoError(t, result.ConvertFrom(&hub))
+		assert.Equal(t, r, result)
+	})
+}
@@ -0,0 +1,58 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// EndpointHealth contains the reachability of the syncmaster of an endpoint,
+// as seen by the operator.
+type EndpointHealth struct {
+	// ConsecutiveFailures is the number of failed requests since the last successful one
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+	// LastSuccessTime is the time of the last successful request
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+	// LastFailureTime is the time of the last failed request
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+	// LastError contains the error of the last failed request, cleared on success
+	LastError string `json:"lastError,omitempty"`
+}
+
+// GetConsecutiveFailures returns the number of consecutive failures, 0 if health is unknown.
+func (h *EndpointHealth) GetConsecutiveFailures() int {
+	if h == nil {
+		return 0
+	}
+	return h.ConsecutiveFailures
+}
+
+// RecordSuccess resets the failures and sets the time of the last success.
+func (h *EndpointHealth) RecordSuccess(now metav1.Time) {
+	h.ConsecutiveFailures = 0
+	h.LastError = ""
+	h.LastSuccessTime = &now
+}
+
+// RecordFailure increments the failures and keeps the given error.
+func (h *EndpointHealth) RecordFailure(now metav1.Time, err error) {
+	h.ConsecutiveFailures++
+	h.LastError = err.Error()
+	h.LastFailureTime = &now
+}
@@ -0,0 +1,50 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+)
+
+func TestEndpointHealth(t *testing.T) {
+	var empty *EndpointHealth
+	assert.Equal(t, 0, empty.GetConsecutiveFailures())
+
+	now := metav1.Now()
+	h := &EndpointHealth{}
+	h.RecordFailure(now, errors.New("connection refused"))
+	h.RecordFailure(now, errors.New("timeout"))
+	assert.Equal(t, 2, h.GetConsecutiveFailures())
+	assert.Equal(t, "timeout", h.LastError)
+	assert.Equal(t, &now, h.LastFailureTime)
+	assert.Nil(t, h.LastSuccessTime)
+
+	h.RecordSuccess(now)
+	assert.Equal(t, 0, h.GetConsecutiveFailures())
+	assert.Empty(t, h.LastError)
+	assert.Equal(t, &now, h.LastSuccessTime)
+	assert.Equal(t, &now, h.LastFailureTime)
+}
@@ -22,9 +22,24 @@
 
 package v2alpha1
 
+import "time"
+
 // EndpointStatus contains the status of either the source or destination endpoint.
 type EndpointStatus struct {
 	// Databases holds the replication status of all databases from the point of view of this endpoint.
 	// List is ordered by name of the database.
 	Databases []DatabaseStatus `json:"databases,omitempty"`
 }
+
+// Lag returns the highest delay and the number of shards not in sync over all databases.
+func (s EndpointStatus) Lag() (time.Duration, int) {
+	var delay time.Duration
+	shardsNotInSync := 0
+	for _, db := range s.Databases {
+		if db.Delay != nil && db.Delay.Duration > delay {
+			delay = db.Delay.Duration
+		}
+		shardsNotInSync += db.ShardsNotInSync
+	}
+	return delay, shardsNotInSync
+}
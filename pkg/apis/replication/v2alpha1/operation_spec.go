@@ -0,0 +1,66 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import "github.com/arangodb/kube-arangodb/pkg/util/errors"
+
+// OperationType is a strongly typed kind of replication operation
+type OperationType string
+
+const (
+	// OperationTypeSwitchover stops writes on the source, waits until the destination
+	// is in sync, cancels the synchronization and reverses its direction.
+	OperationTypeSwitchover OperationType = "Switchover"
+	// OperationTypeFailover forcefully cancels the synchronization without waiting
+	// for the source (which may be gone) and reverses its direction.
+	OperationTypeFailover OperationType = "Failover"
+)
+
+// IsValid returns true when the operation type is known.
+func (t OperationType) IsValid() bool {
+	switch t {
+	case OperationTypeSwitchover, OperationTypeFailover:
+		return true
+	}
+	return false
+}
+
+// OperationSpec contains the specification of an operation that changes
+// the direction of the replication.
+type OperationSpec struct {
+	// Type of the operation, one of Switchover or Failover.
+	Type OperationType `json:"type"`
+	// ID identifies the operation. An operation with a given ID is executed once,
+	// set a new ID to request another operation.
+	ID string `json:"id"`
+}
+
+// Validate the given spec, returning an error on validation
+// problems or nil if all ok.
+func (s OperationSpec) Validate() error {
+	if !s.Type.IsValid() {
+		return errors.WithStack(errors.Wrapf(ValidationError, "Invalid operation type '%s', expected %s or %s", s.Type, OperationTypeSwitchover, OperationTypeFailover))
+	}
+	if s.ID == "" {
+		return errors.WithStack(errors.Wrapf(ValidationError, "Provide an operation id"))
+	}
+	return nil
+}
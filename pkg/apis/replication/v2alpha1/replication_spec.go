@@ -29,6 +29,10 @@ import "github.com/arangodb/kube-arangodb/pkg/util/errors"
 type DeploymentReplicationSpec struct {
 	Source      EndpointSpec `json:"source"`
 	Destination EndpointSpec `json:"destination"`
+	// Operation requests a switchover or failover of the replication direction.
+	Operation *OperationSpec `json:"operation,omitempty"`
+	// Lag holds the thresholds of the LagExceeded condition.
+	Lag *LagSpec `json:"lag,omitempty"`
 }
 
 // Validate the given spec, returning an error on validation
@@ -40,9 +44,31 @@ func (s DeploymentReplicationSpec) Validate() error {
 	if err := s.Destination.Validate(false); err != nil {
 		return errors.WithStack(err)
 	}
+	if err := s.Lag.Validate(); err != nil {
+		return errors.WithStack(err)
+	}
+	if s.Operation != nil {
+		if err := s.Operation.Validate(); err != nil {
+			return errors.WithStack(err)
+		}
+		// The destination becomes the source once the direction is reversed.
+		if err := s.Destination.Validate(true); err != nil {
+			return errors.WithStack(errors.Wrapf(ValidationError, "Destination cannot become source of the replication: %s", err))
+		}
+		if s.Operation.Type == OperationTypeSwitchover && !s.Source.HasDeploymentName() {
+			return errors.WithStack(errors.Wrapf(ValidationError, "Switchover requires a source deploymentName to stop writes, use %s instead", OperationTypeFailover))
+		}
+	}
 	return nil
 }
 
+// Reversed returns a copy of the spec with source and destination swapped.
+func (s DeploymentReplicationSpec) Reversed() DeploymentReplicationSpec {
+	r := *s.DeepCopy()
+	r.Source, r.Destination = r.Destination, r.Source
+	return r
+}
+
 // SetDefaults fills empty field with default values.
 func (s *DeploymentReplicationSpec) SetDefaults() {
 	s.Source.SetDefaults()
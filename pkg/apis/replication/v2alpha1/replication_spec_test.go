@@ -0,0 +1,96 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+func newTestReplicationSpec() DeploymentReplicationSpec {
+	return DeploymentReplicationSpec{
+		Source: EndpointSpec{
+			DeploymentName: util.NewString("dc1"),
+			Authentication: EndpointAuthenticationSpec{KeyfileSecretName: util.NewString("dc1-client")},
+		},
+		Destination: EndpointSpec{
+			DeploymentName: util.NewString("dc2"),
+		},
+	}
+}
+
+func TestDeploymentReplicationSpecValidateOperation(t *testing.T) {
+	s := newTestReplicationSpec()
+	assert.NoError(t, s.Validate())
+
+	s.Operation = &OperationSpec{Type: "Unknown", ID: "1"}
+	assert.True(t, IsValidation(s.Validate()))
+
+	s.Operation = &OperationSpec{Type: OperationTypeSwitchover}
+	assert.True(t, IsValidation(s.Validate()))
+
+	// Destination needs a keyfile to become the source
+	s.Operation = &OperationSpec{Type: OperationTypeSwitchover, ID: "1"}
+	assert.True(t, IsValidation(s.Validate()))
+
+	s.Destination.Authentication.KeyfileSecretName = util.NewString("dc2-client")
+	assert.NoError(t, s.Validate())
+
+	// Switchover needs a source deployment to stop writes
+	s.Source.DeploymentName = nil
+	s.Source.MasterEndpoint = []string{"https://dc1:8629"}
+	s.Source.TLS.CASecretName = util.NewString("dc1-ca")
+	assert.True(t, IsValidation(s.Validate()))
+
+	s.Operation.Type = OperationTypeFailover
+	assert.NoError(t, s.Validate())
+}
+
+func TestDeploymentReplicationSpecReversed(t *testing.T) {
+	s := newTestReplicationSpec()
+	s.Operation = &OperationSpec{Type: OperationTypeSwitchover, ID: "1"}
+
+	r := s.Reversed()
+	assert.Equal(t, "dc2", r.Source.GetDeploymentName())
+	assert.Equal(t, "dc1", r.Destination.GetDeploymentName())
+	assert.Equal(t, "dc1-client", r.Destination.Authentication.GetKeyfileSecretName())
+	assert.Equal(t, s.Operation, r.Operation)
+
+	// Source spec is not modified
+	r.Operation.ID = "2"
+	assert.Equal(t, "dc1", s.Source.GetDeploymentName())
+	assert.Equal(t, "1", s.Operation.ID)
+}
+
+func TestOperationStatusIs(t *testing.T) {
+	var s *OperationStatus
+	assert.False(t, s.IsRunning())
+	assert.False(t, s.Is(&OperationSpec{Type: OperationTypeFailover, ID: "1"}))
+
+	s = &OperationStatus{Type: OperationTypeFailover, ID: "1", State: OperationStateRunning}
+	assert.True(t, s.IsRunning())
+	assert.True(t, s.Is(&OperationSpec{Type: OperationTypeFailover, ID: "1"}))
+	assert.False(t, s.Is(&OperationSpec{Type: OperationTypeFailover, ID: "2"}))
+	assert.False(t, s.Is(nil))
+}
@@ -0,0 +1,61 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package v2alpha1
+
+// SecretHashes keeps track of the value of secrets used to configure
+// the synchronization, so we can detect changes.
+// For each used secret, a sha256 hash is stored.
+type SecretHashes struct {
+	// SourceKeyfile contains the hash of the source.auth.keyfileSecretName secret
+	SourceKeyfile string `json:"source-keyfile,omitempty"`
+	// SourceUser contains the hash of the source.auth.userSecretName secret
+	SourceUser string `json:"source-user,omitempty"`
+	// SourceJWT contains the hash of the JWT secret of the source
+	SourceJWT string `json:"source-jwt,omitempty"`
+	// SourceTLSCA contains the hash of the TLS CA secret of the source
+	SourceTLSCA string `json:"source-tls-ca,omitempty"`
+	// DestinationKeyfile contains the hash of the destination.auth.keyfileSecretName secret
+	DestinationKeyfile string `json:"destination-keyfile,omitempty"`
+	// DestinationUser contains the hash of the destination.auth.userSecretName secret
+	DestinationUser string `json:"destination-user,omitempty"`
+	// DestinationJWT contains the hash of the JWT secret of the destination
+	DestinationJWT string `json:"destination-jwt,omitempty"`
+	// DestinationTLSCA contains the hash of the TLS CA secret of the destination
+	DestinationTLSCA string `json:"destination-tls-ca,omitempty"`
+}
+
+// Equal compares two SecretHashes
+func (sh *SecretHashes) Equal(other *SecretHashes) bool {
+	if sh == nil || other == nil {
+		return false
+	} else if sh == other {
+		return true
+	}
+
+	return sh.SourceKeyfile == other.SourceKeyfile &&
+		sh.SourceUser == other.SourceUser &&
+		sh.SourceJWT == other.SourceJWT &&
+		sh.SourceTLSCA == other.SourceTLSCA &&
+		sh.DestinationKeyfile == other.DestinationKeyfile &&
+		sh.DestinationUser == other.DestinationUser &&
+		sh.DestinationJWT == other.DestinationJWT &&
+		sh.DestinationTLSCA == other.DestinationTLSCA
+}
@@ -0,0 +1,40 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package shared
+
+import (
+	"encoding/json"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+)
+
+// ConvertFields copies the fields with the same JSON name from src to dst.
+// It is used to convert objects between API versions which share the same fields.
+func ConvertFields(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
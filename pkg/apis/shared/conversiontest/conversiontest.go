@@ -0,0 +1,183 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Package conversiontest contains helpers to test the conversion between API versions.
+package conversiontest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// modulePath is the prefix of the packages with the API types, other types are compared and filled as opaque values
+	modulePath = "github.com/arangodb/kube-arangodb/"
+	// maxDepth limits the recursion of self referencing types
+	maxDepth = 32
+)
+
+// Fill sets all fields of the API types in the object pointed by obj to non zero values.
+// Fields of types from other modules (e.g. Kubernetes core types) are left empty,
+// as their JSON representation is not kept for all values.
+func Fill(obj interface{}) {
+	fill(reflect.ValueOf(obj).Elem(), 0)
+}
+
+func fill(v reflect.Value, depth int) {
+	if depth > maxDepth || !v.CanSet() || !isAPIType(v.Type()) {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("value")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	case reflect.Ptr:
+		if !isAPIType(v.Type().Elem()) {
+			return
+		}
+		p := reflect.New(v.Type().Elem())
+		fill(p.Elem(), depth+1)
+		v.Set(p)
+	case reflect.Slice:
+		if !isAPIType(v.Type().Elem()) {
+			return
+		}
+		s := reflect.MakeSlice(v.Type(), 1, 1)
+		fill(s.Index(0), depth+1)
+		v.Set(s)
+	case reflect.Map:
+		if !isAPIType(v.Type().Key()) || !isAPIType(v.Type().Elem()) {
+			return
+		}
+		m := reflect.MakeMap(v.Type())
+		key := reflect.New(v.Type().Key()).Elem()
+		fill(key, depth+1)
+		value := reflect.New(v.Type().Elem()).Elem()
+		fill(value, depth+1)
+		m.SetMapIndex(key, value)
+		v.Set(m)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fill(v.Field(i), depth+1)
+		}
+	}
+}
+
+// isAPIType returns true for types declared in this module and for unnamed types.
+func isAPIType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		if t.Name() == "" {
+			return isAPIType(t.Elem())
+		}
+	}
+	return t.PkgPath() == "" || strings.HasPrefix(t.PkgPath(), modulePath)
+}
+
+// FieldDiff returns JSON paths of fields which differ between the types of objects a and b.
+// Types of other modules have to be the same, API types are compared by their JSON fields.
+func FieldDiff(a, b interface{}) []string {
+	var result []string
+	fieldDiff(reflect.TypeOf(a), reflect.TypeOf(b), "", &result, 0)
+	return result
+}
+
+func fieldDiff(a, b reflect.Type, path string, result *[]string, depth int) {
+	if depth > maxDepth {
+		return
+	}
+	if !isAPIType(a) || !isAPIType(b) {
+		if a != b {
+			*result = append(*result, fmt.Sprintf("%s: %s != %s", pathOrRoot(path), a, b))
+		}
+		return
+	}
+	if a.Kind() != b.Kind() {
+		*result = append(*result, fmt.Sprintf("%s: %s != %s", pathOrRoot(path), a.Kind(), b.Kind()))
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Slice:
+		fieldDiff(a.Elem(), b.Elem(), path, result, depth+1)
+	case reflect.Map:
+		fieldDiff(a.Key(), b.Key(), path+"{key}", result, depth+1)
+		fieldDiff(a.Elem(), b.Elem(), path, result, depth+1)
+	case reflect.Struct:
+		fieldsA, fieldsB := jsonFields(a), jsonFields(b)
+		for name, fa := range fieldsA {
+			fb, ok := fieldsB[name]
+			if !ok {
+				*result = append(*result, fmt.Sprintf("%s: missing in %s", path+"."+name, b))
+				continue
+			}
+			fieldDiff(fa.Type, fb.Type, path+"."+name, result, depth+1)
+		}
+		for name := range fieldsB {
+			if _, ok := fieldsA[name]; !ok {
+				*result = append(*result, fmt.Sprintf("%s: missing in %s", path+"."+name, a))
+			}
+		}
+	}
+}
+
+// jsonFields returns exported fields of the struct type by their JSON name.
+// Fields of inlined structs are returned as fields of the struct.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	result := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct && isAPIType(f.Type) {
+			for n, inlined := range jsonFields(f.Type) {
+				result[n] = inlined
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		result[name] = f
+	}
+	return result
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
@@ -0,0 +1,79 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package conversiontest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+)
+
+type testSpec struct {
+	Name      *string           `json:"name,omitempty"`
+	Replicas  int               `json:"replicas,omitempty"`
+	Args      []string          `json:"args,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Resources core.ResourceRequirements
+	Nested    *testNested `json:"nested,omitempty"`
+}
+
+type testNested struct {
+	Enabled bool `json:"enabled"`
+}
+
+type testInline struct {
+	testNested `json:",inline"`
+	Other      string `json:"other"`
+}
+
+type testRenamed struct {
+	Name      *string           `json:"displayName,omitempty"`
+	Replicas  int32             `json:"replicas,omitempty"`
+	Args      []string          `json:"args,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Resources core.ResourceRequirements
+	Nested    *testInline `json:"nested,omitempty"`
+}
+
+func TestFill(t *testing.T) {
+	var s testSpec
+	Fill(&s)
+
+	assert.Equal(t, "value", *s.Name)
+	assert.Equal(t, 1, s.Replicas)
+	assert.Equal(t, []string{"value"}, s.Args)
+	assert.Equal(t, map[string]string{"value": "value"}, s.Labels)
+	assert.True(t, s.Nested.Enabled)
+	// Types of other modules are left empty
+	assert.Equal(t, core.ResourceRequirements{}, s.Resources)
+}
+
+func TestFieldDiff(t *testing.T) {
+	assert.Empty(t, FieldDiff(testSpec{}, testSpec{}))
+
+	assert.ElementsMatch(t, []string{
+		".replicas: int != int32",
+		".name: missing in conversiontest.testRenamed",
+		".displayName: missing in conversiontest.testSpec",
+		".nested.other: missing in conversiontest.testNested",
+	}, FieldDiff(testSpec{}, testRenamed{}))
+}
@@ -61,6 +61,7 @@ func (a ArangoPlanCollections) IsDBServerInCollections(name string) bool {
 }
 
 type ArangoPlanCollection struct {
+	Name   string          `json:"name"`
 	Shards ArangoPlanShard `json:"shards"`
 }
 
@@ -26,4 +26,7 @@ const (
 	ArangoKey          = "arango"
 	PlanKey            = "Plan"
 	PlanCollectionsKey = "Collections"
+	CurrentKey         = "Current"
+	SupervisionKey     = "Supervision"
+	TargetKey          = "Target"
 )
@@ -0,0 +1,195 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package agency
+
+import (
+	"sort"
+)
+
+// JobState is the state of a supervision job.
+type JobState string
+
+const (
+	JobStateToDo    JobState = "todo"
+	JobStatePending JobState = "pending"
+)
+
+// ShardDistribution describes how shards are distributed over DBServers.
+type ShardDistribution struct {
+	// Servers maps DBServer IDs to their shards.
+	Servers map[string]ServerShards `json:"servers"`
+	// OutOfSync contains shards with at least one planned follower which is not in sync.
+	OutOfSync []ShardInfo `json:"out_of_sync"`
+	// PendingJobs contains supervision jobs which are not finished yet.
+	PendingJobs []PendingJob `json:"pending_jobs"`
+	// Maintenance is true when the supervision maintenance mode is enabled.
+	Maintenance bool `json:"maintenance"`
+}
+
+// ServerShards describes the shards of a single DBServer.
+type ServerShards struct {
+	ShortName string `json:"short_name,omitempty"`
+	Health    string `json:"health,omitempty"`
+	Leaders   int    `json:"leaders"`
+	Followers int    `json:"followers"`
+	OutOfSync int    `json:"out_of_sync"`
+}
+
+// Shards returns the number of shards planned on the server.
+func (s ServerShards) Shards() int {
+	return s.Leaders + s.Followers
+}
+
+// ShardInfo describes a single shard.
+type ShardInfo struct {
+	Database   string `json:"database"`
+	Collection string `json:"collection"`
+	Shard      string `json:"shard"`
+	// Planned contains the planned leader followed by the planned followers.
+	Planned []string `json:"planned"`
+	// Current contains the current leader followed by the followers in sync.
+	Current []string `json:"current"`
+	// OutOfSync contains planned servers which are not in sync.
+	OutOfSync []string `json:"out_of_sync"`
+}
+
+// PendingJob is a supervision job which is not finished yet.
+type PendingJob struct {
+	ArangoJob
+	State JobState `json:"state"`
+}
+
+// ShardDistribution returns the distribution of shards over DBServers.
+func (a ArangoState) ShardDistribution() ShardDistribution {
+	d := ShardDistribution{
+		Servers:     map[string]ServerShards{},
+		OutOfSync:   []ShardInfo{},
+		PendingJobs: []PendingJob{},
+		Maintenance: a.Supervision.IsMaintenanceEnabled(),
+	}
+
+	for id := range a.Plan.DBServers {
+		d.Servers[id] = ServerShards{}
+	}
+
+	for database, collections := range a.Plan.Collections {
+		for collectionID, collection := range collections {
+			collectionName := collection.Name
+			if collectionName == "" {
+				collectionName = collectionID
+			}
+
+			for shard, planned := range collection.Shards {
+				current := a.Current.Collections[database][collectionID][shard].Servers
+				outOfSync := notIn(planned, current)
+
+				for i, server := range planned {
+					s := d.Servers[server]
+					if i == 0 {
+						s.Leaders++
+					} else {
+						s.Followers++
+					}
+					d.Servers[server] = s
+				}
+
+				for _, server := range outOfSync {
+					s := d.Servers[server]
+					s.OutOfSync++
+					d.Servers[server] = s
+				}
+
+				if len(outOfSync) > 0 {
+					d.OutOfSync = append(d.OutOfSync, ShardInfo{
+						Database:   database,
+						Collection: collectionName,
+						Shard:      shard,
+						Planned:    planned,
+						Current:    current,
+						OutOfSync:  outOfSync,
+					})
+				}
+			}
+		}
+	}
+
+	for id, s := range d.Servers {
+		if h, ok := a.Supervision.Health[id]; ok {
+			s.ShortName = h.ShortName
+			s.Health = h.Status
+			d.Servers[id] = s
+		}
+	}
+
+	sort.Slice(d.OutOfSync, func(i, j int) bool {
+		a, b := d.OutOfSync[i], d.OutOfSync[j]
+		if a.Database != b.Database {
+			return a.Database < b.Database
+		}
+		if a.Collection != b.Collection {
+			return a.Collection < b.Collection
+		}
+		return a.Shard < b.Shard
+	})
+
+	for state, jobs := range map[JobState]ArangoJobs{
+		JobStateToDo:    a.Target.ToDo,
+		JobStatePending: a.Target.Pending,
+	} {
+		for id, job := range jobs {
+			if job.ID == "" {
+				job.ID = id
+			}
+			d.PendingJobs = append(d.PendingJobs, PendingJob{
+				ArangoJob: job,
+				State:     state,
+			})
+		}
+	}
+
+	sort.Slice(d.PendingJobs, func(i, j int) bool {
+		a, b := d.PendingJobs[i], d.PendingJobs[j]
+		if a.TimeCreated != b.TimeCreated {
+			return a.TimeCreated < b.TimeCreated
+		}
+		return a.ID < b.ID
+	})
+
+	return d
+}
+
+// notIn returns the elements of a which are not present in b.
+func notIn(a, b []string) []string {
+	r := make([]string, 0)
+	for _, x := range a {
+		found := false
+		for _, y := range b {
+			if x == y {
+				found = true
+				break
+			}
+		}
+		if !found {
+			r = append(r, x)
+		}
+	}
+	return r
+}
@@ -0,0 +1,130 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package agency
+
+import (
+	"context"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+)
+
+// GetAgencyState reads Plan, Current, Supervision and Target from the agency.
+func GetAgencyState(ctx context.Context, f Fetcher) (*ArangoState, error) {
+	ret := &ArangoState{}
+
+	for key, obj := range map[string]interface{}{
+		PlanKey:        &ret.Plan,
+		CurrentKey:     &ret.Current,
+		SupervisionKey: &ret.Supervision,
+		TargetKey:      &ret.Target,
+	} {
+		if err := f(ctx, obj, ArangoKey, key); err != nil {
+			return nil, errors.Wrapf(err, "unable to read %s from agency", key)
+		}
+	}
+
+	return ret, nil
+}
+
+// ArangoState is the part of the agency state used by the operator.
+type ArangoState struct {
+	Plan        ArangoPlan        `json:"Plan"`
+	Current     ArangoCurrent     `json:"Current"`
+	Supervision ArangoSupervision `json:"Supervision"`
+	Target      ArangoTarget      `json:"Target"`
+}
+
+// ArangoPlan is the desired state of the cluster.
+type ArangoPlan struct {
+	Collections ArangoPlanDatabases `json:"Collections,omitempty"`
+	// DBServers contains IDs of all DBServers known to the cluster.
+	DBServers map[string]interface{} `json:"DBServers,omitempty"`
+}
+
+// ArangoCurrent is the actual state of the cluster, as reported by the servers.
+type ArangoCurrent struct {
+	Collections ArangoCurrentDatabases `json:"Collections,omitempty"`
+}
+
+type ArangoCurrentDatabases map[string]ArangoCurrentCollections
+
+type ArangoCurrentCollections map[string]ArangoCurrentCollection
+
+type ArangoCurrentCollection map[string]ArangoCurrentShard
+
+// ArangoCurrentShard is the state of a shard. Servers contains the leader followed by in sync followers.
+type ArangoCurrentShard struct {
+	Servers            []string `json:"servers,omitempty"`
+	FailoverCandidates []string `json:"failoverCandidates,omitempty"`
+	Error              bool     `json:"error,omitempty"`
+	ErrorMessage       string   `json:"errorMessage,omitempty"`
+}
+
+// ArangoSupervision is the state of the supervision of the cluster.
+type ArangoSupervision struct {
+	Health      map[string]ArangoSupervisionHealth `json:"Health,omitempty"`
+	Maintenance interface{}                        `json:"Maintenance,omitempty"`
+}
+
+// IsMaintenanceEnabled returns true if the supervision maintenance mode is enabled.
+func (a ArangoSupervision) IsMaintenanceEnabled() bool {
+	return a.Maintenance != nil
+}
+
+// ArangoSupervisionHealth is the health of a server, as seen by the supervision.
+type ArangoSupervisionHealth struct {
+	ShortName     string `json:"ShortName,omitempty"`
+	Endpoint      string `json:"Endpoint,omitempty"`
+	Host          string `json:"Host,omitempty"`
+	Status        string `json:"Status,omitempty"`
+	SyncStatus    string `json:"SyncStatus,omitempty"`
+	LastAckedTime string `json:"LastAckedTime,omitempty"`
+}
+
+// ArangoTarget contains the jobs of the supervision and the servers being cleaned out.
+type ArangoTarget struct {
+	ToDo               ArangoJobs `json:"ToDo,omitempty"`
+	Pending            ArangoJobs `json:"Pending,omitempty"`
+	Failed             ArangoJobs `json:"Failed,omitempty"`
+	Finished           ArangoJobs `json:"Finished,omitempty"`
+	CleanedServers     []string   `json:"CleanedServers,omitempty"`
+	ToBeCleanedServers []string   `json:"ToBeCleanedServers,omitempty"`
+}
+
+// ArangoJobs maps job IDs to jobs.
+type ArangoJobs map[string]ArangoJob
+
+// ArangoJob is a job of the supervision. Fields are set depending on the type of the job.
+type ArangoJob struct {
+	ID           string `json:"jobId,omitempty"`
+	Type         string `json:"type,omitempty"`
+	Creator      string `json:"creator,omitempty"`
+	TimeCreated  string `json:"timeCreated,omitempty"`
+	TimeStarted  string `json:"timeStarted,omitempty"`
+	TimeFinished string `json:"timeFinished,omitempty"`
+	Database     string `json:"database,omitempty"`
+	Collection   string `json:"collection,omitempty"`
+	Shard        string `json:"shard,omitempty"`
+	Server       string `json:"server,omitempty"`
+	FromServer   string `json:"fromServer,omitempty"`
+	ToServer     string `json:"toServer,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+}
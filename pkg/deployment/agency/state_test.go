@@ -0,0 +1,151 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package agency
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testAgencyState = `{
+  "Plan": {
+    "DBServers": {"PRMR-1": "none", "PRMR-2": "none", "PRMR-3": "none"},
+    "Collections": {
+      "_system": {
+        "1001": {
+          "name": "orders",
+          "shards": {
+            "s1": ["PRMR-1", "PRMR-2"],
+            "s2": ["PRMR-2", "PRMR-1"]
+          }
+        }
+      }
+    }
+  },
+  "Current": {
+    "Collections": {
+      "_system": {
+        "1001": {
+          "s1": {"servers": ["PRMR-1", "PRMR-2"], "failoverCandidates": ["PRMR-1", "PRMR-2"]},
+          "s2": {"servers": ["PRMR-2"]}
+        }
+      }
+    }
+  },
+  "Supervision": {
+    "Health": {
+      "PRMR-1": {"ShortName": "DBServer0001", "Status": "GOOD"},
+      "PRMR-2": {"ShortName": "DBServer0002", "Status": "GOOD"},
+      "PRMR-3": {"ShortName": "DBServer0003", "Status": "BAD"}
+    }
+  },
+  "Target": {
+    "ToDo": {
+      "2": {"type": "moveShard", "jobId": "2", "timeCreated": "2021-06-01T10:00:02Z", "shard": "s2"}
+    },
+    "Pending": {
+      "1": {"type": "cleanOutServer", "jobId": "1", "timeCreated": "2021-06-01T10:00:01Z", "server": "PRMR-3"}
+    },
+    "Finished": {
+      "0": {"type": "addFollower", "jobId": "0", "timeCreated": "2021-06-01T10:00:00Z"}
+    }
+  }
+}`
+
+func Test_GetAgencyState(t *testing.T) {
+	var data map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(testAgencyState), &data))
+
+	state, err := GetAgencyState(context.Background(), func(ctx context.Context, i interface{}, keyParts ...string) error {
+		require.Len(t, keyParts, 2)
+		require.Equal(t, ArangoKey, keyParts[0])
+		return json.Unmarshal(data[keyParts[1]], i)
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "orders", state.Plan.Collections["_system"]["1001"].Name)
+	require.True(t, state.Plan.Collections.IsDBServerInDatabases("PRMR-2"))
+	require.False(t, state.Plan.Collections.IsDBServerInDatabases("PRMR-3"))
+	require.Equal(t, []string{"PRMR-2"}, state.Current.Collections["_system"]["1001"]["s2"].Servers)
+	require.Equal(t, "BAD", state.Supervision.Health["PRMR-3"].Status)
+	require.False(t, state.Supervision.IsMaintenanceEnabled())
+	require.Equal(t, "cleanOutServer", state.Target.Pending["1"].Type)
+	require.Len(t, state.Target.Finished, 1)
+}
+
+func Test_ShardDistribution(t *testing.T) {
+	var state ArangoState
+	require.NoError(t, json.Unmarshal([]byte(testAgencyState), &state))
+
+	d := state.ShardDistribution()
+
+	require.Len(t, d.Servers, 3)
+	require.Equal(t, ServerShards{ShortName: "DBServer0001", Health: "GOOD", Leaders: 1, Followers: 1, OutOfSync: 1}, d.Servers["PRMR-1"])
+	require.Equal(t, ServerShards{ShortName: "DBServer0002", Health: "GOOD", Leaders: 1, Followers: 1}, d.Servers["PRMR-2"])
+	require.Equal(t, ServerShards{ShortName: "DBServer0003", Health: "BAD"}, d.Servers["PRMR-3"])
+	require.Equal(t, 2, d.Servers["PRMR-1"].Shards())
+
+	require.Len(t, d.OutOfSync, 1)
+	require.Equal(t, ShardInfo{
+		Database:   "_system",
+		Collection: "orders",
+		Shard:      "s2",
+		Planned:    []string{"PRMR-2", "PRMR-1"},
+		Current:    []string{"PRMR-2"},
+		OutOfSync:  []string{"PRMR-1"},
+	}, d.OutOfSync[0])
+
+	require.Len(t, d.PendingJobs, 2)
+	require.Equal(t, "1", d.PendingJobs[0].ID)
+	require.Equal(t, JobStatePending, d.PendingJobs[0].State)
+	require.Equal(t, "2", d.PendingJobs[1].ID)
+	require.Equal(t, JobStateToDo, d.PendingJobs[1].State)
+}
+
+func Test_ShardDistribution_MissingCurrent(t *testing.T) {
+	state := ArangoState{
+		Plan: ArangoPlan{
+			Collections: ArangoPlanDatabases{
+				"db": ArangoPlanCollections{
+					"1": ArangoPlanCollection{
+						Shards: ArangoPlanShard{
+							"s1": []string{"PRMR-1"},
+						},
+					},
+				},
+			},
+		},
+		Supervision: ArangoSupervision{
+			Maintenance: "2021-06-01T10:00:00Z",
+		},
+	}
+
+	d := state.ShardDistribution()
+
+	require.True(t, d.Maintenance)
+	require.Len(t, d.OutOfSync, 1)
+	require.Equal(t, "1", d.OutOfSync[0].Collection)
+	require.Equal(t, []string{"PRMR-1"}, d.OutOfSync[0].OutOfSync)
+	require.Equal(t, 1, d.Servers["PRMR-1"].OutOfSync)
+}
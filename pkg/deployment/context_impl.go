@@ -51,6 +51,7 @@ import (
 	"github.com/arangodb/go-driver/jwt"
 	"github.com/arangodb/kube-arangodb/pkg/deployment/pod"
 	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/tracing"
 
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 
@@ -60,6 +61,7 @@ import (
 	"github.com/arangodb/go-driver/agency"
 	"github.com/rs/zerolog/log"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
 	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
@@ -98,6 +100,10 @@ func (d *Deployment) GetMonitoringV1Cli() monitoringClient.MonitoringV1Interface
 	return d.deps.KubeMonitoringCli
 }
 
+func (d *Deployment) GetKubeDynamicCli() dynamic.Interface {
+	return d.deps.KubeDynamicCli
+}
+
 func (d *Deployment) GetArangoCli() versioned.Interface {
 	return d.deps.DatabaseCRCli
 }
@@ -265,7 +271,7 @@ func (d *Deployment) getConnConfig() (http.ConnectionConfig, error) {
 	}
 
 	connConfig := http.ConnectionConfig{
-		Transport:          transport,
+		Transport:          tracing.WrapTransport(transport),
 		DontFollowRedirect: true,
 	}
 
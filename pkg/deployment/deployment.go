@@ -53,6 +53,7 @@ import (
 	"github.com/rs/zerolog"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 
@@ -63,6 +64,7 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/deployment/resources"
 	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
 	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/eventstream"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	"github.com/arangodb/kube-arangodb/pkg/util/trigger"
 )
@@ -84,8 +86,10 @@ type Dependencies struct {
 	KubeCli           kubernetes.Interface
 	KubeExtCli        apiextensionsclient.Interface
 	KubeMonitoringCli monitoringClient.MonitoringV1Interface
+	KubeDynamicCli    dynamic.Interface
 	DatabaseCRCli     versioned.Interface
 	EventRecorder     record.EventRecorder
+	EventStream       eventstream.Stream
 }
 
 // deploymentEventType strongly typed type of event
@@ -121,9 +125,10 @@ type Deployment struct {
 	config Config
 	deps   Dependencies
 
-	eventCh chan *deploymentEvent
-	stopCh  chan struct{}
-	stopped int32
+	eventCh  chan *deploymentEvent
+	stopCh   chan struct{}
+	stopped  int32
+	released int32
 
 	inspectTrigger            trigger.Trigger
 	inspectCRDTrigger         trigger.Trigger
@@ -138,6 +143,8 @@ type Deployment struct {
 	chaosMonkey               *chaos.Monkey
 	syncClientCache           client.ClientCache
 	haveServiceMonitorCRD     bool
+	volumeSnapshots           volumeSnapshotCache
+	nodes                     nodeCache
 }
 
 func (d *Deployment) GetAgencyMaintenanceMode(ctx context.Context) (bool, error) {
@@ -208,6 +215,9 @@ func New(config Config, deps Dependencies, apiObject *api.ArangoDeployment) (*De
 	go d.listenForSecretEvents(d.stopCh)
 	go d.listenForServiceEvents(d.stopCh)
 	go d.listenForCRDEvents(d.stopCh)
+	if !d.GetScope().IsNamespaced() {
+		go d.listenForNodeEvents(d.stopCh)
+	}
 	if apiObject.Spec.GetMode() == api.DeploymentModeCluster {
 		ci := newClusterScalingIntegration(d)
 		d.clusterScalingIntegration = ci
@@ -241,6 +251,16 @@ func (d *Deployment) Delete() {
 	}
 }
 
+// Release stops the handling of the deployment which still exists, but is not in scope of the operator anymore.
+// In contrast to Delete, finalizers of the created resources are kept.
+func (d *Deployment) Release() {
+	d.deps.Log.Info().Msg("deployment is released by the operator")
+	if atomic.CompareAndSwapInt32(&d.stopped, 0, 1) {
+		atomic.StoreInt32(&d.released, 1)
+		close(d.stopCh)
+	}
+}
+
 // send given event into the deployment event queue.
 func (d *Deployment) send(ev *deploymentEvent) {
 	select {
@@ -289,6 +309,7 @@ func (d *Deployment) run() {
 	}
 
 	d.lookForServiceMonitorCRD()
+	d.lookForVolumeSnapshotCRD()
 
 	// Execute inspection for first time without delay of 10s
 	log.Debug().Msg("Initially inspect deployment...")
@@ -298,6 +319,15 @@ func (d *Deployment) run() {
 	for {
 		select {
 		case <-d.stopCh:
+			d.volumeSnapshots.stop()
+			d.reconciler.RemoveMetrics()
+
+			if atomic.LoadInt32(&d.released) == 1 {
+				// Deployment is handled by another operator now, its resources are kept untouched
+				log.Info().Msg("Deployment released")
+				return
+			}
+
 			cachedStatus, err := inspector.NewInspector(context.Background(), d.GetKubeCli(), d.GetMonitoringV1Cli(), d.GetArangoCli(), d.GetNamespace())
 			if err != nil {
 				log.Error().Err(err).Msg("Unable to get resources")
@@ -329,6 +359,7 @@ func (d *Deployment) run() {
 
 		case <-d.inspectCRDTrigger.Done():
 			d.lookForServiceMonitorCRD()
+			d.lookForVolumeSnapshotCRD()
 		case <-d.updateDeploymentTrigger.Done():
 			inspectionInterval = minInspectionInterval
 			if err := d.handleArangoDeploymentUpdatedEvent(context.TODO()); err != nil {
@@ -423,6 +454,7 @@ func (d *Deployment) handleArangoDeploymentUpdatedEvent(ctx context.Context) err
 // On error, the error is logged.
 func (d *Deployment) CreateEvent(evt *k8sutil.Event) {
 	d.deps.EventRecorder.Event(evt.InvolvedObject, evt.Type, evt.Reason, evt.Message)
+	d.publishKubernetesEvent(evt)
 }
 
 // Update the status of the API object from the internal status
@@ -455,7 +487,9 @@ func (d *Deployment) updateCRStatus(ctx context.Context, force ...bool) error {
 		})
 		if err == nil {
 			// Update internal object
+			old := d.apiObject.Status
 			d.apiObject = newAPIObject
+			d.publishStatusEvents(old, newAPIObject.Status)
 			return nil
 		}
 		if attempt < 10 && k8sutil.IsConflict(err) {
@@ -0,0 +1,200 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"reflect"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/eventstream"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+const (
+	planActionAdded   = "added"
+	planActionStarted = "started"
+	// planActionRemoved is used when action is no longer in plan, because it finished, failed or plan was reset
+	planActionRemoved = "removed"
+)
+
+// statusEventData is the data of the status event.
+type statusEventData struct {
+	Phase      api.DeploymentPhase  `json:"phase"`
+	Conditions []conditionEventData `json:"conditions,omitempty"`
+	Members    []memberEventData    `json:"members,omitempty"`
+}
+
+type conditionEventData struct {
+	Type    api.ConditionType    `json:"type"`
+	Status  core.ConditionStatus `json:"status"`
+	Reason  string               `json:"reason,omitempty"`
+	Message string               `json:"message,omitempty"`
+}
+
+type memberEventData struct {
+	ID    string          `json:"id"`
+	Group string          `json:"group"`
+	Phase api.MemberPhase `json:"phase"`
+	Ready bool            `json:"ready"`
+}
+
+// planEventData is the data of the plan event.
+type planEventData struct {
+	Plan     string         `json:"plan"`
+	State    string         `json:"state"`
+	ActionID string         `json:"action_id"`
+	Type     api.ActionType `json:"action_type"`
+	Group    string         `json:"group,omitempty"`
+	MemberID string         `json:"member_id,omitempty"`
+	Reason   string         `json:"reason,omitempty"`
+}
+
+// kubernetesEventData is the data of the Kubernetes event.
+type kubernetesEventData struct {
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	Object  string `json:"object,omitempty"`
+}
+
+// publishEvent publishes the event of the deployment, if event stream is configured.
+func (d *Deployment) publishEvent(t eventstream.Type, data interface{}) {
+	if d.deps.EventStream == nil {
+		return
+	}
+
+	d.deps.EventStream.Publish(eventstream.Event{
+		Type:       t,
+		Namespace:  d.apiObject.GetNamespace(),
+		Deployment: d.apiObject.GetName(),
+		Data:       data,
+	})
+}
+
+// publishKubernetesEvent publishes the Kubernetes event created for the deployment.
+func (d *Deployment) publishKubernetesEvent(evt *k8sutil.Event) {
+	data := kubernetesEventData{
+		Type:    evt.Type,
+		Reason:  evt.Reason,
+		Message: evt.Message,
+	}
+	if obj, err := meta.Accessor(evt.InvolvedObject); err == nil {
+		data.Object = obj.GetName()
+	}
+
+	d.publishEvent(eventstream.TypeEvent, data)
+}
+
+// publishStatusEvents publishes the changes between the given statuses of the deployment.
+func (d *Deployment) publishStatusEvents(previous, current api.DeploymentStatus) {
+	if d.deps.EventStream == nil {
+		return
+	}
+
+	if p, c := newStatusEventData(previous), newStatusEventData(current); !reflect.DeepEqual(p, c) {
+		d.publishEvent(eventstream.TypeStatus, c)
+	}
+
+	for _, e := range planChanges("high", previous.HighPriorityPlan, current.HighPriorityPlan) {
+		d.publishEvent(eventstream.TypePlan, e)
+	}
+	for _, e := range planChanges("normal", previous.Plan, current.Plan) {
+		d.publishEvent(eventstream.TypePlan, e)
+	}
+}
+
+// newStatusEventData creates the data of the status event from the deployment status.
+func newStatusEventData(status api.DeploymentStatus) statusEventData {
+	data := statusEventData{
+		Phase: status.Phase,
+	}
+
+	for _, c := range status.Conditions {
+		data.Conditions = append(data.Conditions, conditionEventData{
+			Type:    c.Type,
+			Status:  c.Status,
+			Reason:  c.Reason,
+			Message: c.Message,
+		})
+	}
+
+	status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+		for _, m := range list {
+			data.Members = append(data.Members, memberEventData{
+				ID:    m.ID,
+				Group: group.AsRole(),
+				Phase: m.Phase,
+				Ready: m.Conditions.IsTrue(api.ConditionTypeReady),
+			})
+		}
+		return nil
+	})
+
+	return data
+}
+
+// planChanges returns the events for actions which were added, started or removed between the given plans.
+func planChanges(name string, previous, current api.Plan) []planEventData {
+	var result []planEventData
+
+	event := func(state string, a api.Action) planEventData {
+		return planEventData{
+			Plan:     name,
+			State:    state,
+			ActionID: a.ID,
+			Type:     a.Type,
+			Group:    a.Group.AsRole(),
+			MemberID: a.MemberID,
+			Reason:   a.Reason,
+		}
+	}
+
+	previousActions := make(map[string]api.Action, len(previous))
+	for _, a := range previous {
+		previousActions[a.ID] = a
+	}
+
+	currentActions := make(map[string]struct{}, len(current))
+	for _, a := range current {
+		currentActions[a.ID] = struct{}{}
+
+		p, found := previousActions[a.ID]
+		if !found {
+			result = append(result, event(planActionAdded, a))
+			if a.StartTime != nil {
+				result = append(result, event(planActionStarted, a))
+			}
+		} else if p.StartTime == nil && a.StartTime != nil {
+			result = append(result, event(planActionStarted, a))
+		}
+	}
+
+	for _, a := range previous {
+		if _, found := currentActions[a.ID]; !found {
+			result = append(result, event(planActionRemoved, a))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,104 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"testing"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/eventstream"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_PlanChanges(t *testing.T) {
+	now := meta.Now()
+
+	previous := api.Plan{
+		{ID: "a", Type: api.ActionTypeRotateMember, Group: api.ServerGroupDBServers, MemberID: "PRMR-1"},
+		{ID: "b", Type: api.ActionTypeRotateMember, Group: api.ServerGroupDBServers, MemberID: "PRMR-2", StartTime: &now},
+	}
+	current := api.Plan{
+		{ID: "a", Type: api.ActionTypeRotateMember, Group: api.ServerGroupDBServers, MemberID: "PRMR-1", StartTime: &now},
+		{ID: "c", Type: api.ActionTypeRotateMember, Group: api.ServerGroupDBServers, MemberID: "PRMR-3"},
+	}
+
+	changes := planChanges("normal", previous, current)
+	require.Len(t, changes, 3)
+
+	require.Equal(t, "a", changes[0].ActionID)
+	require.Equal(t, planActionStarted, changes[0].State)
+	require.Equal(t, "dbserver", changes[0].Group)
+	require.Equal(t, "PRMR-1", changes[0].MemberID)
+
+	require.Equal(t, "c", changes[1].ActionID)
+	require.Equal(t, planActionAdded, changes[1].State)
+
+	require.Equal(t, "b", changes[2].ActionID)
+	require.Equal(t, planActionRemoved, changes[2].State)
+
+	require.Empty(t, planChanges("normal", current, current))
+}
+
+func Test_PublishStatusEvents(t *testing.T) {
+	stream := eventstream.NewStream(eventstream.DefaultHistorySize)
+	sub := stream.Subscribe(eventstream.Filter{}, 0)
+	defer sub.Close()
+
+	d := &Deployment{
+		apiObject: &api.ArangoDeployment{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      testDeploymentName,
+				Namespace: testNamespace,
+			},
+		},
+		deps: Dependencies{
+			EventStream: stream,
+		},
+	}
+
+	previous := api.DeploymentStatus{}
+	current := api.DeploymentStatus{
+		Phase: api.DeploymentPhaseRunning,
+		Plan: api.Plan{
+			{ID: "a", Type: api.ActionTypeAddMember},
+		},
+	}
+
+	d.publishStatusEvents(previous, current)
+
+	e := <-sub.Events()
+	require.Equal(t, eventstream.TypeStatus, e.Type)
+	require.Equal(t, testNamespace, e.Namespace)
+	require.Equal(t, testDeploymentName, e.Deployment)
+	require.Equal(t, api.DeploymentPhaseRunning, e.Data.(statusEventData).Phase)
+
+	e = <-sub.Events()
+	require.Equal(t, eventstream.TypePlan, e.Type)
+	require.Equal(t, planActionAdded, e.Data.(planEventData).State)
+
+	// Only plan changes are published when status is the same
+	d.publishStatusEvents(current, api.DeploymentStatus{Phase: api.DeploymentPhaseRunning})
+	e = <-sub.Events()
+	require.Equal(t, eventstream.TypePlan, e.Type)
+	require.Equal(t, planActionRemoved, e.Data.(planEventData).State)
+	require.Len(t, sub.Events(), 0)
+}
@@ -45,6 +45,7 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/metrics"
 	"github.com/arangodb/kube-arangodb/pkg/util"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/arangodb/kube-arangodb/pkg/util/tracing"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -53,7 +54,8 @@ const (
 )
 
 var (
-	inspectDeploymentDurationGauges = metrics.MustRegisterGaugeVec(metricsComponent, "inspect_deployment_duration", "Amount of time taken by a single inspection of a deployment (in sec)", metrics.DeploymentName)
+	inspectDeploymentDurationGauges     = metrics.MustRegisterGaugeVec(metricsComponent, "inspect_deployment_duration", "Amount of time taken by a single inspection of a deployment (in sec)", metrics.DeploymentName)
+	inspectDeploymentDurationHistograms = metrics.MustRegisterHistogramVec(metricsComponent, "reconciliation_loop_duration", "Distribution of time taken by inspections of a deployment (in sec)", nil, metrics.DeploymentName)
 )
 
 // getReconciliationTimeout gets timeout for the reconciliation loop.
@@ -94,6 +96,11 @@ func (d *Deployment) inspectDeployment(lastInterval util.Interval) util.Interval
 
 	ctxReconciliation, cancelReconciliation := context.WithTimeout(context.Background(), timeout)
 	defer cancelReconciliation()
+
+	ctxReconciliation, span := tracing.Start(ctxReconciliation, "Deployment reconciliation",
+		tracing.AttributeDeployment.String(d.GetName()),
+		tracing.AttributeNamespace.String(d.GetNamespace()))
+	defer span.End()
 	defer func() {
 		d.deps.Log.Info().Msgf("Inspect loop took %s", time.Since(start))
 	}()
@@ -103,8 +110,9 @@ func (d *Deployment) inspectDeployment(lastInterval util.Interval) util.Interval
 
 	deploymentName := d.GetName()
 	defer metrics.SetDuration(inspectDeploymentDurationGauges.WithLabelValues(deploymentName), start)
+	defer metrics.ObserveDuration(inspectDeploymentDurationHistograms.WithLabelValues(deploymentName), start)
 
-	cachedStatus, err := inspector.NewInspector(context.Background(), d.GetKubeCli(), d.GetMonitoringV1Cli(), d.GetArangoCli(), d.GetNamespace())
+	cachedStatus, err := inspector.NewInspector(tracing.Detach(ctxReconciliation), d.GetKubeCli(), d.GetMonitoringV1Cli(), d.GetArangoCli(), d.GetNamespace())
 	if err != nil {
 		log.Error().Err(err).Msg("Unable to get resources")
 		return minInspectionInterval // Retry ASAP
@@ -202,6 +210,22 @@ func (d *Deployment) inspectDeploymentWithError(ctx context.Context, lastInterva
 		}
 	}
 
+	// Ensure that volume snapshot restore mapping is created before any member
+	if spec.HasRestoreFromVolumeSnapshot() && status.VolumeSnapshotRestore == nil {
+		if created, err := d.createVolumeSnapshotRestoreMapping(ctx); err != nil {
+			return minInspectionInterval, errors.Wrapf(err, "Volume snapshot restore mapping failed")
+		} else if !created {
+			// Snapshot is not ready yet, inspection is triggered by the informer once it changes
+			return lastInterval, nil
+		}
+
+		if err := d.createAgencyMapping(ctx); err != nil {
+			return minInspectionInterval, errors.Wrapf(err, "Agency mapping failed")
+		}
+
+		return minInspectionInterval, nil // Retry ASAP
+	}
+
 	// Cleanup terminated pods on the beginning of loop
 	if x, err := d.resources.CleanupTerminatedPods(ctx, cachedStatus); err != nil {
 		return minInspectionInterval, errors.Wrapf(err, "Pod cleanup failed")
@@ -213,6 +237,12 @@ func (d *Deployment) inspectDeploymentWithError(ctx context.Context, lastInterva
 		return minInspectionInterval, errors.Wrapf(err, "ArangoMember creation failed")
 	}
 
+	if x, err := d.resources.EnsureArangoMembersDebug(ctx, cachedStatus); err != nil {
+		return minInspectionInterval, errors.Wrapf(err, "ArangoMember debug inspection failed")
+	} else {
+		nextInterval = nextInterval.ReduceTo(x)
+	}
+
 	if err := d.resources.EnsureServices(ctx, cachedStatus); err != nil {
 		return minInspectionInterval, errors.Wrapf(err, "Service creation failed")
 	}
@@ -256,6 +286,10 @@ func (d *Deployment) inspectDeploymentWithError(ctx context.Context, lastInterva
 		nextInterval = nextInterval.ReduceTo(x)
 	}
 
+	if err := d.resources.InspectVolumeSnapshots(ctx); err != nil {
+		d.deps.Log.Warn().Err(err).Msg("Volume snapshot inspection failed")
+	}
+
 	// Check members for resilience
 	if err := d.resilience.CheckMemberFailure(ctx); err != nil {
 		return minInspectionInterval, errors.Wrapf(err, "Member failure detection failed")
@@ -417,6 +451,10 @@ func (d *Deployment) ensureResources(ctx context.Context, lastInterval util.Inte
 		return minInspectionInterval, errors.Wrapf(err, "Pod creation failed")
 	}
 
+	if err := d.resources.InspectNodeDrain(ctx, cachedStatus); err != nil {
+		return minInspectionInterval, errors.Wrapf(err, "Node drain inspection failed")
+	}
+
 	if err := d.resources.EnsurePDBs(ctx); err != nil {
 		return minInspectionInterval, errors.Wrapf(err, "PDB creation failed")
 	}
@@ -0,0 +1,125 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"sync"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+// nodeCache keeps the nodes of the cluster.
+// It is filled by the informer which runs only when the operator is not in namespaced scope.
+type nodeCache struct {
+	lock sync.RWMutex
+
+	nodes map[string]*core.Node
+}
+
+func (n *nodeCache) set(node *core.Node) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.nodes == nil {
+		n.nodes = map[string]*core.Node{}
+	}
+	n.nodes[node.GetName()] = node
+}
+
+func (n *nodeCache) remove(name string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	delete(n.nodes, name)
+}
+
+func (n *nodeCache) get(name string) (*core.Node, bool) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	node, ok := n.nodes[name]
+	if !ok {
+		return nil, false
+	}
+
+	return node.DeepCopy(), true
+}
+
+// listenForNodeEvents keep listening for changes in nodes until the given channel is closed.
+// Inspection is triggered when a node is cordoned or uncordoned.
+func (d *Deployment) listenForNodeEvents(stopCh <-chan struct{}) {
+	getNode := func(obj interface{}) (*core.Node, bool) {
+		node, ok := obj.(*core.Node)
+		if !ok {
+			tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+			if !ok {
+				return nil, false
+			}
+			node, ok = tombstone.Obj.(*core.Node)
+			return node, ok
+		}
+		return node, true
+	}
+
+	rw := k8sutil.NewResourceWatcher(
+		d.deps.Log,
+		d.deps.KubeCli.CoreV1().RESTClient(),
+		"nodes",
+		"",
+		&core.Node{},
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if n, ok := getNode(obj); ok {
+					d.nodes.set(n)
+					if n.Spec.Unschedulable {
+						d.triggerInspection()
+					}
+				}
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if n, ok := getNode(newObj); ok {
+					d.nodes.set(n)
+					if o, ok := getNode(oldObj); ok && o.Spec.Unschedulable != n.Spec.Unschedulable {
+						d.triggerInspection()
+					}
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				if n, ok := getNode(obj); ok {
+					d.nodes.remove(n.GetName())
+					if n.Spec.Unschedulable {
+						d.triggerInspection()
+					}
+				}
+			},
+		})
+
+	rw.Run(stopCh)
+}
+
+// GetNode returns the cached node with given name.
+// Nodes are not available when the operator runs in namespaced scope.
+func (d *Deployment) GetNode(name string) (*core.Node, bool) {
+	return d.nodes.get(name)
+}
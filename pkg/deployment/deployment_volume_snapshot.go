@@ -0,0 +1,259 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"context"
+	"sync"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/backup"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// volumeSnapshotCache keeps the ArangoVolumeSnapshot resources which refer to the deployment.
+// It is filled by the informer which runs only when the ArangoVolumeSnapshot CRD is installed.
+type volumeSnapshotCache struct {
+	lock sync.RWMutex
+
+	stopCh    chan struct{}
+	snapshots map[string]*backupApi.ArangoVolumeSnapshot
+}
+
+// enabled returns true when the informer is running.
+func (v *volumeSnapshotCache) enabled() bool {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	return v.stopCh != nil
+}
+
+// start returns stop channel for the new informer or false when informer is already running.
+func (v *volumeSnapshotCache) start() (<-chan struct{}, bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.stopCh != nil {
+		return nil, false
+	}
+
+	v.stopCh = make(chan struct{})
+	v.snapshots = map[string]*backupApi.ArangoVolumeSnapshot{}
+
+	return v.stopCh, true
+}
+
+// stop stops the informer and drops the cached resources.
+func (v *volumeSnapshotCache) stop() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.stopCh == nil {
+		return
+	}
+
+	close(v.stopCh)
+	v.stopCh = nil
+	v.snapshots = nil
+}
+
+func (v *volumeSnapshotCache) set(snapshot *backupApi.ArangoVolumeSnapshot) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.snapshots != nil {
+		v.snapshots[snapshot.GetName()] = snapshot
+	}
+}
+
+func (v *volumeSnapshotCache) remove(name string) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	delete(v.snapshots, name)
+}
+
+func (v *volumeSnapshotCache) get(name string) (*backupApi.ArangoVolumeSnapshot, bool) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	s, ok := v.snapshots[name]
+	if !ok {
+		return nil, false
+	}
+
+	return s.DeepCopy(), true
+}
+
+func (v *volumeSnapshotCache) list() []backupApi.ArangoVolumeSnapshot {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	var snapshots []backupApi.ArangoVolumeSnapshot
+	for _, s := range v.snapshots {
+		snapshots = append(snapshots, *s.DeepCopy())
+	}
+
+	return snapshots
+}
+
+// lookForVolumeSnapshotCRD checks if there is a CRD for the ArangoVolumeSnapshot
+// and starts or stops the informer accordingly. This is called
+// once at creation time of the deployment and then always if the CRD
+// informer is triggered.
+func (d *Deployment) lookForVolumeSnapshotCRD() {
+	var err error
+	if d.GetScope().IsNamespaced() {
+		_, err = d.deps.DatabaseCRCli.BackupV1().ArangoVolumeSnapshots(d.GetNamespace()).List(context.Background(), meta.ListOptions{Limit: 1})
+	} else {
+		_, err = d.deps.KubeExtCli.ApiextensionsV1().CustomResourceDefinitions().Get(context.Background(), backup.ArangoVolumeSnapshotCRDName, meta.GetOptions{})
+	}
+	log := d.deps.Log
+	if err == nil {
+		if stopCh, ok := d.volumeSnapshots.start(); ok {
+			log.Info().Msgf("Discovered ArangoVolumeSnapshot CRD")
+			go d.listenForVolumeSnapshotEvents(stopCh)
+		}
+		return
+	} else if k8sutil.IsNotFound(err) {
+		if d.volumeSnapshots.enabled() {
+			log.Info().Msgf("ArangoVolumeSnapshot CRD no longer there")
+			d.volumeSnapshots.stop()
+		}
+		return
+	}
+	log.Warn().Err(err).Msgf("Error when looking for ArangoVolumeSnapshot CRD")
+}
+
+// listenForVolumeSnapshotEvents keep listening for changes in ArangoVolumeSnapshots until the given channel is closed.
+func (d *Deployment) listenForVolumeSnapshotEvents(stopCh <-chan struct{}) {
+	getSnapshot := func(obj interface{}) (*backupApi.ArangoVolumeSnapshot, bool) {
+		snapshot, ok := obj.(*backupApi.ArangoVolumeSnapshot)
+		if !ok {
+			tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+			if !ok {
+				return nil, false
+			}
+			snapshot, ok = tombstone.Obj.(*backupApi.ArangoVolumeSnapshot)
+			return snapshot, ok
+		}
+		return snapshot, true
+	}
+
+	rw := k8sutil.NewResourceWatcher(
+		d.deps.Log,
+		d.deps.DatabaseCRCli.BackupV1().RESTClient(),
+		backup.ArangoVolumeSnapshotResourcePlural,
+		d.GetNamespace(),
+		&backupApi.ArangoVolumeSnapshot{},
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if s, ok := getSnapshot(obj); ok && s.Spec.Deployment.Name == d.name {
+					d.volumeSnapshots.set(s)
+					d.triggerInspection()
+				}
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if s, ok := getSnapshot(newObj); ok && s.Spec.Deployment.Name == d.name {
+					d.volumeSnapshots.set(s)
+					d.triggerInspection()
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				if s, ok := getSnapshot(obj); ok && s.Spec.Deployment.Name == d.name {
+					d.volumeSnapshots.remove(s.GetName())
+					d.triggerInspection()
+				}
+			},
+		})
+
+	rw.Run(stopCh)
+}
+
+// GetVolumeSnapshots returns the cached ArangoVolumeSnapshot resources which refer to the deployment.
+// List is empty when the ArangoVolumeSnapshot CRD is not installed.
+func (d *Deployment) GetVolumeSnapshots() []backupApi.ArangoVolumeSnapshot {
+	return d.volumeSnapshots.list()
+}
+
+// createVolumeSnapshotRestoreMapping assigns VolumeSnapshots from the ArangoVolumeSnapshot
+// defined in spec.restoreFromVolumeSnapshot to the members of the deployment.
+// Returns false while the ArangoVolumeSnapshot is not yet available or not ready.
+func (d *Deployment) createVolumeSnapshotRestoreMapping(ctx context.Context) (bool, error) {
+	spec := d.GetSpec()
+	name := spec.GetRestoreFromVolumeSnapshot()
+
+	snapshot, ok := d.volumeSnapshots.get(name)
+	if !ok {
+		d.deps.Log.Debug().Str("volume-snapshot", name).Msg("Waiting for ArangoVolumeSnapshot")
+		return false, nil
+	}
+
+	if snapshot.Status.State == backupApi.ArangoVolumeSnapshotStateFailed {
+		return false, errors.Newf("ArangoVolumeSnapshot %s failed", name)
+	}
+
+	if snapshot.Status.State != backupApi.ArangoVolumeSnapshotStateReady {
+		d.deps.Log.Debug().Str("volume-snapshot", name).Msg("Waiting for ArangoVolumeSnapshot to be ready")
+		return false, nil
+	}
+
+	if snapshot.Status.Mode != string(spec.GetMode()) {
+		return false, errors.Newf("ArangoVolumeSnapshot %s was taken from %s deployment", name, snapshot.Status.Mode)
+	}
+
+	restore := api.DeploymentStatusVolumeSnapshotRestore{
+		Name: name,
+	}
+
+	for _, v := range snapshot.Status.Volumes {
+		restore.Volumes = append(restore.Volumes, api.DeploymentStatusVolumeSnapshotRestoreVolume{
+			Group:              api.ServerGroupFromRole(v.Group),
+			ID:                 v.ID,
+			VolumeSnapshotName: v.VolumeSnapshotName,
+		})
+	}
+
+	if spec.Mode.HasAgents() {
+		if c := len(restore.GetIDs(api.ServerGroupAgents)); spec.Agents.GetCount() != c {
+			return false, errors.Newf("ArangoVolumeSnapshot %s requires %d agents", name, c)
+		}
+	}
+
+	if spec.Mode.HasDBServers() {
+		if c := len(restore.GetIDs(api.ServerGroupDBServers)); spec.DBServers.GetCount() < c {
+			return false, errors.Newf("ArangoVolumeSnapshot %s requires at least %d dbservers", name, c)
+		}
+	}
+
+	if err := d.WithStatusUpdate(ctx, func(s *api.DeploymentStatus) bool {
+		s.VolumeSnapshotRestore = &restore
+		return true
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -52,6 +52,11 @@ func (d *Deployment) createAgencyMapping(ctx context.Context) error {
 		return nil
 	}
 
+	if spec.HasRestoreFromVolumeSnapshot() && status.VolumeSnapshotRestore == nil {
+		// Agent IDs need to be taken from the snapshot
+		return nil
+	}
+
 	var i api.DeploymentStatusAgencyInfo
 
 	if spec.Agents.Count == nil {
@@ -72,6 +77,12 @@ func (d *Deployment) createAgencyMapping(ctx context.Context) error {
 		i.IDs = append(i.IDs, agents[id].ID)
 	}
 
+	for _, id := range status.VolumeSnapshotRestore.GetIDs(api.ServerGroupAgents) {
+		if !agents.ContainsID(id) {
+			i.IDs = append(i.IDs, id)
+		}
+	}
+
 	for len(i.IDs) < *spec.Agents.Count {
 		i.IDs = append(i.IDs, names.GetArangodID(api.ServerGroupAgents))
 	}
@@ -119,6 +130,15 @@ func renderMember(log zerolog.Logger, status *api.DeploymentStatus, group api.Se
 			}
 		}
 	} else {
+		if id == "" {
+			// In case of restore from volume snapshot we need to use ids of snapshotted members
+			for _, nid := range status.VolumeSnapshotRestore.GetIDs(group) {
+				if !status.Members.ContainsID(nid) {
+					id = nid
+					break
+				}
+			}
+		}
 		if id == "" {
 			for {
 				id = names.GetArangodID(group)
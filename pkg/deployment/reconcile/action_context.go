@@ -28,6 +28,7 @@ import (
 
 	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
 	monitoringClient "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/arangodb/kube-arangodb/pkg/deployment/resources"
@@ -176,6 +177,10 @@ func (ac *actionContext) GetMonitoringV1Cli() monitoringClient.MonitoringV1Inter
 	return ac.context.GetMonitoringV1Cli()
 }
 
+func (ac *actionContext) GetKubeDynamicCli() dynamic.Interface {
+	return ac.context.GetKubeDynamicCli()
+}
+
 func (ac *actionContext) GetArangoCli() versioned.Interface {
 	return ac.context.GetArangoCli()
 }
@@ -0,0 +1,197 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/rs/zerolog"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	registerAction(api.ActionTypeVolumeSnapshotCreate, newVolumeSnapshotCreateAction)
+}
+
+func newVolumeSnapshotCreateAction(log zerolog.Logger, action api.Action, actionCtx ActionContext) Action {
+	a := &actionVolumeSnapshotCreate{}
+
+	a.actionImpl = newActionImplDefRef(log, action, actionCtx, volumeSnapshotCreateTimeout)
+
+	return a
+}
+
+// actionVolumeSnapshotCreate implements an VolumeSnapshotCreate.
+// It takes VolumeSnapshots of all agent and dbserver PVCs and waits until all of them are cut.
+type actionVolumeSnapshotCreate struct {
+	// actionImpl implement timeout and member id functions
+	actionImpl
+}
+
+// volumeSnapshotGroups contains groups for which PVCs are snapshotted
+var volumeSnapshotGroups = []api.ServerGroup{
+	api.ServerGroupSingle,
+	api.ServerGroupAgents,
+	api.ServerGroupDBServers,
+}
+
+func (a *actionVolumeSnapshotCreate) Start(ctx context.Context) (bool, error) {
+	snapshot, ok, err := a.getVolumeSnapshot(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if !ok || snapshot.Status.State != backupApi.ArangoVolumeSnapshotStateNone {
+		return true, nil
+	}
+
+	if err := snapshot.Validate(); err != nil {
+		return true, a.updateVolumeSnapshotStatus(ctx, snapshot, backupApi.ArangoVolumeSnapshotStateFailed, err.Error())
+	}
+
+	status := a.actionCtx.GetStatus()
+	ns := a.actionCtx.GetAPIObject().GetNamespace()
+
+	var volumes backupApi.ArangoVolumeSnapshotVolumes
+
+	if err := status.Members.ForeachServerInGroups(func(group api.ServerGroup, list api.MemberStatusList) error {
+		for _, m := range list {
+			if m.PersistentVolumeClaimName == "" {
+				return errors.Newf("Member %s does not have a PersistentVolumeClaim", m.ID)
+			}
+
+			volumes = append(volumes, backupApi.ArangoVolumeSnapshotVolume{
+				Group:                     group.AsRole(),
+				ID:                        m.ID,
+				PersistentVolumeClaimName: m.PersistentVolumeClaimName,
+				VolumeSnapshotName:        k8sutil.CreateVolumeSnapshotName(snapshot.GetName(), group.AsRole(), m.ID),
+			})
+		}
+		return nil
+	}, volumeSnapshotGroups...); err != nil {
+		return true, a.updateVolumeSnapshotStatus(ctx, snapshot, backupApi.ArangoVolumeSnapshotStateFailed, err.Error())
+	}
+
+	owner := snapshot.AsOwner()
+
+	for _, v := range volumes {
+		err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+			return k8sutil.CreateVolumeSnapshot(ctxChild, a.actionCtx.GetKubeDynamicCli(), v.VolumeSnapshotName, ns,
+				v.PersistentVolumeClaimName, snapshot.Spec.VolumeSnapshotClassName, k8sutil.LabelsForDeployment(a.actionCtx.GetName(), v.Group), owner)
+		})
+		if err != nil {
+			a.log.Error().Err(err).Str("pvc", v.PersistentVolumeClaimName).Msg("Unable to create VolumeSnapshot")
+			return true, a.updateVolumeSnapshotStatus(ctx, snapshot, backupApi.ArangoVolumeSnapshotStateFailed,
+				fmt.Sprintf("Unable to create VolumeSnapshot of %s: %s", v.PersistentVolumeClaimName, err.Error()))
+		}
+	}
+
+	snapshot.Status.Mode = string(a.actionCtx.GetMode())
+	snapshot.Status.Volumes = volumes
+
+	if err := a.updateVolumeSnapshotStatus(ctx, snapshot, backupApi.ArangoVolumeSnapshotStateCreating, ""); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+// CheckProgress returns true when all VolumeSnapshots are cut on the storage backend.
+// ReadyToUse flag is tracked outside of the plan, so maintenance mode can be disabled as soon as possible.
+func (a *actionVolumeSnapshotCreate) CheckProgress(ctx context.Context) (bool, bool, error) {
+	snapshot, ok, err := a.getVolumeSnapshot(ctx)
+	if err != nil {
+		return false, false, err
+	}
+
+	if !ok || snapshot.Status.State != backupApi.ArangoVolumeSnapshotStateCreating {
+		return true, false, nil
+	}
+
+	ns := a.actionCtx.GetAPIObject().GetNamespace()
+
+	for _, v := range snapshot.Status.Volumes {
+		var vs k8sutil.VolumeSnapshotStatus
+		err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+			var err error
+			vs, err = k8sutil.GetVolumeSnapshotStatus(ctxChild, a.actionCtx.GetKubeDynamicCli(), v.VolumeSnapshotName, ns)
+			return err
+		})
+		if err != nil {
+			a.log.Warn().Err(err).Str("volume-snapshot", v.VolumeSnapshotName).Msg("Unable to get VolumeSnapshot")
+			return false, false, nil
+		}
+
+		if vs.Error != "" {
+			return true, false, a.updateVolumeSnapshotStatus(ctx, snapshot, backupApi.ArangoVolumeSnapshotStateFailed,
+				fmt.Sprintf("VolumeSnapshot %s failed: %s", v.VolumeSnapshotName, vs.Error))
+		}
+
+		if !vs.Created {
+			return false, false, nil
+		}
+	}
+
+	return true, false, nil
+}
+
+func (a *actionVolumeSnapshotCreate) getVolumeSnapshot(ctx context.Context) (*backupApi.ArangoVolumeSnapshot, bool, error) {
+	name, ok := a.action.Params[volumeSnapshotActionParam]
+	if !ok {
+		a.log.Error().Msg("VolumeSnapshot name is missing in action")
+		return nil, false, nil
+	}
+
+	var snapshot *backupApi.ArangoVolumeSnapshot
+	err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+		var err error
+		snapshot, err = a.actionCtx.GetArangoCli().BackupV1().ArangoVolumeSnapshots(a.actionCtx.GetAPIObject().GetNamespace()).
+			Get(ctxChild, name, meta.GetOptions{})
+		return err
+	})
+	if err != nil {
+		if k8sutil.IsNotFound(err) {
+			a.log.Warn().Str("volume-snapshot", name).Msg("ArangoVolumeSnapshot is gone")
+			return nil, false, nil
+		}
+		return nil, false, errors.WithStack(err)
+	}
+
+	return snapshot, true, nil
+}
+
+func (a *actionVolumeSnapshotCreate) updateVolumeSnapshotStatus(ctx context.Context, snapshot *backupApi.ArangoVolumeSnapshot,
+	state backupApi.ArangoVolumeSnapshotState, message string) error {
+	snapshot.Status.State = state
+	snapshot.Status.Message = message
+	snapshot.Status.Time = meta.Now()
+
+	return k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+		_, err := a.actionCtx.GetArangoCli().BackupV1().ArangoVolumeSnapshots(snapshot.GetNamespace()).
+			UpdateStatus(ctxChild, snapshot, meta.UpdateOptions{})
+		return err
+	})
+}
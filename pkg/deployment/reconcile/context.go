@@ -121,6 +121,8 @@ type Context interface {
 	SecretsInterface() k8sutil.SecretInterface
 	// GetBackup receives information about a backup resource
 	GetBackup(ctx context.Context, backup string) (*backupApi.ArangoBackup, error)
+	// GetVolumeSnapshots returns the cached ArangoVolumeSnapshot resources which refer to the deployment
+	GetVolumeSnapshots() []backupApi.ArangoVolumeSnapshot
 	// GetName receives deployment name
 	GetName() string
 	// GetAuthentication return authentication for members
@@ -0,0 +1,154 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/metrics"
+)
+
+const (
+	metricsComponent = "deployment_reconcile"
+
+	// planLabel is a label key used for the type of the plan (normal|high)
+	planLabel = "plan"
+)
+
+var (
+	planLengthGauges         = metrics.MustRegisterGaugeVec(metricsComponent, "plan_length", "Number of actions in the plan of a deployment", metrics.Namespace, metrics.DeploymentName, planLabel)
+	executedActionsCounters  = metrics.MustRegisterCounterVec(metricsComponent, "executed_actions", "Number of finished plan actions by type and result", metrics.Namespace, metrics.DeploymentName, metrics.ActionType, metrics.Result)
+	actionDurationHistograms = metrics.MustRegisterHistogramVec(metricsComponent, "action_duration", "Amount of time from creation of the plan action until it has finished (in sec)", nil, metrics.Namespace, metrics.DeploymentName, metrics.ActionType, metrics.Result)
+	actionTimeoutsCounters   = metrics.MustRegisterCounterVec(metricsComponent, "action_timeouts", "Number of plan actions which did not finish in time", metrics.Namespace, metrics.DeploymentName, metrics.ActionType)
+	memberRotationsCounters  = metrics.MustRegisterCounterVec(metricsComponent, "member_rotations", "Number of started member rotations by reason", metrics.Namespace, metrics.DeploymentName, metrics.Group, metrics.Reason)
+	memberUpgradesCounters   = metrics.MustRegisterCounterVec(metricsComponent, "member_upgrades", "Number of started member upgrades", metrics.Namespace, metrics.DeploymentName, metrics.Group)
+)
+
+// metricVec is a metric vector which series can be removed
+type metricVec interface {
+	DeleteLabelValues(lvs ...string) bool
+}
+
+// metricSeries identifies a series of the metric vector
+type metricSeries struct {
+	vec    metricVec
+	labels []string
+}
+
+// deploymentMetrics keeps track of the metric series of a single deployment,
+// so they are removed together with the deployment.
+type deploymentMetrics struct {
+	namespace, name string
+
+	lock   sync.Mutex
+	series map[string]metricSeries
+}
+
+func newDeploymentMetrics(namespace, name string) *deploymentMetrics {
+	return &deploymentMetrics{
+		namespace: namespace,
+		name:      name,
+		series:    map[string]metricSeries{},
+	}
+}
+
+// labels returns the label values of the series of the deployment with given metric specific values
+// and records the series, so it is removed with the deployment.
+func (m *deploymentMetrics) labels(metric string, vec metricVec, values ...string) []string {
+	labels := append([]string{m.namespace, m.name}, values...)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	key := metric + "/" + strings.Join(labels, "/")
+	if _, ok := m.series[key]; !ok {
+		m.series[key] = metricSeries{vec: vec, labels: labels}
+	}
+	return labels
+}
+
+// remove all the metric series of the deployment
+func (m *deploymentMetrics) remove() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, s := range m.series {
+		s.vec.DeleteLabelValues(s.labels...)
+	}
+	m.series = map[string]metricSeries{}
+}
+
+// observeActionResult records the result of the finished plan action
+func (m *deploymentMetrics) observeActionResult(planAction api.Action, result string) {
+	actionType := planAction.Type.String()
+
+	executedActionsCounters.WithLabelValues(m.labels("executed_actions", executedActionsCounters, actionType, result)...).Inc()
+	actionDurationHistograms.WithLabelValues(m.labels("action_duration", actionDurationHistograms, actionType, result)...).
+		Observe(time.Since(planAction.CreationTime.Time).Seconds())
+
+	if result == metrics.Timeout {
+		actionTimeoutsCounters.WithLabelValues(m.labels("action_timeouts", actionTimeoutsCounters, actionType)...).Inc()
+	}
+}
+
+// observeActionStart records the start of the plan action
+func (m *deploymentMetrics) observeActionStart(planAction api.Action) {
+	switch planAction.Type {
+	case api.ActionTypeRotateMember, api.ActionTypeRotateStartMember:
+		reason := planAction.Reason
+		if reason == "" {
+			reason = "Unknown"
+		}
+
+		memberRotationsCounters.WithLabelValues(m.labels("member_rotations", memberRotationsCounters, planAction.Group.AsRole(), reason)...).Inc()
+	case api.ActionTypeUpgradeMember:
+		memberUpgradesCounters.WithLabelValues(m.labels("member_upgrades", memberUpgradesCounters, planAction.Group.AsRole())...).Inc()
+	}
+}
+
+// observePlanLength records the length of the plan
+func (m *deploymentMetrics) observePlanLength(planName string, plan api.Plan) {
+	planLengthGauges.WithLabelValues(m.labels("plan_length", planLengthGauges, planName)...).Set(float64(len(plan)))
+}
+
+// observeActionResult records the result of the finished plan action
+func (d *Reconciler) observeActionResult(planAction api.Action, result string) {
+	d.metrics.observeActionResult(planAction, result)
+}
+
+// observeActionStart records the start of the plan action
+func (d *Reconciler) observeActionStart(planAction api.Action) {
+	d.metrics.observeActionStart(planAction)
+}
+
+// observePlanLength records the length of the plan
+func (d *Reconciler) observePlanLength(pg planner, plan api.Plan) {
+	d.metrics.observePlanLength(pg.Name(), plan)
+}
+
+// RemoveMetrics removes the metric series of the deployment.
+// Called when the deployment is not handled by the operator anymore.
+func (d *Reconciler) RemoveMetrics() {
+	d.metrics.remove()
+}
@@ -0,0 +1,113 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/metrics"
+)
+
+const testMetricsNamespace = "test-ns"
+
+func Test_Metrics_MemberRotations(t *testing.T) {
+	name := "test-rotations"
+	m := newDeploymentMetrics(testMetricsNamespace, name)
+
+	m.observeActionStart(api.NewAction(api.ActionTypeRotateMember, api.ServerGroupDBServers, "id", "Pod changed"))
+	m.observeActionStart(api.NewAction(api.ActionTypeRotateStartMember, api.ServerGroupDBServers, "id", "Pod changed"))
+	m.observeActionStart(api.NewAction(api.ActionTypeRotateMember, api.ServerGroupAgents, "id"))
+
+	require.Equal(t, float64(2), testutil.ToFloat64(memberRotationsCounters.WithLabelValues(testMetricsNamespace, name, api.ServerGroupDBServers.AsRole(), "Pod changed")))
+	require.Equal(t, float64(1), testutil.ToFloat64(memberRotationsCounters.WithLabelValues(testMetricsNamespace, name, api.ServerGroupAgents.AsRole(), "Unknown")))
+	require.Equal(t, float64(0), testutil.ToFloat64(memberUpgradesCounters.WithLabelValues(testMetricsNamespace, name, api.ServerGroupDBServers.AsRole())))
+}
+
+func Test_Metrics_MemberUpgrades(t *testing.T) {
+	name := "test-upgrades"
+	m := newDeploymentMetrics(testMetricsNamespace, name)
+
+	m.observeActionStart(api.NewAction(api.ActionTypeUpgradeMember, api.ServerGroupDBServers, "id", "Upgrade"))
+
+	require.Equal(t, float64(1), testutil.ToFloat64(memberUpgradesCounters.WithLabelValues(testMetricsNamespace, name, api.ServerGroupDBServers.AsRole())))
+	require.Equal(t, float64(0), testutil.ToFloat64(memberRotationsCounters.WithLabelValues(testMetricsNamespace, name, api.ServerGroupDBServers.AsRole(), "Upgrade")))
+}
+
+func Test_Metrics_ActionResult(t *testing.T) {
+	name := "test-results"
+	m := newDeploymentMetrics(testMetricsNamespace, name)
+	action := api.NewAction(api.ActionTypeAddMember, api.ServerGroupDBServers, "id")
+
+	m.observeActionResult(action, metrics.Success)
+	m.observeActionResult(action, metrics.Timeout)
+
+	actionType := api.ActionTypeAddMember.String()
+
+	require.Equal(t, float64(1), testutil.ToFloat64(executedActionsCounters.WithLabelValues(testMetricsNamespace, name, actionType, metrics.Success)))
+	require.Equal(t, float64(1), testutil.ToFloat64(executedActionsCounters.WithLabelValues(testMetricsNamespace, name, actionType, metrics.Timeout)))
+	require.Equal(t, float64(1), testutil.ToFloat64(actionTimeoutsCounters.WithLabelValues(testMetricsNamespace, name, actionType)))
+}
+
+func Test_Metrics_Remove(t *testing.T) {
+	name := "test-remove"
+	m := newDeploymentMetrics(testMetricsNamespace, name)
+	other := newDeploymentMetrics("other-ns", name)
+
+	m.observeActionStart(api.NewAction(api.ActionTypeUpgradeMember, api.ServerGroupDBServers, "id"))
+	m.observeActionResult(api.NewAction(api.ActionTypeAddMember, api.ServerGroupDBServers, "id"), metrics.Timeout)
+	m.observePlanLength("normal", api.Plan{api.NewAction(api.ActionTypeAddMember, api.ServerGroupDBServers, "id")})
+	other.observePlanLength("normal", api.Plan{})
+
+	require.Equal(t, 6, countSeries(name))
+
+	m.remove()
+
+	require.Equal(t, 1, countSeries(name))
+}
+
+// countSeries returns the number of series of the reconcile metrics with given deployment name
+func countSeries(name string) int {
+	var count int
+	for _, vec := range []prometheus.Collector{planLengthGauges, executedActionsCounters, actionDurationHistograms,
+		actionTimeoutsCounters, memberRotationsCounters, memberUpgradesCounters} {
+		ch := make(chan prometheus.Metric, 100)
+		vec.Collect(ch)
+		close(ch)
+		for metric := range ch {
+			var m dto.Metric
+			if err := metric.Write(&m); err != nil {
+				continue
+			}
+			for _, l := range m.GetLabel() {
+				if l.GetName() == metrics.DeploymentName && l.GetValue() == name {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
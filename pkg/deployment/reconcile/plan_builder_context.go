@@ -76,6 +76,8 @@ type PlanBuilderContext interface {
 	SecretsInterface() k8sutil.SecretInterface
 	// GetBackup receives information about a backup resource
 	GetBackup(ctx context.Context, backup string) (*backupApi.ArangoBackup, error)
+	// GetVolumeSnapshots returns the cached ArangoVolumeSnapshot resources which refer to the deployment
+	GetVolumeSnapshots() []backupApi.ArangoVolumeSnapshot
 	// GetName receives deployment name
 	GetName() string
 	// GetAgency returns a connection to the entire agency.
@@ -28,7 +28,9 @@ import (
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	inspectorInterface "github.com/arangodb/kube-arangodb/pkg/util/k8sutil/inspector"
+	"github.com/arangodb/kube-arangodb/pkg/util/tracing"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type planBuilder func(ctx context.Context,
@@ -90,5 +92,14 @@ func (w withPlanBuilder) ApplySubPlan(p planBuilderSubPlan, plans ...planBuilder
 }
 
 func (w withPlanBuilder) Apply(p planBuilder) api.Plan {
-	return p(w.ctx, w.log, w.apiObject, w.spec, w.status, w.cachedStatus, w.context)
+	ctx, span := tracing.Start(w.ctx, "Plan builder "+tracing.FuncName(p),
+		tracing.AttributeDeployment.String(w.apiObject.GetName()),
+		tracing.AttributeNamespace.String(w.apiObject.GetNamespace()))
+	defer span.End()
+
+	plan := p(ctx, w.log, w.apiObject, w.spec, w.status, w.cachedStatus, w.context)
+
+	span.SetAttributes(attribute.Int("arangodb.plan.length", len(plan)))
+
+	return plan
 }
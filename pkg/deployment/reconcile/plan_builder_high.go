@@ -31,6 +31,7 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	inspectorInterface "github.com/arangodb/kube-arangodb/pkg/util/k8sutil/inspector"
+	"github.com/arangodb/kube-arangodb/pkg/util/tracing"
 	"github.com/rs/zerolog"
 	core "k8s.io/api/core/v1"
 )
@@ -38,6 +39,12 @@ import (
 func (d *Reconciler) CreateHighPlan(ctx context.Context, cachedStatus inspectorInterface.Inspector) (error, bool) {
 	// Create plan
 	apiObject := d.context.GetAPIObject()
+
+	ctx, span := tracing.Start(ctx, "Create high plan",
+		tracing.AttributeDeployment.String(apiObject.GetName()),
+		tracing.AttributeNamespace.String(apiObject.GetNamespace()))
+	defer span.End()
+
 	spec := d.context.GetSpec()
 	status, lastVersion := d.context.GetStatus()
 	builderCtx := newPlanBuilderContext(d.context)
@@ -0,0 +1,74 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	inspectorInterface "github.com/arangodb/kube-arangodb/pkg/util/k8sutil/inspector"
+)
+
+// createNodeDrainPlan prepares DBServers on cordoned nodes for the eviction of their pods.
+// Depending on the node drain mode member resigns leadership of its shards or is replaced with a new member.
+func createNodeDrainPlan(ctx context.Context, log zerolog.Logger, _ k8sutil.APIObject, spec api.DeploymentSpec,
+	status api.DeploymentStatus, _ inspectorInterface.Inspector, _ PlanBuilderContext) api.Plan {
+	mode := spec.NodeDrainMode.Get()
+	if !mode.IsEnabled() || spec.GetMode() != api.DeploymentModeCluster {
+		return nil
+	}
+
+	for _, m := range status.Members.DBServers {
+		if m.Phase != api.MemberPhaseCreated || !m.Conditions.IsTrue(api.ConditionTypeNodeDrain) {
+			continue
+		}
+
+		if m.Conditions.IsTrue(api.ConditionTypeNodeDrainPrepared) || m.Conditions.IsTrue(api.ConditionTypeTerminating) {
+			continue
+		}
+
+		switch mode {
+		case api.DeploymentNodeDrainModeResignLeadership:
+			log.Info().Str("id", m.ID).Msg("Node of the member is cordoned, resigning leadership")
+
+			return api.Plan{
+				api.NewAction(api.ActionTypeResignLeadership, api.ServerGroupDBServers, m.ID, "Node cordoned"),
+				api.NewAction(api.ActionTypeSetMemberCondition, api.ServerGroupDBServers, m.ID, "Node drain prepared").
+					AddParam(api.ConditionTypeNodeDrainPrepared.String(), "T"),
+			}
+		case api.DeploymentNodeDrainModeCleanOut:
+			if m.Conditions.IsTrue(api.ConditionTypeMarkedToRemove) {
+				continue
+			}
+
+			log.Info().Str("id", m.ID).Msg("Node of the member is cordoned, replacing member")
+
+			return api.Plan{
+				api.NewAction(api.ActionTypeMarkToRemoveMember, api.ServerGroupDBServers, m.ID, "Node cordoned"),
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+func Test_NodeDrainPlan(t *testing.T) {
+	cordonedMember := func(conditions ...api.ConditionType) api.MemberStatus {
+		m := api.MemberStatus{ID: "PRMR-1", Phase: api.MemberPhaseCreated}
+		m.Conditions.Update(api.ConditionTypeNodeDrain, true, "", "")
+		for _, c := range conditions {
+			m.Conditions.Update(c, true, "", "")
+		}
+		return m
+	}
+
+	type testCase struct {
+		mode    *api.DeploymentNodeDrainMode
+		member  api.MemberStatus
+		actions []api.ActionType
+	}
+
+	testCases := map[string]testCase{
+		"Disabled by default": {
+			member: cordonedMember(),
+		},
+		"Node not cordoned": {
+			mode:   api.DeploymentNodeDrainModeResignLeadership.New(),
+			member: api.MemberStatus{ID: "PRMR-1", Phase: api.MemberPhaseCreated},
+		},
+		"Resign leadership": {
+			mode:    api.DeploymentNodeDrainModeResignLeadership.New(),
+			member:  cordonedMember(),
+			actions: []api.ActionType{api.ActionTypeResignLeadership, api.ActionTypeSetMemberCondition},
+		},
+		"Resign leadership already prepared": {
+			mode:   api.DeploymentNodeDrainModeResignLeadership.New(),
+			member: cordonedMember(api.ConditionTypeNodeDrainPrepared),
+		},
+		"Clean out": {
+			mode:    api.DeploymentNodeDrainModeCleanOut.New(),
+			member:  cordonedMember(),
+			actions: []api.ActionType{api.ActionTypeMarkToRemoveMember},
+		},
+		"Clean out already marked": {
+			mode:   api.DeploymentNodeDrainModeCleanOut.New(),
+			member: cordonedMember(api.ConditionTypeMarkedToRemove),
+		},
+		"Terminating member": {
+			mode:   api.DeploymentNodeDrainModeCleanOut.New(),
+			member: cordonedMember(api.ConditionTypeTerminating),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			spec := api.DeploymentSpec{
+				Mode:          api.NewMode(api.DeploymentModeCluster),
+				NodeDrainMode: tc.mode,
+			}
+			status := api.DeploymentStatus{
+				Members: api.DeploymentStatusMembers{
+					DBServers: api.MemberStatusList{tc.member},
+				},
+			}
+
+			plan := createNodeDrainPlan(context.Background(), log.Logger, nil, spec, status, nil, nil)
+
+			require.Len(t, plan, len(tc.actions))
+			for i, action := range tc.actions {
+				require.Equal(t, action, plan[i].Type)
+				require.Equal(t, tc.member.ID, plan[i].MemberID)
+			}
+		})
+	}
+}
@@ -29,12 +29,19 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	inspectorInterface "github.com/arangodb/kube-arangodb/pkg/util/k8sutil/inspector"
+	"github.com/arangodb/kube-arangodb/pkg/util/tracing"
 	"github.com/rs/zerolog"
 )
 
 func (d *Reconciler) CreateNormalPlan(ctx context.Context, cachedStatus inspectorInterface.Inspector) (error, bool) {
 	// Create plan
 	apiObject := d.context.GetAPIObject()
+
+	ctx, span := tracing.Start(ctx, "Create normal plan",
+		tracing.AttributeDeployment.String(apiObject.GetName()),
+		tracing.AttributeNamespace.String(apiObject.GetNamespace()))
+	defer span.End()
+
 	spec := d.context.GetSpec()
 	status, lastVersion := d.context.GetStatus()
 	builderCtx := newPlanBuilderContext(d.context)
@@ -97,6 +104,8 @@ func createNormalPlan(ctx context.Context, log zerolog.Logger, apiObject k8sutil
 		ApplyIfEmpty(createJWTStatusUpdate).
 		// Check for cleaned out dbserver in created state
 		ApplyIfEmpty(createRemoveCleanedDBServersPlan).
+		// Prepare members on cordoned nodes for eviction
+		ApplyIfEmpty(createNodeDrainPlan).
 		// Check for members to be removed
 		ApplyIfEmpty(createReplaceMemberPlan).
 		// Check for the need to rotate one or more members
@@ -113,6 +122,7 @@ func createNormalPlan(ctx context.Context, log zerolog.Logger, apiObject k8sutil
 		ApplyIfEmpty(createRotateServerStorageResizePlan).
 		ApplySubPlanIfEmpty(createTLSStatusPropagatedFieldUpdate, createRotateTLSServerSNIPlan).
 		ApplyIfEmpty(createRestorePlan).
+		ApplyIfEmpty(createVolumeSnapshotPlan).
 		ApplySubPlanIfEmpty(createEncryptionKeyStatusPropagatedFieldUpdate, createEncryptionKeyCleanPlan).
 		ApplySubPlanIfEmpty(createTLSStatusPropagatedFieldUpdate, createCACleanPlan).
 		ApplyIfEmpty(createClusterOperationPlan).
@@ -47,6 +47,14 @@ func createRestorePlan(ctx context.Context,
 		}
 	}
 
+	if spec.RestoreFrom != nil && status.Restore != nil && status.Restore.RequestedFrom != spec.GetRestoreFrom() &&
+		status.Restore.State != api.DeploymentRestoreStateRestoring {
+		// Restore from the other backup was requested, result of the previous one needs to be cleaned first
+		return api.Plan{
+			api.NewAction(api.ActionTypeBackupRestoreClean, api.ServerGroupUnknown, ""),
+		}
+	}
+
 	if spec.RestoreFrom != nil && status.Restore == nil {
 		backup, err := context.GetBackup(ctx, spec.GetRestoreFrom())
 		if err != nil {
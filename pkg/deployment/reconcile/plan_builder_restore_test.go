@@ -0,0 +1,91 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+func Test_RestorePlan_Clean(t *testing.T) {
+	restored := func(from string, state api.DeploymentRestoreState) *api.DeploymentRestoreResult {
+		return &api.DeploymentRestoreResult{
+			RequestedFrom: from,
+			State:         state,
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		restoreFrom *string
+		restore     *api.DeploymentRestoreResult
+		clean       bool
+	}{
+		{
+			name:    "restore removed from spec",
+			restore: restored("backup-1", api.DeploymentRestoreStateRestored),
+			clean:   true,
+		},
+		{
+			name:        "same backup restored",
+			restoreFrom: util.NewString("backup-1"),
+			restore:     restored("backup-1", api.DeploymentRestoreStateRestored),
+		},
+		{
+			name:        "other backup requested",
+			restoreFrom: util.NewString("backup-2"),
+			restore:     restored("backup-1", api.DeploymentRestoreStateRestored),
+			clean:       true,
+		},
+		{
+			name:        "other backup requested after failure",
+			restoreFrom: util.NewString("backup-2"),
+			restore:     restored("backup-1", api.DeploymentRestoreStateRestoreFailed),
+			clean:       true,
+		},
+		{
+			name:        "other backup requested during restore",
+			restoreFrom: util.NewString("backup-2"),
+			restore:     restored("backup-1", api.DeploymentRestoreStateRestoring),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := api.DeploymentSpec{RestoreFrom: tc.restoreFrom}
+			status := api.DeploymentStatus{Restore: tc.restore}
+
+			p := createRestorePlan(context.Background(), log.Logger, nil, spec, status, nil, nil)
+			if tc.clean {
+				require.Len(t, p, 1)
+				require.Equal(t, api.ActionTypeBackupRestoreClean, p[0].Type)
+			} else {
+				require.Empty(t, p)
+			}
+		})
+	}
+}
@@ -88,6 +88,8 @@ func createRotateOrUpgradePlanInternal(log zerolog.Logger, apiObject k8sutil.API
 
 	var newPlan api.Plan
 	var upgradeNotAllowed bool
+	// debugRemovedMember is the ID of the member which needs rotation to remove the ArangoMember debug spec
+	var debugRemovedMember string
 	var fromVersion, toVersion driver.Version
 	var fromLicense, toLicense upgraderules.License
 
@@ -127,6 +129,12 @@ func createRotateOrUpgradePlanInternal(log zerolog.Logger, apiObject k8sutil.API
 				if rotation.CheckPossible(m) {
 					if m.Conditions.IsTrue(api.ConditionTypeRestart) {
 						newPlan = createRotateMemberPlan(log, m, group, "Restart flag present")
+						if arangoMember, ok := cachedStatus.ArangoMember(m.ArangoMemberName(apiObject.GetName(), group)); ok {
+							// Removed debug spec may be the reason why the cluster is not ready
+							if rotation.IsDebugRemoved(arangoMember.Spec.Template, arangoMember.Status.Template) {
+								debugRemovedMember = m.ID
+							}
+						}
 					} else if m.Conditions.IsTrue(api.ConditionTypeUpdating) || m.Conditions.IsTrue(api.ConditionTypeUpdateFailed) {
 						continue
 					} else if m.Conditions.IsTrue(api.ConditionTypePendingUpdate) {
@@ -206,6 +214,9 @@ func createRotateOrUpgradePlanInternal(log zerolog.Logger, apiObject k8sutil.API
 		if clusterReadyForUpgrade(context) {
 			// Use the new plan
 			return newPlan, false
+		} else if debugRemovedMember != "" && clusterReadyForMemberRotation(context, debugRemovedMember) {
+			log.Info().Str("id", debugRemovedMember).Msg("Pod needs rotation to remove ArangoMember debug spec, rotating it even if it is not ready")
+			return newPlan, false
 		} else {
 			if util.BoolOrDefault(spec.AllowUnsafeUpgrade, false) {
 				log.Info().Msg("Pod needs upgrade but cluster is not ready. Either some shards are not in sync or some member is not ready, but unsafe upgrade is allowed")
@@ -372,6 +383,22 @@ func clusterReadyForUpgrade(context PlanBuilderContext) bool {
 	return allInSync && status.Conditions.IsTrue(api.ConditionTypeReady)
 }
 
+// clusterReadyForMemberRotation returns true when the cluster is ready for the rotation of the given member,
+// regardless of the readiness of the member itself.
+func clusterReadyForMemberRotation(context PlanBuilderContext, memberID string) bool {
+	status, _ := context.GetStatus()
+	othersReady := true
+	status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+		for _, m := range list {
+			if m.ID != memberID && !m.Conditions.IsTrue(api.ConditionTypeReady) {
+				othersReady = false
+			}
+		}
+		return nil
+	})
+	return othersReady && context.GetShardSyncStatus()
+}
+
 // createUpgradeMemberPlan creates a plan to upgrade (stop-recreateWithAutoUpgrade-stop-start) an existing
 // member.
 func createUpgradeMemberPlan(log zerolog.Logger, member api.MemberStatus,
@@ -26,10 +26,14 @@ import (
 	"testing"
 
 	"github.com/arangodb/go-driver"
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/resources/inspector"
 	"github.com/arangodb/kube-arangodb/pkg/util"
 	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_RotateUpgrade_Condition(t *testing.T) {
@@ -108,3 +112,97 @@ func Test_RotateUpgrade_Condition(t *testing.T) {
 		})
 	}
 }
+
+func Test_RotateUpgrade_DebugRemoved(t *testing.T) {
+	template := func(debugChecksum string) *api.ArangoMemberPodTemplate {
+		pod := &core.PodTemplateSpec{}
+		if debugChecksum != "" {
+			pod.Annotations = map[string]string{deployment.ArangoDeploymentPodDebugAnnotation: debugChecksum}
+		}
+		return &api.ArangoMemberPodTemplate{PodSpec: pod}
+	}
+
+	type testCase struct {
+		specDebug, statusDebug string
+		clusterReady           bool
+		otherNotReady          bool
+		shardsNotInSync        bool
+		rotate                 bool
+	}
+
+	testCases := map[string]testCase{
+		"Cluster ready": {
+			clusterReady: true,
+			rotate:       true,
+		},
+		"Cluster not ready": {},
+		"Debug spec removed from crashlooping member": {
+			statusDebug: "a",
+			rotate:      true,
+		},
+		"Debug spec changed on crashlooping member": {
+			specDebug:   "b",
+			statusDebug: "a",
+		},
+		"Debug spec removed while other member is not ready": {
+			statusDebug:   "a",
+			otherNotReady: true,
+		},
+		"Debug spec removed while shards are not in sync": {
+			statusDebug:     "a",
+			shardsNotInSync: true,
+		},
+	}
+
+	for n, c := range testCases {
+		t.Run(n, func(t *testing.T) {
+			image := api.ImageInfo{Image: "arangodb", ImageID: "arangodb", ArangoDBVersion: "3.8.0"}
+			member := api.MemberStatus{
+				ID:                 "PRMR-1",
+				PodName:            "pod",
+				Phase:              api.MemberPhaseCreated,
+				Image:              &image,
+				RecentTerminations: []meta.Time{meta.Now(), meta.Now()},
+			}
+			member.Conditions.Update(api.ConditionTypeRestart, true, "", "")
+			member.Conditions.Update(api.ConditionTypeReady, false, "Pod Not Ready", "")
+			other := api.MemberStatus{ID: "PRMR-2", PodName: "other", Phase: api.MemberPhaseCreated, Image: &image}
+			other.Conditions.Update(api.ConditionTypeReady, !c.otherNotReady, "", "")
+
+			depl := &api.ArangoDeployment{
+				ObjectMeta: meta.ObjectMeta{Name: "depl", Namespace: "test"},
+				Spec: api.DeploymentSpec{
+					Mode:  api.NewMode(api.DeploymentModeCluster),
+					Image: util.NewString("arangodb"),
+				},
+				Status: api.DeploymentStatus{
+					Images: api.ImageInfoList{image},
+					Members: api.DeploymentStatusMembers{
+						DBServers: api.MemberStatusList{member, other},
+					},
+				},
+			}
+			depl.Status.Conditions.Update(api.ConditionTypeReady, c.clusterReady, "", "")
+
+			memberName := member.ArangoMemberName(depl.GetName(), api.ServerGroupDBServers)
+			cachedStatus := inspector.NewInspectorFromData(nil, nil, nil, nil, nil, nil, nil, map[string]*api.ArangoMember{
+				memberName: {
+					ObjectMeta: meta.ObjectMeta{Name: memberName, Namespace: "test"},
+					Spec:       api.ArangoMemberSpec{Template: template(c.specDebug)},
+					Status:     api.ArangoMemberStatus{Template: template(c.statusDebug)},
+				},
+			})
+
+			plan, idle := createRotateOrUpgradePlanInternal(log.Logger, depl, depl.Spec, depl.Status, cachedStatus, &testContext{ArangoDeployment: depl, ShardsNotInSync: c.shardsNotInSync})
+			if c.rotate {
+				require.False(t, idle)
+				require.NotEmpty(t, plan)
+				require.Equal(t, api.ActionTypeRotateMember, plan[2].Type)
+				require.Equal(t, member.ID, plan[2].MemberID)
+			} else {
+				require.True(t, idle)
+				require.Empty(t, plan)
+			}
+		})
+	}
+}
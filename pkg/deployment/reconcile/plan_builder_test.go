@@ -31,6 +31,7 @@ import (
 
 	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
 	monitoringClient "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/arangodb/kube-arangodb/pkg/deployment/resources"
@@ -77,6 +78,7 @@ type testContext struct {
 	PVC              *core.PersistentVolumeClaim
 	PVCErr           error
 	RecordedEvent    *k8sutil.Event
+	ShardsNotInSync  bool
 }
 
 func (c *testContext) WithStatusUpdateErr(ctx context.Context, action resources.DeploymentStatusUpdateErrFunc, force ...bool) error {
@@ -91,6 +93,10 @@ func (c *testContext) GetMonitoringV1Cli() monitoringClient.MonitoringV1Interfac
 	panic("implement me")
 }
 
+func (c *testContext) GetKubeDynamicCli() dynamic.Interface {
+	panic("implement me")
+}
+
 func (c *testContext) GetArangoCli() versioned.Interface {
 	panic("implement me")
 }
@@ -163,6 +169,10 @@ func (c *testContext) GetBackup(_ context.Context, backup string) (*backupApi.Ar
 	panic("implement me")
 }
 
+func (c *testContext) GetVolumeSnapshots() []backupApi.ArangoVolumeSnapshot {
+	return nil
+}
+
 func (c *testContext) SecretsInterface() k8sutil.SecretInterface {
 	panic("implement me")
 }
@@ -306,7 +316,7 @@ func (c *testContext) GetExpectedPodArguments(apiObject meta.Object, deplSpec ap
 
 // GetShardSyncStatus returns true if all shards are in sync
 func (c *testContext) GetShardSyncStatus() bool {
-	return true
+	return !c.ShardsNotInSync
 }
 
 // InvalidateSyncStatus resets the sync state to false and triggers an inspection
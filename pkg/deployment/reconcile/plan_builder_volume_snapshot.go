@@ -0,0 +1,63 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package reconcile
+
+import (
+	"context"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	inspectorInterface "github.com/arangodb/kube-arangodb/pkg/util/k8sutil/inspector"
+	"github.com/rs/zerolog"
+)
+
+const volumeSnapshotActionParam = "volumeSnapshot"
+
+// createVolumeSnapshotPlan creates plan to take VolumeSnapshots of member PVCs
+// requested by the ArangoVolumeSnapshot resources
+func createVolumeSnapshotPlan(ctx context.Context,
+	log zerolog.Logger, apiObject k8sutil.APIObject,
+	spec api.DeploymentSpec, status api.DeploymentStatus,
+	cachedStatus inspectorInterface.Inspector, context PlanBuilderContext) api.Plan {
+	for _, snapshot := range context.GetVolumeSnapshots() {
+		if snapshot.Status.State != backupApi.ArangoVolumeSnapshotStateNone {
+			continue
+		}
+
+		return volumeSnapshotPlan(spec, snapshot.GetName())
+	}
+
+	return nil
+}
+
+func volumeSnapshotPlan(spec api.DeploymentSpec, name string) api.Plan {
+	p := api.Plan{
+		api.NewAction(api.ActionTypeVolumeSnapshotCreate, api.ServerGroupUnknown, "").AddParam(volumeSnapshotActionParam, name),
+	}
+
+	if spec.Mode.Get() == api.DeploymentModeSingle || spec.Database.GetMaintenance() {
+		// Maintenance is not available or is already managed by the user
+		return p
+	}
+
+	return withMaintenance(p...)
+}
@@ -28,10 +28,14 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/arangodb/kube-arangodb/pkg/metrics"
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 	inspectorInterface "github.com/arangodb/kube-arangodb/pkg/util/k8sutil/inspector"
+	"github.com/arangodb/kube-arangodb/pkg/util/tracing"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
@@ -39,6 +43,7 @@ import (
 )
 
 type planner interface {
+	Name() string
 	Get(deployment *api.DeploymentStatus) api.Plan
 	Set(deployment *api.DeploymentStatus, p api.Plan) bool
 }
@@ -49,6 +54,10 @@ var _ planner = plannerHigh{}
 type plannerNormal struct {
 }
 
+func (p plannerNormal) Name() string {
+	return "normal"
+}
+
 func (p plannerNormal) Get(deployment *api.DeploymentStatus) api.Plan {
 	return deployment.Plan
 }
@@ -65,6 +74,10 @@ func (p plannerNormal) Set(deployment *api.DeploymentStatus, plan api.Plan) bool
 type plannerHigh struct {
 }
 
+func (p plannerHigh) Name() string {
+	return "high"
+}
+
 func (p plannerHigh) Get(deployment *api.DeploymentStatus) api.Plan {
 	return deployment.HighPriorityPlan
 }
@@ -105,11 +118,14 @@ func (d *Reconciler) executePlanStatus(ctx context.Context, cachedStatus inspect
 	plan := pg.Get(&loopStatus)
 
 	if len(plan) == 0 {
+		d.observePlanLength(pg, plan)
 		return false, nil
 	}
 
 	newPlan, callAgain, err := d.executePlan(ctx, cachedStatus, log, plan)
 
+	d.observePlanLength(pg, newPlan)
+
 	// Refresh current status
 	loopStatus, lastVersion := d.context.GetStatus()
 
@@ -204,23 +220,34 @@ func (d *Reconciler) executePlan(ctx context.Context, cachedStatus inspectorInte
 func (d *Reconciler) executeAction(ctx context.Context, log zerolog.Logger, planAction api.Action, action Action) (done, abort, callAgain bool, err error) {
 	if planAction.StartTime.IsZero() {
 		// Not started yet
-		ready, err := action.Start(ctx)
+		ctxStart, span := d.startActionSpan(ctx, "Start", planAction)
+		ready, err := action.Start(ctxStart)
+		span.SetAttributes(attribute.Bool("arangodb.action.ready", ready))
+		tracing.End(span, err)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to start action")
+			d.observeActionResult(planAction, metrics.Failed)
 			return false, false, false, errors.WithStack(err)
 		}
 
+		d.observeActionStart(planAction)
+
 		if ready {
 			log.Debug().Bool("ready", ready).Msg("Action Start completed")
+			d.observeActionResult(planAction, metrics.Success)
 			return true, false, false, nil
 		}
 
 		return false, false, true, nil
 	}
 	// First action of plan has been started, check its progress
-	ready, abort, err := action.CheckProgress(ctx)
+	ctxProgress, span := d.startActionSpan(ctx, "CheckProgress", planAction)
+	ready, abort, err := action.CheckProgress(ctxProgress)
+	span.SetAttributes(attribute.Bool("arangodb.action.ready", ready), attribute.Bool("arangodb.action.abort", abort))
+	tracing.End(span, err)
 	if err != nil {
 		log.Debug().Err(err).Msg("Failed to check action progress")
+		d.observeActionResult(planAction, metrics.Failed)
 		return false, false, false, errors.WithStack(err)
 	}
 
@@ -230,15 +257,18 @@ func (d *Reconciler) executeAction(ctx context.Context, log zerolog.Logger, plan
 		Msg("Action CheckProgress completed")
 
 	if ready {
+		d.observeActionResult(planAction, metrics.Success)
 		return true, false, false, nil
 	}
 
 	if abort {
 		log.Warn().Msg("Action aborted. Removing the entire plan")
+		d.observeActionResult(planAction, metrics.Aborted)
 		d.context.CreateEvent(k8sutil.NewPlanAbortedEvent(d.context.GetAPIObject(), string(planAction.Type), planAction.MemberID, planAction.Group.AsRole()))
 		return false, true, false, nil
 	} else if time.Now().After(planAction.CreationTime.Add(action.Timeout(d.context.GetSpec()))) {
 		log.Warn().Msg("Action not finished in time. Removing the entire plan")
+		d.observeActionResult(planAction, metrics.Timeout)
 		d.context.CreateEvent(k8sutil.NewPlanTimeoutEvent(d.context.GetAPIObject(), string(planAction.Type), planAction.MemberID, planAction.Group.AsRole()))
 		return false, true, false, nil
 	}
@@ -247,6 +277,17 @@ func (d *Reconciler) executeAction(ctx context.Context, log zerolog.Logger, plan
 	return false, false, true, nil
 }
 
+// startActionSpan creates span for the given phase of the action
+func (d *Reconciler) startActionSpan(ctx context.Context, phase string, action api.Action) (context.Context, trace.Span) {
+	return tracing.Start(ctx, fmt.Sprintf("Action %s %s", action.Type, phase),
+		tracing.AttributeDeployment.String(d.context.GetName()),
+		tracing.AttributeNamespace.String(d.context.GetAPIObject().GetNamespace()),
+		tracing.AttributeAction.String(action.ID),
+		tracing.AttributeActionType.String(action.Type.String()),
+		tracing.AttributeGroup.String(action.Group.AsRole()),
+		tracing.AttributeMember.String(action.MemberID))
+}
+
 // createAction create action object based on action type
 func (d *Reconciler) createAction(log zerolog.Logger, action api.Action, cachedStatus inspectorInterface.Inspector) Action {
 	actionCtx := newActionContext(log.With().Str("id", action.ID).Str("type", action.Type.String()).Logger(), d.context, cachedStatus)
@@ -36,13 +36,16 @@ import (
 type Reconciler struct {
 	log     zerolog.Logger
 	context Context
+	metrics *deploymentMetrics
 }
 
 // NewReconciler creates a new reconciler with given context.
 func NewReconciler(log zerolog.Logger, context Context) *Reconciler {
+	apiObject := context.GetAPIObject()
 	return &Reconciler{
 		log:     log,
 		context: context,
+		metrics: newDeploymentMetrics(apiObject.GetNamespace(), apiObject.GetName()),
 	}
 }
 
@@ -34,6 +34,7 @@ const (
 	pvcResizeTimeout                 = time.Minute * 30
 	pvcResizedTimeout                = time.Minute * 15
 	backupRestoreTimeout             = time.Minute * 15
+	volumeSnapshotCreateTimeout      = time.Minute * 10
 	shutdownMemberTimeout            = time.Minute * 30
 	upgradeMemberTimeout             = time.Hour * 6
 	waitForMemberUpTimeout           = time.Minute * 30
@@ -28,6 +28,7 @@ import (
 
 	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
 	monitoringClient "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/arangodb/kube-arangodb/pkg/operator/scope"
@@ -92,6 +93,8 @@ type DeploymentCLIGetter interface {
 	GetKubeCli() kubernetes.Interface
 	// GetMonitoringV1Cli returns monitoring client
 	GetMonitoringV1Cli() monitoringClient.MonitoringV1Interface
+	// GetKubeDynamicCli returns the kubernetes dynamic client
+	GetKubeDynamicCli() dynamic.Interface
 	// GetArangoCli returns the Arango CRD client
 	GetArangoCli() versioned.Interface
 }
@@ -161,6 +164,10 @@ type Context interface {
 	GetAgency(ctx context.Context) (agency.Agency, error)
 	// GetBackup receives information about a backup resource
 	GetBackup(ctx context.Context, backup string) (*backupApi.ArangoBackup, error)
+	// GetVolumeSnapshots returns the cached ArangoVolumeSnapshot resources which refer to the deployment
+	GetVolumeSnapshots() []backupApi.ArangoVolumeSnapshot
+	// GetNode returns the cached node with given name, nodes are not available in namespaced scope
+	GetNode(name string) (*core.Node, bool)
 	GetScope() scope.Scope
 
 	GetCachedStatus() inspectorInterface.Inspector
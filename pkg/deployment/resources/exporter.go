@@ -61,7 +61,7 @@ func ArangodbExporterContainer(image string, args []string, livenessProbe *probe
 	return c
 }
 
-func createInternalExporterArgs(spec api.DeploymentSpec, groupSpec api.ServerGroupSpec, version driver.Version) []string {
+func createInternalExporterArgs(spec api.DeploymentSpec, groupSpec api.ServerGroupSpec, version driver.Version, labels map[string]string) []string {
 	tokenpath := filepath.Join(k8sutil.ExporterJWTVolumeMountDir, constants.SecretKeyToken)
 	options := k8sutil.CreateOptionPairs(64)
 
@@ -91,6 +91,20 @@ func createInternalExporterArgs(spec api.DeploymentSpec, groupSpec api.ServerGro
 		options.Addf("--server.address", ":%d", port)
 	}
 
+	if spec.Metrics.IsRelabel() {
+		for k, v := range labels {
+			options.Addf("--metrics.label", "%s=%s", k, v)
+		}
+	}
+
+	for _, e := range spec.Metrics.Allow {
+		options.Add("--metrics.allow", e)
+	}
+
+	for _, e := range spec.Metrics.Deny {
+		options.Add("--metrics.deny", e)
+	}
+
 	return options.Sort().AsArgs()
 }
 
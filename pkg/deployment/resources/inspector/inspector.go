@@ -26,7 +26,9 @@ package inspector
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/arangodb/kube-arangodb/pkg/metrics"
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,6 +45,10 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+var (
+	inspectorRefreshDurationHistograms = metrics.MustRegisterHistogramVec("deployment_inspector", "refresh_duration", "Amount of time taken by a refresh of the cached resources (in sec)", nil, metrics.Namespace, metrics.Result)
+)
+
 // SecretReadInterface has methods to work with Secret resources with ReadOnly mode.
 type SecretReadInterface interface {
 	Get(ctx context.Context, name string, opts meta.GetOptions) (*core.Secret, error)
@@ -106,7 +112,7 @@ type inspector struct {
 	arangoMembers        map[string]*api.ArangoMember
 }
 
-func (i *inspector) Refresh(ctx context.Context) error {
+func (i *inspector) Refresh(ctx context.Context) (err error) {
 	i.lock.Lock()
 	defer i.lock.Unlock()
 
@@ -114,6 +120,15 @@ func (i *inspector) Refresh(ctx context.Context) error {
 		return errors.New("Inspector created fro mstatic data")
 	}
 
+	start := time.Now()
+	defer func() {
+		result := metrics.Success
+		if err != nil {
+			result = metrics.Failed
+		}
+		metrics.ObserveDuration(inspectorRefreshDurationHistograms.WithLabelValues(i.namespace, result), start)
+	}()
+
 	pods, err := podsToMap(ctx, i.k, i.namespace)
 	if err != nil {
 		return err
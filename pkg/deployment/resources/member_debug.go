@@ -0,0 +1,147 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	inspectorInterface "github.com/arangodb/kube-arangodb/pkg/util/k8sutil/inspector"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil/inspector/arangomember"
+)
+
+const (
+	maxArangoMemberDebugInterval = util.Interval(time.Minute)
+)
+
+// applyArangoMemberDebug applies the debug spec of the ArangoMember to the server container of the pod.
+// Checksum of the debug spec is stored in the pod annotation, so changes of the debug spec can be detected during rotation.
+func applyArangoMemberDebug(member *api.ArangoMember, pod *core.Pod) {
+	debug := member.Spec.Debug
+	if debug == nil || debug.Validate() != nil {
+		return
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[deployment.ArangoDeploymentPodDebugAnnotation] = debug.Checksum()
+
+	for id := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[id]
+		if c.Name != k8sutil.ServerContainerName {
+			continue
+		}
+
+		c.Command = append(c.Command, debug.Args...)
+
+		for _, env := range debug.Envs {
+			c.Env = setContainerEnv(c.Env, core.EnvVar{
+				Name:  env.Name,
+				Value: env.Value,
+			})
+		}
+	}
+}
+
+// setContainerEnv overrides the env with the same name or appends it.
+func setContainerEnv(envs []core.EnvVar, env core.EnvVar) []core.EnvVar {
+	for id := range envs {
+		if envs[id].Name == env.Name {
+			envs[id] = env
+			return envs
+		}
+	}
+
+	return append(envs, env)
+}
+
+// EnsureArangoMembersDebug tracks when the debug spec of ArangoMembers was applied
+// and removes it when its TTL passes.
+// Returns: Interval_till_next_inspection, error
+func (r *Resources) EnsureArangoMembersDebug(ctx context.Context, cachedStatus inspectorInterface.Inspector) (util.Interval, error) {
+	log := r.log
+	obj := r.context.GetAPIObject()
+	nextInterval := maxArangoMemberDebugInterval
+	now := time.Now()
+
+	if err := cachedStatus.IterateArangoMembers(func(member *api.ArangoMember) error {
+		debug := member.Spec.Debug
+
+		if debug == nil {
+			if member.Status.Debug == nil {
+				return nil
+			}
+
+			return r.context.WithArangoMemberStatusUpdate(ctx, member.GetNamespace(), member.GetName(), func(_ *api.ArangoMember, s *api.ArangoMemberStatus) bool {
+				if s.Debug == nil {
+					return false
+				}
+				s.Debug = nil
+				return true
+			})
+		}
+
+		if err := debug.Validate(); err != nil {
+			log.Warn().Err(err).Str("member", member.GetName()).Msg("Invalid debug spec of ArangoMember, ignoring it")
+			return nil
+		}
+
+		if checksum := debug.Checksum(); member.Status.Debug == nil || member.Status.Debug.Checksum != checksum {
+			log.Info().Str("member", member.GetName()).Msg("Debug spec of ArangoMember changed")
+			return r.context.WithArangoMemberStatusUpdate(ctx, member.GetNamespace(), member.GetName(), func(_ *api.ArangoMember, s *api.ArangoMemberStatus) bool {
+				s.Debug = &api.ArangoMemberDebugStatus{
+					Checksum:  checksum,
+					StartTime: meta.NewTime(now),
+				}
+				return true
+			})
+		}
+
+		if member.Status.Debug.IsExpired(debug, now) {
+			log.Info().Str("member", member.GetName()).Msg("TTL of ArangoMember debug spec passed, removing it")
+			return r.context.WithArangoMemberUpdate(ctx, member.GetNamespace(), member.GetName(), func(m *api.ArangoMember) bool {
+				if m.Spec.Debug == nil {
+					return false
+				}
+				m.Spec.Debug = nil
+				return true
+			})
+		}
+
+		if expiresIn := member.Status.Debug.ExpiresIn(debug, now); expiresIn > 0 {
+			nextInterval = nextInterval.ReduceTo(util.Interval(expiresIn))
+		}
+
+		return nil
+	}, arangomember.FilterByDeploymentUID(obj.GetUID())); err != nil {
+		return 0, err
+	}
+
+	return nextInterval, nil
+}
@@ -0,0 +1,43 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	core "k8s.io/api/core/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+// applyArangoMemberOverrides stores the checksum of the ArangoMember overrides in the pod annotation.
+// Overrides are merged into the server group spec before the pod is rendered, annotation allows
+// to detect their changes during rotation.
+func applyArangoMemberOverrides(member *api.ArangoMember, pod *core.Pod) {
+	overrides := member.Spec.Overrides
+	if overrides == nil {
+		return
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[deployment.ArangoDeploymentPodOverridesAnnotation] = overrides.Checksum()
+}
@@ -0,0 +1,152 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	inspectorInterface "github.com/arangodb/kube-arangodb/pkg/util/k8sutil/inspector"
+)
+
+// InspectNodeDrain sets the NodeDrain condition on DBServers whose pods run on cordoned nodes,
+// so the plan can prepare them for the eviction of their pods.
+// Conditions are removed when the node is uncordoned or the pod is gone.
+// Pods of prepared DBServers are labeled, so they are not protected by the node drain PDB anymore.
+func (r *Resources) InspectNodeDrain(ctx context.Context, cachedStatus inspectorInterface.Inspector) error {
+	log := r.log
+	spec := r.context.GetSpec()
+	status, _ := r.context.GetStatus()
+
+	nodeNames := map[string]string{}
+	if spec.NodeDrainMode.Get().IsEnabled() && spec.GetMode().HasDBServers() && len(status.Members.DBServers) > 0 {
+		if r.context.GetScope().IsNamespaced() {
+			log.Debug().Msg("Node drain mode requires access to nodes, which is not available in namespaced scope")
+		} else {
+			for _, m := range status.Members.DBServers {
+				pod, ok := cachedStatus.Pod(m.PodName)
+				if !ok {
+					continue
+				}
+				if node, ok := r.context.GetNode(pod.Spec.NodeName); ok && node.Spec.Unschedulable {
+					nodeNames[m.ID] = pod.Spec.NodeName
+				}
+			}
+		}
+	}
+
+	var drained []api.MemberStatus
+	if err := r.context.WithStatusUpdate(ctx, func(s *api.DeploymentStatus) bool {
+		drained = nil
+		changed := false
+
+		for _, m := range s.Members.DBServers {
+			updated := false
+			if nodeName, ok := nodeNames[m.ID]; ok {
+				if !m.Conditions.IsTrue(api.ConditionTypeNodeDrain) {
+					drained = append(drained, m)
+				}
+				updated = m.Conditions.Update(api.ConditionTypeNodeDrain, true, "Node cordoned", fmt.Sprintf("Node %s is cordoned", nodeName))
+			} else {
+				// Both conditions needs to be removed
+				updated = m.Conditions.Remove(api.ConditionTypeNodeDrain)
+				updated = m.Conditions.Remove(api.ConditionTypeNodeDrainPrepared) || updated
+			}
+
+			if updated {
+				if err := s.Members.Update(m, api.ServerGroupDBServers); err != nil {
+					log.Warn().Err(err).Str("member", m.ID).Msg("Unable to update member")
+					continue
+				}
+				changed = true
+			}
+		}
+
+		return changed
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, m := range drained {
+		log.Info().Str("member", m.ID).Str("node", nodeNames[m.ID]).Msg("Node of the member is cordoned")
+		r.context.CreateEvent(k8sutil.NewMemberNodeDrainEvent(m.ID, api.ServerGroupDBServers.AsRole(), nodeNames[m.ID], r.context.GetAPIObject()))
+	}
+
+	status, _ = r.context.GetStatus()
+	for _, m := range status.Members.DBServers {
+		pod, ok := cachedStatus.Pod(m.PodName)
+		if !ok {
+			continue
+		}
+
+		prepared := isNodeDrainPrepared(m)
+		if _, labeled := pod.GetLabels()[k8sutil.LabelKeyArangoNodeDrainPrepared]; labeled == prepared {
+			continue
+		}
+
+		if err := r.setNodeDrainPreparedLabel(ctx, pod.GetName(), prepared); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+// isNodeDrainPrepared returns true when the pod of the DBServer on a cordoned node can be evicted.
+func isNodeDrainPrepared(m api.MemberStatus) bool {
+	if !m.Conditions.IsTrue(api.ConditionTypeNodeDrain) {
+		return false
+	}
+
+	return m.Conditions.IsTrue(api.ConditionTypeNodeDrainPrepared) || m.Conditions.IsTrue(api.ConditionTypeCleanedOut)
+}
+
+// setNodeDrainPreparedLabel adds or removes the label which excludes the pod from the node drain PDB.
+func (r *Resources) setNodeDrainPreparedLabel(ctx context.Context, podName string, prepared bool) error {
+	var value interface{}
+	if prepared {
+		value = "true"
+	}
+
+	d, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{
+				k8sutil.LabelKeyArangoNodeDrainPrepared: value,
+			},
+		},
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	r.log.Info().Str("pod", podName).Bool("prepared", prepared).Msg("Updating node drain label of the pod")
+	return k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+		_, err := r.context.GetKubeCli().CoreV1().Pods(r.context.GetNamespace()).Patch(ctxChild, podName, types.MergePatchType, d, meta.PatchOptions{})
+		return err
+	})
+}
@@ -0,0 +1,83 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+func Test_NodeDrainPDB_Selector(t *testing.T) {
+	pdb := newNodeDrainPDB("depl", meta.OwnerReference{})
+	selector, err := meta.LabelSelectorAsSelector(pdb.Spec.Selector)
+	require.NoError(t, err)
+	require.Equal(t, 0, pdb.Spec.MaxUnavailable.IntValue())
+
+	dbserver := k8sutil.LabelsForMember("depl", api.ServerGroupDBServers.AsRole(), "PRMR-1")
+	require.True(t, selector.Matches(labels.Set(dbserver)))
+
+	dbserver[k8sutil.LabelKeyArangoNodeDrainPrepared] = "true"
+	require.False(t, selector.Matches(labels.Set(dbserver)))
+
+	require.False(t, selector.Matches(labels.Set(k8sutil.LabelsForMember("depl", api.ServerGroupCoordinators.AsRole(), "CRDN-1"))))
+	require.False(t, selector.Matches(labels.Set(k8sutil.LabelsForMember("other", api.ServerGroupDBServers.AsRole(), "PRMR-1"))))
+}
+
+func Test_IsNodeDrainPrepared(t *testing.T) {
+	type testCase struct {
+		conditions []api.ConditionType
+		prepared   bool
+	}
+
+	testCases := map[string]testCase{
+		"Node not cordoned": {},
+		"Node not cordoned, prepared before": {
+			conditions: []api.ConditionType{api.ConditionTypeNodeDrainPrepared},
+		},
+		"Not prepared": {
+			conditions: []api.ConditionType{api.ConditionTypeNodeDrain},
+		},
+		"Leadership resigned": {
+			conditions: []api.ConditionType{api.ConditionTypeNodeDrain, api.ConditionTypeNodeDrainPrepared},
+			prepared:   true,
+		},
+		"Cleaned out": {
+			conditions: []api.ConditionType{api.ConditionTypeNodeDrain, api.ConditionTypeCleanedOut},
+			prepared:   true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			m := api.MemberStatus{ID: "PRMR-1", Phase: api.MemberPhaseCreated}
+			for _, c := range tc.conditions {
+				m.Conditions.Update(c, true, "", "")
+			}
+			require.Equal(t, tc.prepared, isNodeDrainPrepared(m))
+		})
+	}
+}
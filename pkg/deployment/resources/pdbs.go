@@ -81,6 +81,12 @@ func (r *Resources) EnsurePDBs(ctx context.Context) error {
 		if err := r.ensurePDBForGroup(ctx, api.ServerGroupSyncWorkers, minSyncWorker); err != nil {
 			return err
 		}
+
+		// Do not allow eviction of DBServers until they are prepared for the node drain
+		nodeDrain := spec.NodeDrainMode.Get().IsEnabled() && !r.context.GetScope().IsNamespaced()
+		if err := r.ensureNodeDrainPDB(ctx, nodeDrain); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -90,6 +96,79 @@ func PDBNameForGroup(depl string, group api.ServerGroup) string {
 	return fmt.Sprintf("%s-%s-pdb", depl, group.AsRole())
 }
 
+// NodeDrainPDBName returns the name of the PDB which protects DBServers not prepared for the node drain
+func NodeDrainPDBName(depl string) string {
+	return fmt.Sprintf("%s-%s-drain-pdb", depl, api.ServerGroupDBServers.AsRole())
+}
+
+// newNodeDrainPDB creates a PDB which does not allow the eviction of DBServer pods without the node drain prepared label
+func newNodeDrainPDB(deplname string, owner metav1.OwnerReference) *policyv1beta1.PodDisruptionBudget {
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            NodeDrainPDBName(deplname),
+			OwnerReferences: []metav1.OwnerReference{owner},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: newFromInt(0),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: k8sutil.LabelsForDeployment(deplname, api.ServerGroupDBServers.AsRole()),
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      k8sutil.LabelKeyArangoNodeDrainPrepared,
+						Operator: metav1.LabelSelectorOpDoesNotExist,
+					},
+				},
+			},
+		},
+	}
+}
+
+// ensureNodeDrainPDB creates the node drain PDB when enabled or removes it otherwise.
+// PDB is never recreated, DBServers are excluded from it by the label of their pods,
+// so there is no time window without the PDB during the node drain.
+func (r *Resources) ensureNodeDrainPDB(ctx context.Context, enabled bool) error {
+	deplname := r.context.GetAPIObject().GetName()
+	pdbname := NodeDrainPDBName(deplname)
+	pdbcli := r.context.GetKubeCli().PolicyV1beta1().PodDisruptionBudgets(r.context.GetNamespace())
+
+	var pdb *policyv1beta1.PodDisruptionBudget
+	err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+		var err error
+		pdb, err = pdbcli.Get(ctxChild, pdbname, metav1.GetOptions{})
+		return err
+	})
+	if k8sutil.IsNotFound(err) {
+		if !enabled {
+			return nil
+		}
+
+		r.log.Debug().Msg("Creating node drain PDB")
+		err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+			_, err := pdbcli.Create(ctxChild, newNodeDrainPDB(deplname, r.context.GetAPIObject().AsOwner()), metav1.CreateOptions{})
+			return err
+		})
+		if err != nil && !k8sutil.IsAlreadyExists(err) {
+			return errors.WithStack(err)
+		}
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if enabled || pdb.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	r.log.Debug().Msg("Removing node drain PDB")
+	err = k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+		return pdbcli.Delete(ctxChild, pdbname, metav1.DeleteOptions{})
+	})
+	if err != nil && !k8sutil.IsNotFound(err) {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
 func newPDB(minAvail int, deplname string, group api.ServerGroup, owner metav1.OwnerReference) *policyv1beta1.PodDisruptionBudget {
 	return &policyv1beta1.PodDisruptionBudget{
 		ObjectMeta: metav1.ObjectMeta{
@@ -361,6 +361,8 @@ func (r *Resources) RenderPodForMember(ctx context.Context, cachedStatus inspect
 		return nil, errors.Newf("ArangoMember %s not found", memberName)
 	}
 
+	groupSpec = member.Spec.Overrides.Apply(groupSpec)
+
 	// Update pod name
 	role := group.AsRole()
 	roleAbbr := group.AsRoleAbbreviated()
@@ -399,7 +401,7 @@ func (r *Resources) RenderPodForMember(ctx context.Context, cachedStatus inspect
 			return nil, errors.WithStack(errors.Wrapf(err, "Validation of pods resources failed"))
 		}
 
-		return RenderArangoPod(cachedStatus, apiObject, role, newMember.ID, newMember.PodName, args, &memberPod)
+		return renderArangoMemberPod(cachedStatus, apiObject, role, newMember.ID, newMember.PodName, args, &memberPod, member)
 	} else if group.IsArangosync() {
 		// Check image
 		if !imageInfo.Enterprise {
@@ -470,7 +472,7 @@ func (r *Resources) RenderPodForMember(ctx context.Context, cachedStatus inspect
 			arangoMember:           *member,
 		}
 
-		return RenderArangoPod(cachedStatus, apiObject, role, newMember.ID, newMember.PodName, args, &memberSyncPod)
+		return renderArangoMemberPod(cachedStatus, apiObject, role, newMember.ID, newMember.PodName, args, &memberSyncPod, member)
 	} else {
 		return nil, errors.Newf("unable to render Pod")
 	}
@@ -645,6 +647,20 @@ func (r *Resources) createPodForMember(ctx context.Context, spec api.DeploymentS
 	return nil
 }
 
+// renderArangoMemberPod renders new Pod with the overrides and the debug spec of the ArangoMember applied
+func renderArangoMemberPod(cachedStatus inspectorInterface.Inspector, deployment k8sutil.APIObject, role, id, podName string,
+	args []string, podCreator interfaces.PodCreator, member *api.ArangoMember) (*core.Pod, error) {
+	p, err := RenderArangoPod(cachedStatus, deployment, role, id, podName, args, podCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	applyArangoMemberOverrides(member, p)
+	applyArangoMemberDebug(member, p)
+
+	return p, nil
+}
+
 // RenderArangoPod renders new ArangoD Pod
 func RenderArangoPod(cachedStatus inspectorInterface.Inspector, deployment k8sutil.APIObject, role, id, podName string,
 	args []string, podCreator interfaces.PodCreator) (*core.Pod, error) {
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"strconv"
 
 	"github.com/arangodb/kube-arangodb/pkg/deployment/topology"
 
@@ -525,10 +526,25 @@ func (m *MemberArangoDPod) GetContainerCreator() interfaces.ContainerCreator {
 	}
 }
 
+// createMetricsLabels returns labels which identify metrics of the member
+func (m *MemberArangoDPod) createMetricsLabels() map[string]string {
+	labels := map[string]string{
+		"deployment": m.context.GetAPIObject().GetName(),
+		"role":       m.group.AsRole(),
+		"member_id":  m.status.ID,
+	}
+
+	if t := m.status.Topology; t != nil {
+		labels["zone"] = strconv.Itoa(t.Zone)
+	}
+
+	return labels
+}
+
 func (m *MemberArangoDPod) createMetricsExporterSidecarInternalExporter() (*core.Container, error) {
 	image := m.GetContainerCreator().GetImage()
 
-	args := createInternalExporterArgs(m.spec, m.groupSpec, m.imageInfo.ArangoDBVersion)
+	args := createInternalExporterArgs(m.spec, m.groupSpec, m.imageInfo.ArangoDBVersion, m.createMetricsLabels())
 
 	c, err := ArangodbInternalExporterContainer(image, args,
 		createExporterLivenessProbe(m.spec.IsSecure() && m.spec.Metrics.IsTLS()), m.spec.Metrics.Resources,
@@ -31,6 +31,8 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	inspectorInterface "github.com/arangodb/kube-arangodb/pkg/util/k8sutil/inspector"
+
+	core "k8s.io/api/core/v1"
 )
 
 // createPVCFinalizers creates a list of finalizers for a PVC created for the given group.
@@ -47,6 +49,7 @@ func (r *Resources) EnsurePVCs(ctx context.Context, cachedStatus inspectorInterf
 	owner := apiObject.AsOwner()
 	iterator := r.context.GetServerGroupIterator()
 	status, _ := r.context.GetStatus()
+	volumeSnapshotRestore := status.VolumeSnapshotRestore
 	enforceAntiAffinity := r.context.GetSpec().GetEnvironment().IsProduction()
 	pvcs := kubecli.CoreV1().PersistentVolumeClaims(apiObject.GetNamespace())
 
@@ -65,8 +68,14 @@ func (r *Resources) EnsurePVCs(ctx context.Context, cachedStatus inspectorInterf
 			resources := spec.Resources
 			vct := spec.VolumeClaimTemplate
 			finalizers := r.createPVCFinalizers(group)
+
+			var dataSource *core.TypedLocalObjectReference
+			if name, ok := volumeSnapshotRestore.GetVolumeSnapshotName(group, m.ID); ok {
+				dataSource = k8sutil.NewVolumeSnapshotDataSource(name)
+			}
+
 			err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
-				return k8sutil.CreatePersistentVolumeClaim(ctxChild, pvcs, m.PersistentVolumeClaimName, deploymentName, ns, storageClassName, role, enforceAntiAffinity, resources, vct, finalizers, owner)
+				return k8sutil.CreatePersistentVolumeClaim(ctxChild, pvcs, m.PersistentVolumeClaimName, deploymentName, ns, storageClassName, role, enforceAntiAffinity, resources, vct, dataSource, finalizers, owner)
 			})
 			if err != nil {
 				return errors.WithStack(err)
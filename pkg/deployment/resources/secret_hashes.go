@@ -24,11 +24,7 @@ package resources
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
-	"sort"
-	"strings"
 
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 	inspectorInterface "github.com/arangodb/kube-arangodb/pkg/util/k8sutil/inspector"
@@ -237,15 +233,6 @@ func (r *Resources) getSecretHash(cachedStatus inspectorInterface.Inspector, sec
 	if !exists {
 		return nil, "", false
 	}
-	// Create hash of value
-	rows := make([]string, 0, len(s.Data))
-	for k, v := range s.Data {
-		rows = append(rows, k+"="+hex.EncodeToString(v))
-	}
-	// Sort so we're not detecting order differences
-	sort.Strings(rows)
-	data := strings.Join(rows, "\n")
-	rawHash := sha256.Sum256([]byte(data))
-	hash := fmt.Sprintf("%0x", rawHash)
+	hash := k8sutil.GetSecretHash(s)
 	return s, hash, true
 }
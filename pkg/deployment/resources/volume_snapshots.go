@@ -0,0 +1,107 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InspectVolumeSnapshots checks VolumeSnapshots of the ArangoVolumeSnapshot resources in Creating state
+// and marks them as Ready when all of them are ready to use, or as Failed when any of them failed.
+func (r *Resources) InspectVolumeSnapshots(ctx context.Context) error {
+	log := r.log
+
+	snapshots := r.context.GetVolumeSnapshots()
+
+	for id := range snapshots {
+		snapshot := snapshots[id]
+
+		if snapshot.Status.State != backupApi.ArangoVolumeSnapshotStateCreating {
+			continue
+		}
+
+		changed := false
+		failed := ""
+
+		for vid, v := range snapshot.Status.Volumes {
+			if v.ReadyToUse {
+				continue
+			}
+
+			var vs k8sutil.VolumeSnapshotStatus
+			err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+				var err error
+				vs, err = k8sutil.GetVolumeSnapshotStatus(ctxChild, r.context.GetKubeDynamicCli(), v.VolumeSnapshotName, snapshot.GetNamespace())
+				return err
+			})
+			if err != nil {
+				if k8sutil.IsNotFound(err) {
+					failed = fmt.Sprintf("VolumeSnapshot %s is missing", v.VolumeSnapshotName)
+					break
+				}
+				log.Warn().Err(err).Str("volume-snapshot", v.VolumeSnapshotName).Msg("Unable to get VolumeSnapshot")
+				continue
+			}
+
+			if vs.Error != "" {
+				failed = fmt.Sprintf("VolumeSnapshot %s failed: %s", v.VolumeSnapshotName, vs.Error)
+				break
+			}
+
+			if vs.ReadyToUse {
+				snapshot.Status.Volumes[vid].ReadyToUse = true
+				changed = true
+			}
+		}
+
+		if failed != "" {
+			snapshot.Status.State = backupApi.ArangoVolumeSnapshotStateFailed
+			snapshot.Status.Message = failed
+			snapshot.Status.Time = meta.Now()
+			changed = true
+		} else if snapshot.Status.Volumes.IsReady() {
+			snapshot.Status.State = backupApi.ArangoVolumeSnapshotStateReady
+			snapshot.Status.Time = meta.Now()
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+
+		err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+			_, err := r.context.GetArangoCli().BackupV1().ArangoVolumeSnapshots(snapshot.GetNamespace()).
+				UpdateStatus(ctxChild, &snapshot, meta.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
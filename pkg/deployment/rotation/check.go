@@ -57,12 +57,18 @@ func CheckPossible(member api.MemberStatus) bool {
 		return false
 	}
 
-	if member.Conditions.IsTrue(api.ConditionTypeTerminated) || member.Conditions.IsTrue(api.ConditionTypeTerminating) {
-		// Termination in progress, nothing to do
-		return false
-	}
+	return !isTerminating(member)
+}
 
-	return true
+// isTerminating returns true when termination of the member is in progress
+func isTerminating(member api.MemberStatus) bool {
+	return member.Conditions.IsTrue(api.ConditionTypeTerminated) || member.Conditions.IsTrue(api.ConditionTypeTerminating)
+}
+
+// IsDebugRemoved returns true when the ArangoMember debug spec applied to the pod has been removed, e.g. after its TTL passed.
+// Debug spec may keep the member from becoming ready, so such member is rotated even when it is not ready.
+func IsDebugRemoved(specTemplate, statusTemplate *api.ArangoMemberPodTemplate) bool {
+	return getDebugChecksum(specTemplate) == "" && getDebugChecksum(statusTemplate) != ""
 }
 
 func IsRotationRequired(log zerolog.Logger, cachedStatus inspectorInterface.Inspector, spec api.DeploymentSpec, member api.MemberStatus, group api.ServerGroup, pod *core.Pod, specTemplate, statusTemplate *api.ArangoMemberPodTemplate) (mode Mode, plan api.Plan, reason string, err error) {
@@ -71,6 +77,12 @@ func IsRotationRequired(log zerolog.Logger, cachedStatus inspectorInterface.Insp
 	// Set default mode for return value
 	mode = SkippedRotation
 
+	if member.PodSpecVersion != "" && IsDebugRemoved(specTemplate, statusTemplate) && !isTerminating(member) {
+		reason = "ArangoMember debug spec removed"
+		mode = EnforcedRotation
+		return
+	}
+
 	if !CheckPossible(member) {
 		// Check is not possible due to improper state of member
 		return
@@ -108,6 +120,12 @@ func IsRotationRequired(log zerolog.Logger, cachedStatus inspectorInterface.Insp
 		return
 	}
 
+	if getDebugChecksum(specTemplate) != getDebugChecksum(statusTemplate) {
+		reason = "ArangoMember debug spec changed"
+		mode = EnforcedRotation
+		return
+	}
+
 	// Check if any of resize events are in place
 	if member.Conditions.IsTrue(api.ConditionTypePendingTLSRotation) {
 		reason = "TLS Rotation pending"
@@ -130,3 +148,21 @@ func IsRotationRequired(log zerolog.Logger, cachedStatus inspectorInterface.Insp
 		return mode, plan, "Pod needs rotation", nil
 	}
 }
+
+// getDebugChecksum returns checksum of the ArangoMember debug spec applied to the template.
+func getDebugChecksum(template *api.ArangoMemberPodTemplate) string {
+	return getTemplateAnnotation(template, deployment.ArangoDeploymentPodDebugAnnotation)
+}
+
+// getOverridesChecksum returns checksum of the ArangoMember overrides applied to the template.
+func getOverridesChecksum(template *api.ArangoMemberPodTemplate) string {
+	return getTemplateAnnotation(template, deployment.ArangoDeploymentPodOverridesAnnotation)
+}
+
+func getTemplateAnnotation(template *api.ArangoMemberPodTemplate, key string) string {
+	if template == nil || template.PodSpec == nil {
+		return ""
+	}
+
+	return template.PodSpec.Annotations[key]
+}
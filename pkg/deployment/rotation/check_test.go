@@ -0,0 +1,186 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package rotation
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+func withAnnotation(key, value string) podSpecBuilder {
+	return func(pod *core.PodTemplateSpec) {
+		if value == "" {
+			return
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[key] = value
+	}
+}
+
+func withDebugChecksum(checksum string) podSpecBuilder {
+	return withAnnotation(deployment.ArangoDeploymentPodDebugAnnotation, checksum)
+}
+
+func withOverridesChecksum(checksum string) podSpecBuilder {
+	return withAnnotation(deployment.ArangoDeploymentPodOverridesAnnotation, checksum)
+}
+
+func Test_ArangoMember_Overrides(t *testing.T) {
+	testCases := []TestCase{
+		{
+			name: "Affinity changed without overrides",
+			spec: buildPodSpec(func(pod *core.PodTemplateSpec) {
+				pod.Spec.Affinity = &core.Affinity{NodeAffinity: &core.NodeAffinity{}}
+			}),
+			status: buildPodSpec(),
+
+			expectedMode: SilentRotation,
+		},
+		{
+			name: "Affinity changed with overrides",
+			spec: buildPodSpec(withOverridesChecksum("a"), func(pod *core.PodTemplateSpec) {
+				pod.Spec.Affinity = &core.Affinity{NodeAffinity: &core.NodeAffinity{}}
+			}),
+			status: buildPodSpec(),
+
+			expectedMode: GracefulRotation,
+		},
+		{
+			name: "Overrides removed",
+			spec: buildPodSpec(),
+			status: buildPodSpec(withOverridesChecksum("a"), func(pod *core.PodTemplateSpec) {
+				pod.Spec.NodeSelector = map[string]string{"zone": "a"}
+			}),
+
+			expectedMode: GracefulRotation,
+		},
+		{
+			name:   "Overrides changed without pod changes",
+			spec:   buildPodSpec(withOverridesChecksum("b")),
+			status: buildPodSpec(withOverridesChecksum("a")),
+
+			expectedMode: SkippedRotation,
+		},
+	}
+
+	runTestCases(t)(testCases...)
+}
+
+func Test_IsRotationRequired_Debug(t *testing.T) {
+	testCases := []struct {
+		name           string
+		spec, status   string
+		specArgs       []string
+		phase          api.MemberPhase
+		crashlooping   bool
+		conditions     []api.ConditionType
+		expectedMode   Mode
+		expectedReason string
+	}{
+		{
+			name:           "Debug spec added",
+			spec:           "a",
+			specArgs:       []string{"--log.level=debug"},
+			expectedMode:   EnforcedRotation,
+			expectedReason: "ArangoMember debug spec changed",
+		},
+		{
+			name:           "Debug spec changed",
+			spec:           "b",
+			status:         "a",
+			specArgs:       []string{"--log.level=trace"},
+			expectedMode:   EnforcedRotation,
+			expectedReason: "ArangoMember debug spec changed",
+		},
+		{
+			name:           "Debug spec removed",
+			status:         "a",
+			expectedMode:   EnforcedRotation,
+			expectedReason: "ArangoMember debug spec removed",
+		},
+		{
+			name:           "Debug spec removed from crashlooping member",
+			status:         "a",
+			crashlooping:   true,
+			expectedMode:   EnforcedRotation,
+			expectedReason: "ArangoMember debug spec removed",
+		},
+		{
+			name:           "Debug spec removed from member which is not ready",
+			status:         "a",
+			phase:          api.MemberPhasePending,
+			expectedMode:   EnforcedRotation,
+			expectedReason: "ArangoMember debug spec removed",
+		},
+		{
+			name:         "Debug spec changed on member which is not ready",
+			spec:         "b",
+			status:       "a",
+			specArgs:     []string{"--log.level=trace"},
+			phase:        api.MemberPhasePending,
+			expectedMode: SkippedRotation,
+		},
+		{
+			name:       "Debug spec removed from terminating member",
+			status:     "a",
+			conditions: []api.ConditionType{api.ConditionTypeTerminating},
+
+			expectedMode: SkippedRotation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var deploymentSpec api.DeploymentSpec
+
+			spec := newTemplateFromSpec(t, buildPodSpec(withDebugChecksum(tc.spec),
+				addContainerWithCommand("server", append([]string{"/usr/sbin/arangod"}, tc.specArgs...))), api.ServerGroupAgents, deploymentSpec)
+			status := newTemplateFromSpec(t, buildPodSpec(withDebugChecksum(tc.status),
+				addContainerWithCommand("server", []string{"/usr/sbin/arangod"})), api.ServerGroupAgents, deploymentSpec)
+
+			member := api.MemberStatus{ID: "id", Phase: api.MemberPhaseCreated, PodSpecVersion: "version"}
+			if tc.phase != "" {
+				member.Phase = tc.phase
+			}
+			if tc.crashlooping {
+				member.Conditions.Update(api.ConditionTypeReady, false, "Pod Not Ready", "")
+				member.RecentTerminations = []meta.Time{meta.Now(), meta.Now()}
+			}
+			for _, c := range tc.conditions {
+				member.Conditions.Update(c, true, "", "")
+			}
+
+			mode, _, reason, err := IsRotationRequired(log.Logger, nil, deploymentSpec, member, api.ServerGroupAgents, nil, spec, status)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMode, mode)
+			require.Equal(t, tc.expectedReason, reason)
+		})
+	}
+}
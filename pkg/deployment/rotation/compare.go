@@ -56,6 +56,14 @@ func compare(log zerolog.Logger, deploymentSpec api.DeploymentSpec, member api.M
 		return SkippedRotation, nil, nil
 	}
 
+	if getOverridesChecksum(spec) != getOverridesChecksum(status) {
+		// Overrides are applied only to this member, so their changes need rotation
+		// even when the rest of the pod spec could be updated silently or in place
+		log.Info().Str("id", member.ID).Msg("Pod needs rotation - ArangoMember overrides changed")
+
+		return GracefulRotation, nil, nil
+	}
+
 	// If checksums are different and rotation is not needed and there are no changes between containers
 	// then silent rotation must be applied to adjust status checksum.
 	mode = SilentRotation
@@ -0,0 +1,43 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"context"
+	"time"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/agency"
+	"github.com/arangodb/kube-arangodb/pkg/server"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+)
+
+// AgencyState reads the state of the agency (Plan, Current, Supervision and Target) of the deployment.
+func (d *Deployment) AgencyState(ctx context.Context) (*agency.ArangoState, error) {
+	if mode := d.Mode(); mode != api.DeploymentModeCluster && mode != api.DeploymentModeActiveFailover {
+		return nil, errors.Wrapf(server.BadRequestError, "deployment in mode %s does not have an agency", mode)
+	}
+
+	agencyCtx, agencyCancel := context.WithTimeout(ctx, time.Minute)
+	defer agencyCancel()
+
+	return agency.GetAgencyState(agencyCtx, d.GetAgencyData)
+}
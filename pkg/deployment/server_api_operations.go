@@ -0,0 +1,227 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/features"
+	"github.com/arangodb/kube-arangodb/pkg/server"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+const maxSpecUpdateAttempts = 10
+
+// Scale changes the number of servers in the given group.
+func (d *Deployment) Scale(ctx context.Context, group api.ServerGroup, count int) error {
+	if group == api.ServerGroupUnknown {
+		return errors.Wrapf(server.BadRequestError, "unknown server group")
+	}
+
+	return d.modifySpec(ctx, func(spec *api.DeploymentSpec) error {
+		groupSpec := spec.GetServerGroupSpec(group)
+		groupSpec.Count = &count
+		spec.UpdateServerGroupSpec(group, groupSpec)
+		return nil
+	})
+}
+
+// SetMaintenance enables or disables the maintenance mode of the cluster.
+func (d *Deployment) SetMaintenance(ctx context.Context, enabled bool) error {
+	if !features.Maintenance().Enabled() {
+		return errors.Wrapf(server.BadRequestError, "feature %s is not enabled", features.Maintenance().Name())
+	}
+
+	return d.modifySpec(ctx, func(spec *api.DeploymentSpec) error {
+		if spec.Database == nil {
+			spec.Database = &api.DatabaseSpec{}
+		}
+		spec.Database.Maintenance = &enabled
+		return nil
+	})
+}
+
+// RotateMember marks the pod of the member with given ID to be rotated.
+func (d *Deployment) RotateMember(ctx context.Context, id string) error {
+	status, _ := d.GetStatus()
+
+	member, _, ok := status.Members.ElementByID(id)
+	if !ok {
+		return errors.Wrapf(server.NotFoundError, "member %s", id)
+	}
+
+	if member.PodName == "" {
+		return errors.Wrapf(server.BadRequestError, "member %s does not have a pod", id)
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				deployment.ArangoDeploymentPodRotateAnnotation: "true",
+			},
+		},
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+		_, err := d.deps.KubeCli.CoreV1().Pods(d.Namespace()).Patch(ctxChild, member.PodName, types.MergePatchType, data, metav1.PatchOptions{})
+		return err
+	})
+	if err != nil {
+		if k8sutil.IsNotFound(err) {
+			return errors.Wrapf(server.NotFoundError, "pod %s", member.PodName)
+		}
+		return errors.WithStack(err)
+	}
+
+	d.triggerInspection()
+
+	return nil
+}
+
+// CreateBackup creates an ArangoBackup of the deployment.
+// If name is empty, name is generated. Returns the name of the created ArangoBackup.
+func (d *Deployment) CreateBackup(ctx context.Context, name string) (string, error) {
+	b := &backupApi.ArangoBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: d.Namespace(),
+		},
+		Spec: backupApi.ArangoBackupSpec{
+			Deployment: backupApi.ArangoBackupSpecDeployment{
+				Name: d.Name(),
+			},
+		},
+	}
+	if name == "" {
+		b.GenerateName = fmt.Sprintf("%s-", d.Name())
+	}
+
+	var created *backupApi.ArangoBackup
+	err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+		var err error
+		created, err = d.deps.DatabaseCRCli.BackupV1().ArangoBackups(d.Namespace()).Create(ctxChild, b, metav1.CreateOptions{})
+		return err
+	})
+	if err != nil {
+		if k8sutil.IsAlreadyExists(err) {
+			return "", errors.Wrapf(server.BadRequestError, "backup %s already exists", name)
+		}
+		return "", errors.WithStack(err)
+	}
+
+	return created.GetName(), nil
+}
+
+// RestoreBackup restores the deployment from the ArangoBackup with given name.
+func (d *Deployment) RestoreBackup(ctx context.Context, name string) error {
+	backup, err := d.GetBackup(ctx, name)
+	if err != nil {
+		if k8sutil.IsNotFound(err) {
+			return errors.Wrapf(server.NotFoundError, "backup %s", name)
+		}
+		return errors.WithStack(err)
+	}
+
+	if backup.Spec.Deployment.Name != d.Name() {
+		return errors.Wrapf(server.BadRequestError, "backup %s does not belong to the deployment", name)
+	}
+
+	if backup.Status.State != backupApi.ArangoBackupStateReady || backup.Status.Backup == nil {
+		return errors.Wrapf(server.BadRequestError, "backup %s is not ready", name)
+	}
+
+	status, _ := d.GetStatus()
+
+	return d.modifySpec(ctx, func(spec *api.DeploymentSpec) error {
+		if spec.HasRestoreFrom() {
+			if status.Restore == nil || status.Restore.RequestedFrom != spec.GetRestoreFrom() || status.Restore.State == api.DeploymentRestoreStateRestoring {
+				return errors.Wrapf(server.BadRequestError, "restore from %s is in progress", spec.GetRestoreFrom())
+			}
+			if spec.GetRestoreFrom() == name {
+				return errors.Wrapf(server.BadRequestError, "restore from %s is already done", name)
+			}
+		}
+		spec.RestoreFrom = &name
+		return nil
+	})
+}
+
+// modifySpec applies the modifier on the most recent spec of the deployment and saves it.
+// Spec is validated before update, so invalid changes are rejected instead of being reverted by the operator.
+func (d *Deployment) modifySpec(ctx context.Context, modifier func(spec *api.DeploymentSpec) error) error {
+	c := d.deps.DatabaseCRCli.DatabaseV1().ArangoDeployments(d.Namespace())
+
+	for attempt := 1; ; attempt++ {
+		var current *api.ArangoDeployment
+		err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+			var err error
+			current, err = c.Get(ctxChild, d.Name(), metav1.GetOptions{})
+			return err
+		})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		update := current.DeepCopy()
+		if err := modifier(&update.Spec); err != nil {
+			return err
+		}
+
+		specBefore := d.GetSpec()
+		check := update.Spec.DeepCopy()
+		check.SetDefaultsFrom(specBefore)
+		check.SetDefaults(d.Name())
+
+		if fields := specBefore.ResetImmutableFields(check); len(fields) > 0 {
+			return errors.Wrapf(server.BadRequestError, "immutable fields cannot be changed: %v", fields)
+		}
+		if err := check.Validate(); err != nil {
+			return errors.Wrapf(server.BadRequestError, "validation failed: %s", err.Error())
+		}
+
+		err = k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+			_, err := c.Update(ctxChild, update, metav1.UpdateOptions{})
+			return err
+		})
+		if err == nil {
+			return nil
+		}
+
+		if attempt < maxSpecUpdateAttempts && k8sutil.IsConflict(err) {
+			continue
+		}
+
+		return errors.WithStack(err)
+	}
+}
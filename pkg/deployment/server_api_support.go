@@ -0,0 +1,71 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package deployment
+
+import (
+	"context"
+	"io"
+	"os"
+
+	driver "github.com/arangodb/go-driver"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/support"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+)
+
+// SupportBundle writes the support bundle (tar.gz) of the deployment into the writer.
+func (d *Deployment) SupportBundle(ctx context.Context, out io.Writer, logLines int64) error {
+	c := support.Collector{
+		KubeCli:           d.deps.KubeCli,
+		CRCli:             d.deps.DatabaseCRCli,
+		LogLines:          logLines,
+		OperatorPod:       os.Getenv(constants.EnvOperatorPodName),
+		OperatorNamespace: os.Getenv(constants.EnvOperatorPodNamespace),
+	}
+
+	if d.Mode() == api.DeploymentModeCluster {
+		c.Agency = d.GetAgencyData
+		c.Health = d.getClusterHealth
+	}
+
+	return c.Collect(ctx, d.GetNamespace(), d.GetName(), out)
+}
+
+// getClusterHealth fetches the current health of the cluster.
+func (d *Deployment) getClusterHealth(ctx context.Context) (driver.ClusterHealth, error) {
+	client, err := d.GetDatabaseClient(ctx)
+	if err != nil {
+		return driver.ClusterHealth{}, errors.WithStack(err)
+	}
+
+	cluster, err := client.Cluster(ctx)
+	if err != nil {
+		return driver.ClusterHealth{}, errors.WithStack(err)
+	}
+
+	health, err := cluster.Health(ctx)
+	if err != nil {
+		return driver.ClusterHealth{}, errors.WithStack(err)
+	}
+	return health, nil
+}
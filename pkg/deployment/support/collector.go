@@ -0,0 +1,357 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package support
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	driver "github.com/arangodb/go-driver"
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/agency"
+	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+
+	"github.com/ghodss/yaml"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultLogLines is the default number of the last lines of logs collected per container
+	DefaultLogLines = 1000
+
+	errorsFile = "errors.txt"
+)
+
+// Collector collects diagnostics of a deployment into a support bundle (tar.gz).
+// Secrets are never read, sensitive values in specs and logs are redacted.
+type Collector struct {
+	KubeCli kubernetes.Interface
+	CRCli   versioned.Interface
+
+	// Agency reads data from the agency of the deployment. Agency Plan and Current are not collected when nil.
+	Agency agency.Fetcher
+	// Health returns the health of the cluster. Health is not collected when nil.
+	Health func(ctx context.Context) (driver.ClusterHealth, error)
+
+	// LogLines is the number of the last lines of logs collected per container
+	LogLines int64
+
+	// OperatorPod and OperatorNamespace of the operator, its logs are collected when set.
+	OperatorPod       string
+	OperatorNamespace string
+}
+
+// Collect writes the support bundle of the deployment with given name into the writer.
+// Errors while collecting parts of the bundle are recorded in the bundle, only errors of the deployment itself
+// and of the writer are returned.
+func (c Collector) Collect(ctx context.Context, namespace, name string, out io.Writer) error {
+	depl, err := c.CRCli.DatabaseV1().ArangoDeployments(namespace).Get(ctx, name, meta.GetOptions{})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	b := newBundle(out, name)
+
+	c.collectDeployment(b, depl)
+	c.collectMembers(ctx, b, depl)
+	pods := c.collectPods(ctx, b, depl)
+	c.collectEvents(ctx, b, depl, pods)
+	c.collectAgency(ctx, b)
+	c.collectHealth(ctx, b)
+	c.collectLogs(ctx, b, pods)
+	c.collectOperatorLogs(ctx, b)
+
+	return b.Close()
+}
+
+func (c Collector) collectDeployment(b *bundle, depl *api.ArangoDeployment) {
+	depl = depl.DeepCopy()
+	redactDeployment(depl)
+	b.addYAML("arangodeployment.yaml", depl)
+
+	b.addYAML("plans.yaml", map[string]interface{}{
+		"highPriorityPlan": depl.Status.HighPriorityPlan,
+		"plan":             depl.Status.Plan,
+	})
+
+	members := map[string]api.ConditionList{}
+	depl.Status.Members.ForeachServerGroup(func(group api.ServerGroup, list api.MemberStatusList) error {
+		for _, m := range list {
+			members[fmt.Sprintf("%s/%s", group.AsRole(), m.ID)] = m.Conditions
+		}
+		return nil
+	})
+	b.addYAML("conditions.yaml", map[string]interface{}{
+		"deployment": depl.Status.Conditions,
+		"members":    members,
+	})
+}
+
+func (c Collector) collectMembers(ctx context.Context, b *bundle, depl *api.ArangoDeployment) {
+	list, err := c.CRCli.DatabaseV1().ArangoMembers(depl.GetNamespace()).List(ctx, meta.ListOptions{})
+	if err != nil {
+		b.addError("arangomembers", err)
+		return
+	}
+
+	members := make([]api.ArangoMember, 0, len(list.Items))
+	for _, m := range list.Items {
+		if !isOwnedBy(m.GetOwnerReferences(), depl) {
+			continue
+		}
+		redactMember(&m)
+		members = append(members, m)
+	}
+	b.addYAML("arangomembers.yaml", members)
+}
+
+func (c Collector) collectPods(ctx context.Context, b *bundle, depl *api.ArangoDeployment) []core.Pod {
+	list, err := c.KubeCli.CoreV1().Pods(depl.GetNamespace()).List(ctx, meta.ListOptions{
+		LabelSelector: labels.SelectorFromSet(k8sutil.LabelsForDeployment(depl.GetName(), "")).String(),
+	})
+	if err != nil {
+		b.addError("pods", err)
+		return nil
+	}
+
+	for _, p := range list.Items {
+		p := p.DeepCopy()
+		redactPod(p)
+		b.addYAML(path.Join("pods", p.GetName()+".yaml"), p)
+	}
+	return list.Items
+}
+
+func (c Collector) collectEvents(ctx context.Context, b *bundle, depl *api.ArangoDeployment, pods []core.Pod) {
+	list, err := c.KubeCli.CoreV1().Events(depl.GetNamespace()).List(ctx, meta.ListOptions{})
+	if err != nil {
+		b.addError("events", err)
+		return
+	}
+
+	names := map[string]struct{}{
+		depl.GetName(): {},
+	}
+	for _, p := range pods {
+		names[p.GetName()] = struct{}{}
+	}
+
+	events := make([]core.Event, 0)
+	for _, e := range list.Items {
+		_, found := names[e.InvolvedObject.Name]
+		if !found && !strings.HasPrefix(e.InvolvedObject.Name, depl.GetName()+"-") {
+			continue
+		}
+		events = append(events, e)
+	}
+	b.addYAML("events.yaml", events)
+}
+
+func (c Collector) collectAgency(ctx context.Context, b *bundle) {
+	if c.Agency == nil {
+		return
+	}
+
+	for file, key := range map[string]string{
+		"agency/plan.json":    agency.PlanKey,
+		"agency/current.json": agency.CurrentKey,
+	} {
+		var data interface{}
+		err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+			return c.Agency(ctxChild, &data, agency.ArangoKey, key)
+		})
+		if err != nil {
+			b.addError(file, err)
+			continue
+		}
+		b.addJSON(file, data)
+	}
+}
+
+func (c Collector) collectHealth(ctx context.Context, b *bundle) {
+	if c.Health == nil {
+		return
+	}
+
+	var health driver.ClusterHealth
+	err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+		var err error
+		health, err = c.Health(ctxChild)
+		return err
+	})
+	if err != nil {
+		b.addError("health", err)
+		return
+	}
+	b.addJSON("health.json", health)
+}
+
+func (c Collector) collectLogs(ctx context.Context, b *bundle, pods []core.Pod) {
+	for _, p := range pods {
+		for _, container := range p.Spec.Containers {
+			c.collectLog(ctx, b, path.Join("logs", p.GetName(), container.Name+".log"), p.GetNamespace(), p.GetName(), container.Name)
+		}
+	}
+}
+
+func (c Collector) collectOperatorLogs(ctx context.Context, b *bundle) {
+	if c.OperatorPod == "" || c.OperatorNamespace == "" {
+		return
+	}
+
+	c.collectLog(ctx, b, path.Join("operator", c.OperatorPod+".log"), c.OperatorNamespace, c.OperatorPod, "")
+}
+
+func (c Collector) collectLog(ctx context.Context, b *bundle, file, namespace, pod, container string) {
+	lines := c.LogLines
+	if lines <= 0 {
+		lines = DefaultLogLines
+	}
+
+	stream, err := c.KubeCli.CoreV1().Pods(namespace).GetLogs(pod, &core.PodLogOptions{
+		Container: container,
+		TailLines: &lines,
+	}).Stream(ctx)
+	if err != nil {
+		b.addError(file, err)
+		return
+	}
+	defer stream.Close()
+
+	var buffer bytes.Buffer
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		buffer.WriteString(redactLog(scanner.Text()))
+		buffer.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		b.addError(file, err)
+	}
+	b.add(file, buffer.Bytes())
+}
+
+// isOwnedBy returns true if one of the owner references points to the deployment.
+func isOwnedBy(refs []meta.OwnerReference, depl *api.ArangoDeployment) bool {
+	for _, r := range refs {
+		if r.Kind == deployment.ArangoDeploymentResourceKind && r.Name == depl.GetName() {
+			return true
+		}
+	}
+	return false
+}
+
+// bundle writes files into tar.gz archive.
+type bundle struct {
+	prefix string
+	gzip   *gzip.Writer
+	tar    *tar.Writer
+	err    error
+	errors []string
+	now    time.Time
+}
+
+func newBundle(out io.Writer, prefix string) *bundle {
+	gz := gzip.NewWriter(out)
+	return &bundle{
+		prefix: prefix,
+		gzip:   gz,
+		tar:    tar.NewWriter(gz),
+		now:    time.Now(),
+	}
+}
+
+// add writes the file into the archive. Write errors are returned from Close.
+func (b *bundle) add(file string, data []byte) {
+	if b.err != nil {
+		return
+	}
+
+	if err := b.tar.WriteHeader(&tar.Header{
+		Name:    path.Join(b.prefix, file),
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: b.now,
+	}); err != nil {
+		b.err = errors.WithStack(err)
+		return
+	}
+
+	if _, err := b.tar.Write(data); err != nil {
+		b.err = errors.WithStack(err)
+	}
+}
+
+func (b *bundle) addYAML(file string, obj interface{}) {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		b.addError(file, err)
+		return
+	}
+	b.add(file, data)
+}
+
+func (b *bundle) addJSON(file string, obj interface{}) {
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		b.addError(file, err)
+		return
+	}
+	b.add(file, data)
+}
+
+// addError records error of collecting the given part of the bundle.
+func (b *bundle) addError(part string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %s", part, err.Error()))
+}
+
+// Close writes recorded errors and closes the archive.
+func (b *bundle) Close() error {
+	if len(b.errors) > 0 {
+		b.add(errorsFile, []byte(strings.Join(b.errors, "\n")+"\n"))
+	}
+
+	if b.err != nil {
+		return b.err
+	}
+	if err := b.tar.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := b.gzip.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
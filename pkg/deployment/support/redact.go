@@ -0,0 +1,159 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package support
+
+import (
+	"regexp"
+	"strings"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+
+	core "k8s.io/api/core/v1"
+)
+
+const (
+	redacted = "<redacted>"
+
+	lastAppliedConfigurationAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+var (
+	sensitiveName = regexp.MustCompile(`(?i)(password|passwd|secret|token|jwt|credential|key)`)
+
+	sensitiveLogPatterns = []struct {
+		pattern     *regexp.Regexp
+		replacement string
+	}{
+		{
+			pattern:     regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-_.=]+`),
+			replacement: "${1}" + redacted,
+		},
+		{
+			pattern:     regexp.MustCompile(`(?i)(basic\s+)[A-Za-z0-9+/=]+`),
+			replacement: "${1}" + redacted,
+		},
+		{
+			pattern:     regexp.MustCompile(`(?i)((password|passwd|secret|token)["']?\s*[:=]\s*["']?)[^"'\s,}&]+`),
+			replacement: "${1}" + redacted,
+		},
+	}
+)
+
+// isSensitive returns true if the name of an environment variable or argument suggests that it contains a secret.
+func isSensitive(name string) bool {
+	return sensitiveName.MatchString(name)
+}
+
+// redactLog replaces credentials found in the log line.
+func redactLog(line string) string {
+	for _, p := range sensitiveLogPatterns {
+		line = p.pattern.ReplaceAllString(line, p.replacement)
+	}
+	return line
+}
+
+// redactAnnotations removes annotations which can contain the full object, including sensitive data.
+func redactAnnotations(annotations map[string]string) {
+	delete(annotations, lastAppliedConfigurationAnnotation)
+}
+
+// redactArgs replaces the values of sensitive arguments in format --<name>=<value> or --<name> <value>.
+// Paths are not considered to be sensitive.
+func redactArgs(args []string) {
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) == 1 {
+			// Value is provided as the next argument
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") || strings.HasPrefix(args[i+1], "/") {
+				continue
+			}
+			if isSensitive(arg) {
+				args[i+1] = redacted
+			}
+			continue
+		}
+		if strings.HasPrefix(parts[1], "/") {
+			continue
+		}
+		if isSensitive(parts[0]) {
+			args[i] = parts[0] + "=" + redacted
+		}
+	}
+}
+
+// redactContainers replaces sensitive environment variables and arguments of the containers.
+func redactContainers(containers []core.Container) {
+	for i := range containers {
+		c := &containers[i]
+		for j := range c.Env {
+			if c.Env[j].Value != "" && isSensitive(c.Env[j].Name) {
+				c.Env[j].Value = redacted
+			}
+		}
+		redactArgs(c.Command)
+		redactArgs(c.Args)
+	}
+}
+
+// redactPodSpec replaces sensitive data in the pod spec.
+func redactPodSpec(spec *core.PodSpec) {
+	redactContainers(spec.InitContainers)
+	redactContainers(spec.Containers)
+}
+
+// redactPod replaces sensitive data in the pod.
+func redactPod(pod *core.Pod) {
+	redactAnnotations(pod.Annotations)
+	redactPodSpec(&pod.Spec)
+}
+
+// redactDeployment replaces sensitive data in the deployment.
+func redactDeployment(depl *api.ArangoDeployment) {
+	redactAnnotations(depl.Annotations)
+	for _, group := range api.AllServerGroups {
+		spec := depl.Spec.GetServerGroupSpec(group)
+		if len(spec.Envs) == 0 {
+			continue
+		}
+		envs := make(api.ServerGroupEnvVars, len(spec.Envs))
+		for i, e := range spec.Envs {
+			if e.Value != "" && isSensitive(e.Name) {
+				e.Value = redacted
+			}
+			envs[i] = e
+		}
+		spec.Envs = envs
+		depl.Spec.UpdateServerGroupSpec(group, spec)
+	}
+}
+
+// redactMember replaces sensitive data in the member.
+func redactMember(member *api.ArangoMember) {
+	redactAnnotations(member.Annotations)
+	for _, t := range []*api.ArangoMemberPodTemplate{member.Spec.Template, member.Status.Template} {
+		if t != nil && t.PodSpec != nil {
+			redactPodSpec(&t.PodSpec.Spec)
+		}
+	}
+}
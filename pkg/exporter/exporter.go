@@ -31,6 +31,12 @@ import (
 
 type Authentication func() (string, error)
 
+// HealthChecker reports health of the metrics source
+type HealthChecker interface {
+	// Health returns error if metrics source is not healthy
+	Health() error
+}
+
 // CreateArangodJwtAuthorizationHeader calculates a JWT authorization header, for authorization
 // of a request to an arangod server, based on the given secret.
 // If the secret is empty, nothing is done.
@@ -43,6 +49,18 @@ func NewExporter(endpoint string, url string, handler http.Handler) operatorHTTP
 
 	s.Handle(url, handler)
 
+	if h, ok := handler.(HealthChecker); ok {
+		s.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+			if err := h.Health(); err != nil {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(err.Error()))
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+
 	s.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`<html>
              <head><title>ArangoDB Exporter</title></head>
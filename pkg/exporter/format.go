@@ -0,0 +1,280 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package exporter
+
+import (
+	"bytes"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+const openMetricsEOF = "# EOF"
+
+// negotiateFormat returns the exposition format requested in the Accept header.
+// Upstream format is preferred when it is accepted by the client, so the ArangoDB response
+// can be passed without parsing. Prometheus text format is returned if nothing else is requested.
+func negotiateFormat(h http.Header, upstream expfmt.Format) expfmt.Format {
+	if acceptsFormat(h, upstream) {
+		return upstream
+	}
+
+	return expfmt.NegotiateIncludingOpenMetrics(h)
+}
+
+// upstreamFormat returns the exposition format of the ArangoDB response.
+// Prometheus text format is assumed if Content-Type is not OpenMetrics.
+func upstreamFormat(h http.Header) expfmt.Format {
+	if mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type")); err == nil && mediaType == expfmt.OpenMetricsType {
+		return expfmt.FmtOpenMetrics
+	}
+
+	return expfmt.FmtText
+}
+
+// appendMetrics appends metrics to the response in the given text based format
+func appendMetrics(response, metrics []byte, format expfmt.Format) []byte {
+	out := make([]byte, 0, len(response)+len(metrics)+1)
+
+	if format == expfmt.FmtOpenMetrics {
+		// Metrics need to be placed before # EOF
+		out = append(out, bytes.TrimSuffix(bytes.TrimRight(response, "\n"), []byte(openMetricsEOF))...)
+		out = append(out, metrics...)
+		return append(out, openMetricsEOF+"\n"...)
+	}
+
+	out = append(out, response...)
+	if len(out) > 0 && out[len(out)-1] != '\n' {
+		out = append(out, '\n')
+	}
+	return append(out, metrics...)
+}
+
+// acceptsFormat returns true if the given format is accepted by the client
+func acceptsFormat(h http.Header, format expfmt.Format) bool {
+	accept := h.Get("Accept")
+	if accept == "" {
+		return format == expfmt.FmtText
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		if q, ok := params["q"]; ok {
+			if v, err := strconv.ParseFloat(q, 64); err != nil || v <= 0 {
+				continue
+			}
+		}
+
+		version := params["version"]
+
+		switch mediaType {
+		case "*/*":
+			return true
+		case "text/plain":
+			if format == expfmt.FmtText && (version == "" || version == expfmt.TextVersion) {
+				return true
+			}
+		case expfmt.OpenMetricsType:
+			if format == expfmt.FmtOpenMetrics && (version == "" || version == expfmt.OpenMetricsVersion) {
+				return true
+			}
+		case expfmt.ProtoType:
+			if format == expfmt.FmtProtoDelim && params["proto"] == expfmt.ProtoProtocol && params["encoding"] == "delimited" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// encodeFamilies renders metric families in the given exposition format
+func encodeFamilies(families []*dto.MetricFamily, format expfmt.Format) ([]byte, error) {
+	out := bytes.NewBuffer(nil)
+
+	enc := expfmt.NewEncoder(out, format)
+
+	for _, f := range families {
+		if err := enc.Encode(f); err != nil {
+			return nil, errors.Wrapf(err, "Unable to render metric %s", f.GetName())
+		}
+	}
+
+	// OpenMetrics requires # EOF at the end of the exposition
+	if c, ok := enc.(expfmt.Closer); ok {
+		if err := c.Close(); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+// openMetricsToText converts the ArangoDB response in the OpenMetrics text format to the Prometheus text format,
+// so it can be parsed by expfmt.TextParser:
+//   - # EOF and # UNIT lines are removed
+//   - exemplars are removed and timestamps are converted from seconds to milliseconds
+//   - counter families are renamed to the names of their _total samples and _created samples are removed
+//   - types which are not known in the Prometheus text format are reported as untyped
+func openMetricsToText(data []byte) ([]byte, error) {
+	lines := strings.Split(string(data), "\n")
+
+	types := map[string]string{}
+	samples := map[string]bool{}
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			if fields := strings.Fields(line); len(fields) == 4 && fields[1] == "TYPE" {
+				types[fields[2]] = fields[3]
+			}
+			continue
+		}
+
+		if name, _, _, err := splitSample(line); err == nil && name != "" {
+			samples[name] = true
+		}
+	}
+
+	// familyName returns the name of the family in the Prometheus text format
+	familyName := func(name string) string {
+		if types[name] == "counter" && !samples[name] && samples[name+"_total"] {
+			return name + "_total"
+		}
+		return name
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+
+	for _, line := range lines {
+		if line == "" || line == openMetricsEOF {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			fields := strings.SplitN(line, " ", 4)
+			if len(fields) < 3 {
+				continue
+			}
+
+			switch fields[1] {
+			case "HELP":
+				fields[2] = familyName(fields[2])
+				if len(fields) == 4 {
+					// Quotes are not escaped in the Prometheus text format
+					fields[3] = strings.ReplaceAll(fields[3], `\"`, `"`)
+				}
+			case "TYPE":
+				fields[2] = familyName(fields[2])
+				if len(fields) == 4 {
+					switch fields[3] {
+					case "counter", "gauge", "histogram", "summary":
+					default:
+						fields[3] = "untyped"
+					}
+				}
+			default:
+				continue
+			}
+
+			out.WriteString(strings.Join(fields, " "))
+			out.WriteByte('\n')
+			continue
+		}
+
+		name, labels, rest, err := splitSample(line)
+		if err != nil {
+			return nil, err
+		}
+
+		if base := strings.TrimSuffix(name, "_created"); base != name && !samples[base] {
+			switch types[base] {
+			case "counter", "histogram", "summary":
+				continue
+			}
+		}
+
+		// Exemplar follows the value and the timestamp
+		if i := strings.Index(rest, "#"); i >= 0 {
+			rest = rest[:i]
+		}
+
+		fields := strings.Fields(rest)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, errors.Newf("Invalid OpenMetrics sample: %s", line)
+		}
+
+		out.WriteString(name)
+		out.WriteString(labels)
+		out.WriteByte(' ')
+		out.WriteString(fields[0])
+
+		if len(fields) == 2 {
+			ts, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Invalid OpenMetrics timestamp: %s", line)
+			}
+			out.WriteByte(' ')
+			out.WriteString(strconv.FormatInt(int64(math.Round(ts*1000)), 10))
+		}
+
+		out.WriteByte('\n')
+	}
+
+	return out.Bytes(), nil
+}
+
+// splitSample splits the OpenMetrics sample line into the metric name, labels (with braces) and the rest of the line
+func splitSample(line string) (string, string, string, error) {
+	end := strings.IndexAny(line, "{ ")
+	if end < 0 {
+		return "", "", "", errors.Newf("Invalid OpenMetrics sample: %s", line)
+	}
+
+	if line[end] == ' ' {
+		return line[:end], "", line[end:], nil
+	}
+
+	quoted, escaped := false, false
+	for i := end + 1; i < len(line); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case line[i] == '\\':
+			escaped = quoted
+		case line[i] == '"':
+			quoted = !quoted
+		case line[i] == '}' && !quoted:
+			return line[:end], line[end : i+1], line[i+1:], nil
+		}
+	}
+
+	return "", "", "", errors.Newf("Invalid OpenMetrics labels: %s", line)
+}
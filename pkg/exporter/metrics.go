@@ -0,0 +1,123 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package exporter
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+const selfMetricsPrefix = "arangodb_exporter_"
+
+// selfMetrics keeps metrics of the exporter itself
+type selfMetrics struct {
+	registry *prometheus.Registry
+
+	scrapeDuration  prometheus.Histogram
+	upstreamErrors  prometheus.Counter
+	responseSize    prometheus.Gauge
+	lastSuccess     prometheus.Gauge
+	cacheHits       prometheus.Counter
+	staleResponses  prometheus.Counter
+	upstreamHealthy prometheus.Gauge
+}
+
+func newSelfMetrics() *selfMetrics {
+	m := &selfMetrics{
+		registry: prometheus.NewRegistry(),
+		scrapeDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    selfMetricsPrefix + "scrape_duration_seconds",
+			Help:    "Duration of the ArangoDB metrics requests",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15, 30},
+		}),
+		upstreamErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: selfMetricsPrefix + "upstream_errors_total",
+			Help: "Number of failed ArangoDB metrics requests",
+		}),
+		responseSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: selfMetricsPrefix + "response_size_bytes",
+			Help: "Size of the last successful ArangoDB metrics response",
+		}),
+		lastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: selfMetricsPrefix + "last_success_timestamp_seconds",
+			Help: "Timestamp of the last successful ArangoDB metrics request",
+		}),
+		cacheHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: selfMetricsPrefix + "cache_hits_total",
+			Help: "Number of scrapes served from the cache",
+		}),
+		staleResponses: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: selfMetricsPrefix + "stale_responses_total",
+			Help: "Number of scrapes served with stale data because ArangoDB was not available",
+		}),
+		upstreamHealthy: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: selfMetricsPrefix + "upstream_up",
+			Help: "1 if the last ArangoDB metrics request succeeded, 0 otherwise",
+		}),
+	}
+
+	m.registry.MustRegister(m.scrapeDuration, m.upstreamErrors, m.responseSize, m.lastSuccess,
+		m.cacheHits, m.staleResponses, m.upstreamHealthy)
+
+	return m
+}
+
+func (m *selfMetrics) observeSuccess(start time.Time, size int) {
+	m.scrapeDuration.Observe(time.Since(start).Seconds())
+	m.responseSize.Set(float64(size))
+	m.lastSuccess.Set(float64(time.Now().Unix()))
+	m.upstreamHealthy.Set(1)
+}
+
+func (m *selfMetrics) observeFailure(start time.Time) {
+	m.scrapeDuration.Observe(time.Since(start).Seconds())
+	m.upstreamErrors.Inc()
+	m.upstreamHealthy.Set(0)
+}
+
+// render returns exporter metrics in the Prometheus text format, or in the OpenMetrics format (without # EOF)
+func (m *selfMetrics) render(format expfmt.Format) ([]byte, error) {
+	families, err := m.registry.Gather()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	out := bytes.NewBuffer(nil)
+
+	for _, f := range families {
+		if format == expfmt.FmtOpenMetrics {
+			if _, err := expfmt.MetricFamilyToOpenMetrics(out, f); err != nil {
+				return nil, errors.WithStack(err)
+			}
+			continue
+		}
+
+		if _, err := expfmt.MetricFamilyToText(out, f); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return out.Bytes(), nil
+}
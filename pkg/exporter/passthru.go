@@ -24,19 +24,36 @@ package exporter
 
 import (
 	"crypto/tls"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/prometheus/common/expfmt"
 )
 
 var _ http.Handler = &passthru{}
+var _ HealthChecker = &passthru{}
 
-func NewPassthru(arangodbEndpoint string, auth Authentication, sslVerify bool, timeout time.Duration) (http.Handler, error) {
+// CacheConfig defines caching of the ArangoDB metrics
+type CacheConfig struct {
+	// TTL defines how long metrics are served from the cache. Cache is disabled if 0
+	TTL time.Duration
+	// StaleTTL defines how long after TTL metrics are served from the cache when ArangoDB is not available
+	StaleTTL time.Duration
+	// MaxFailures defines number of consecutive failed ArangoDB requests after which exporter is not healthy
+	MaxFailures int
+}
+
+func NewPassthru(arangodbEndpoint string, auth Authentication, sslVerify bool, timeout time.Duration, relabel *Relabel, cache CacheConfig) (http.Handler, error) {
 	return &passthru{
 		factory: newHttpClientFactory(arangodbEndpoint, auth, sslVerify, timeout),
+		relabel: relabel,
+		cache:   cache,
+		metrics: newSelfMetrics(),
 	}, nil
 }
 
@@ -79,11 +96,29 @@ func newHttpClientFactory(arangodbEndpoint string, auth Authentication, sslVerif
 	}
 }
 
+// scrape keeps the successful ArangoDB metrics response
+type scrape struct {
+	data   []byte
+	format expfmt.Format
+	time   time.Time
+}
+
 type passthru struct {
 	factory httpClientFactory
+	relabel *Relabel
+	cache   CacheConfig
+	metrics *selfMetrics
+
+	// scrapeLock ensures that only one request to ArangoDB is made at a time
+	scrapeLock sync.Mutex
+	last       *scrape
+
+	lock     sync.Mutex
+	failures int
+	lastErr  error
 }
 
-func (p passthru) get() (*http.Response, error) {
+func (p *passthru) get() (*http.Response, error) {
 	c, req, err := p.factory()
 	if err != nil {
 		return nil, err
@@ -91,40 +126,179 @@ func (p passthru) get() (*http.Response, error) {
 	return c.Do(req)
 }
 
-func (p passthru) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
-	data, err := p.get()
+// Health returns error if ArangoDB metrics requests kept failing
+func (p *passthru) Health() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.cache.MaxFailures > 0 && p.failures >= p.cache.MaxFailures {
+		return errors.Newf("%d consecutive ArangoDB metrics requests failed, last error: %v", p.failures, p.lastErr)
+	}
+
+	return nil
+}
+
+func (p *passthru) setResult(err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err == nil {
+		p.failures = 0
+	} else {
+		p.failures++
+	}
+
+	p.lastErr = err
+}
+
+// upstreamError is returned when ArangoDB responds with non-OK status code
+type upstreamError struct {
+	code int
+	body []byte
+}
 
+func (u upstreamError) Error() string {
+	return fmt.Sprintf("ArangoDB responded with code %d", u.code)
+}
+
+// fetch requests metrics from ArangoDB
+func (p *passthru) fetch() ([]byte, expfmt.Format, error) {
+	data, err := p.get()
 	if err != nil {
-		// Ignore error
-		resp.WriteHeader(http.StatusInternalServerError)
-		resp.Write([]byte(err.Error()))
-		return
+		return nil, "", err
 	}
 
 	if data.Body == nil {
-		// Ignore error
-		resp.WriteHeader(http.StatusInternalServerError)
-		resp.Write([]byte("Body is empty"))
-		return
+		return nil, "", errors.Newf("Body is empty")
 	}
 
 	defer data.Body.Close()
 
 	response, err := ioutil.ReadAll(data.Body)
 	if err != nil {
+		return nil, "", err
+	}
+
+	if data.StatusCode != http.StatusOK {
+		return nil, "", upstreamError{code: data.StatusCode, body: response}
+	}
+
+	// Fix Header response
+	return []byte(strings.ReplaceAll(string(response), "guage", "gauge")), upstreamFormat(data.Header), nil
+}
+
+// scrape returns ArangoDB metrics, from the cache if they are fresh enough.
+// Stale flag is true if ArangoDB is not available and metrics were served from the cache.
+func (p *passthru) scrape() (*scrape, bool, error) {
+	p.scrapeLock.Lock()
+	defer p.scrapeLock.Unlock()
+
+	if p.last != nil && p.cache.TTL > 0 && time.Since(p.last.time) < p.cache.TTL {
+		p.metrics.cacheHits.Inc()
+		return p.last, false, nil
+	}
+
+	start := time.Now()
+
+	data, format, err := p.fetch()
+	if err != nil {
+		p.metrics.observeFailure(start)
+		p.setResult(err)
+
+		if p.last != nil && p.cache.StaleTTL > 0 && time.Since(p.last.time) < p.cache.TTL+p.cache.StaleTTL {
+			p.metrics.staleResponses.Inc()
+			return p.last, true, nil
+		}
+
+		return nil, false, err
+	}
+
+	p.metrics.observeSuccess(start, len(data))
+	p.setResult(nil)
+	p.last = &scrape{
+		data:   data,
+		format: format,
+		time:   time.Now(),
+	}
+
+	return p.last, false, nil
+}
+
+func (p *passthru) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	last, stale, err := p.scrape()
+	if err != nil {
+		if u, ok := err.(upstreamError); ok {
+			resp.WriteHeader(u.code)
+			resp.Write(u.body)
+			return
+		}
+
 		// Ignore error
 		resp.WriteHeader(http.StatusInternalServerError)
 		resp.Write([]byte(err.Error()))
 		return
 	}
 
-	responseStr := string(response)
+	format := negotiateFormat(req.Header, last.format)
 
-	// Fix Header response
-	responseStr = strings.ReplaceAll(responseStr, "guage", "gauge")
+	var response []byte
+
+	if format == last.format && p.relabel.IsEmpty() {
+		// ArangoDB response is passed without parsing, so exemplars are kept
+		self, err := p.metrics.render(format)
+		if err != nil {
+			// Ignore error
+			resp.WriteHeader(http.StatusInternalServerError)
+			resp.Write([]byte(err.Error()))
+			return
+		}
+
+		response = appendMetrics(last.data, self, format)
+	} else {
+		self, err := p.metrics.render(expfmt.FmtText)
+		if err != nil {
+			// Ignore error
+			resp.WriteHeader(http.StatusInternalServerError)
+			resp.Write([]byte(err.Error()))
+			return
+		}
+
+		data := last.data
+		if last.format == expfmt.FmtOpenMetrics {
+			// Prometheus text parser does not support OpenMetrics exemplars and # EOF
+			if data, err = openMetricsToText(data); err != nil {
+				// Ignore error
+				resp.WriteHeader(http.StatusInternalServerError)
+				resp.Write([]byte(err.Error()))
+				return
+			}
+		}
+
+		families, err := p.relabel.Families(appendMetrics(data, self, expfmt.FmtText))
+		if err != nil {
+			// Ignore error
+			resp.WriteHeader(http.StatusInternalServerError)
+			resp.Write([]byte(err.Error()))
+			return
+		}
+
+		response, err = encodeFamilies(families, format)
+		if err != nil {
+			// Ignore error
+			resp.WriteHeader(http.StatusInternalServerError)
+			resp.Write([]byte(err.Error()))
+			return
+		}
+	}
+
+	resp.Header().Set("Content-Type", string(format))
+
+	if stale {
+		resp.Header().Set("X-ArangoDB-Exporter-Stale", "true")
+	}
 
-	resp.WriteHeader(data.StatusCode)
-	_, err = resp.Write([]byte(responseStr))
+	resp.WriteHeader(http.StatusOK)
+	_, err = resp.Write(response)
 	if err != nil {
 		// Ignore error
 		resp.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,264 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package exporter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/require"
+)
+
+type testUpstream struct {
+	requests int
+	failing  bool
+
+	contentType string
+	body        string
+}
+
+func (t *testUpstream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t.requests++
+
+	if t.failing {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	if t.contentType != "" {
+		w.Header().Set("Content-Type", t.contentType)
+	}
+
+	if t.body != "" {
+		w.Write([]byte(t.body))
+		return
+	}
+
+	w.Write([]byte("# HELP arangodb_test Test\n# TYPE arangodb_test guage\narangodb_test 1\n"))
+}
+
+func testPassthru(t *testing.T, upstream *testUpstream, cache CacheConfig) *passthru {
+	server := httptest.NewServer(upstream)
+	t.Cleanup(server.Close)
+
+	h, err := NewPassthru(server.URL, func() (string, error) {
+		return "", nil
+	}, false, time.Second, nil, cache)
+	require.NoError(t, err)
+
+	return h.(*passthru)
+}
+
+func testScrape(p *passthru, accept ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	for _, a := range accept {
+		req.Header.Add("Accept", a)
+	}
+	p.ServeHTTP(r, req)
+	return r
+}
+
+func Test_Passthru_SelfMetrics(t *testing.T) {
+	p := testPassthru(t, &testUpstream{}, CacheConfig{})
+
+	r := testScrape(p)
+	require.Equal(t, http.StatusOK, r.Code)
+	require.Contains(t, r.Body.String(), "# TYPE arangodb_test gauge")
+	require.Contains(t, r.Body.String(), "arangodb_exporter_upstream_up 1")
+	require.Contains(t, r.Body.String(), "arangodb_exporter_scrape_duration_seconds_count 1")
+}
+
+func Test_Passthru_Cache(t *testing.T) {
+	upstream := &testUpstream{}
+	p := testPassthru(t, upstream, CacheConfig{TTL: time.Hour})
+
+	require.Equal(t, http.StatusOK, testScrape(p).Code)
+	require.Equal(t, http.StatusOK, testScrape(p).Code)
+	require.Equal(t, 1, upstream.requests)
+}
+
+func Test_Passthru_Stale(t *testing.T) {
+	upstream := &testUpstream{}
+	p := testPassthru(t, upstream, CacheConfig{StaleTTL: time.Hour, MaxFailures: 2})
+
+	require.Equal(t, http.StatusOK, testScrape(p).Code)
+
+	upstream.failing = true
+
+	r := testScrape(p)
+	require.Equal(t, http.StatusOK, r.Code)
+	require.Equal(t, "true", r.Header().Get("X-ArangoDB-Exporter-Stale"))
+	require.Contains(t, r.Body.String(), "arangodb_exporter_upstream_errors_total 1")
+	require.NoError(t, p.Health())
+
+	require.Equal(t, http.StatusOK, testScrape(p).Code)
+	require.Error(t, p.Health())
+
+	upstream.failing = false
+
+	require.Equal(t, http.StatusOK, testScrape(p).Code)
+	require.NoError(t, p.Health())
+}
+
+func Test_Passthru_Failure(t *testing.T) {
+	p := testPassthru(t, &testUpstream{failing: true}, CacheConfig{StaleTTL: time.Hour, MaxFailures: 1})
+
+	require.Equal(t, http.StatusServiceUnavailable, testScrape(p).Code)
+	require.Error(t, p.Health())
+}
+
+func Test_Passthru_OpenMetrics(t *testing.T) {
+	p := testPassthru(t, &testUpstream{}, CacheConfig{})
+
+	r := testScrape(p, "application/openmetrics-text; version=0.0.1")
+	require.Equal(t, http.StatusOK, r.Code)
+	require.Equal(t, string(expfmt.FmtOpenMetrics), r.Header().Get("Content-Type"))
+	require.Contains(t, r.Body.String(), "arangodb_test 1")
+	require.Contains(t, r.Body.String(), "# TYPE arangodb_exporter_upstream_errors counter")
+	require.True(t, strings.HasSuffix(r.Body.String(), "# EOF\n"))
+}
+
+func Test_Passthru_Protobuf(t *testing.T) {
+	p := testPassthru(t, &testUpstream{}, CacheConfig{})
+
+	r := testScrape(p, "application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited")
+	require.Equal(t, http.StatusOK, r.Code)
+	require.Equal(t, string(expfmt.FmtProtoDelim), r.Header().Get("Content-Type"))
+
+	dec := expfmt.NewDecoder(r.Body, expfmt.FmtProtoDelim)
+
+	families := map[string]*dto.MetricFamily{}
+	for {
+		var f dto.MetricFamily
+		if err := dec.Decode(&f); err == io.EOF {
+			break
+		} else {
+			require.NoError(t, err)
+		}
+		families[f.GetName()] = &f
+	}
+
+	require.Contains(t, families, "arangodb_test")
+	require.Equal(t, dto.MetricType_GAUGE, families["arangodb_test"].GetType())
+	require.Equal(t, float64(1), families["arangodb_test"].Metric[0].GetGauge().GetValue())
+}
+
+func Test_Passthru_PrometheusAccept(t *testing.T) {
+	p := testPassthru(t, &testUpstream{contentType: "text/plain; version=0.0.4"}, CacheConfig{})
+
+	r := testScrape(p, "application/openmetrics-text;version=0.0.1,text/plain;version=0.0.4;q=0.5,*/*;q=0.1")
+	require.Equal(t, http.StatusOK, r.Code)
+	require.Equal(t, string(expfmt.FmtText), r.Header().Get("Content-Type"))
+	require.True(t, strings.HasPrefix(r.Body.String(), "# HELP arangodb_test Test\n# TYPE arangodb_test gauge\narangodb_test 1\n"))
+	require.Contains(t, r.Body.String(), "arangodb_exporter_upstream_up 1")
+}
+
+func Test_Passthru_OpenMetrics_Exemplars(t *testing.T) {
+	upstream := &testUpstream{
+		contentType: "application/openmetrics-text; version=0.0.1; charset=utf-8",
+		body: "# HELP arangodb_test Test\n# TYPE arangodb_test counter\n" +
+			"arangodb_test_total 1 # {trace_id=\"abc\"} 1.0 1600000000.000\n# EOF\n",
+	}
+	p := testPassthru(t, upstream, CacheConfig{})
+
+	r := testScrape(p, "application/openmetrics-text; version=0.0.1")
+	require.Equal(t, http.StatusOK, r.Code)
+	require.Equal(t, string(expfmt.FmtOpenMetrics), r.Header().Get("Content-Type"))
+
+	body := r.Body.String()
+	require.Contains(t, body, "arangodb_test_total 1 # {trace_id=\"abc\"} 1.0 1600000000.000\n")
+	require.Contains(t, body, "# TYPE arangodb_exporter_upstream_errors counter")
+	require.True(t, strings.HasSuffix(body, "# EOF\n"))
+	require.Equal(t, 1, strings.Count(body, "# EOF"))
+}
+
+const testOpenMetricsUpstream = "# HELP arangodb_test Test \\\"quoted\\\"\n# TYPE arangodb_test counter\n# UNIT arangodb_test seconds\n" +
+	"arangodb_test_total{role=\"dbserver\",path=\"/a # {b}\"} 2 1600000000.500 # {trace_id=\"abc\"} 1.0 1600000000.000\n" +
+	"arangodb_test_created{role=\"dbserver\",path=\"/a # {b}\"} 1600000000\n" +
+	"# TYPE arangodb_info info\narangodb_info_info{version=\"3.8\"} 1\n# EOF\n"
+
+func Test_Passthru_OpenMetrics_Protobuf(t *testing.T) {
+	upstream := &testUpstream{
+		contentType: "application/openmetrics-text; version=0.0.1; charset=utf-8",
+		body:        testOpenMetricsUpstream,
+	}
+	p := testPassthru(t, upstream, CacheConfig{})
+
+	r := testScrape(p, "application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited")
+	require.Equal(t, http.StatusOK, r.Code, r.Body.String())
+	require.Equal(t, string(expfmt.FmtProtoDelim), r.Header().Get("Content-Type"))
+
+	dec := expfmt.NewDecoder(r.Body, expfmt.FmtProtoDelim)
+
+	families := map[string]*dto.MetricFamily{}
+	for {
+		var f dto.MetricFamily
+		if err := dec.Decode(&f); err == io.EOF {
+			break
+		} else {
+			require.NoError(t, err)
+		}
+		families[f.GetName()] = &f
+	}
+
+	require.Contains(t, families, "arangodb_test_total")
+	require.NotContains(t, families, "arangodb_test_created")
+	require.Contains(t, families, "arangodb_info_info")
+	require.Contains(t, families, "arangodb_exporter_upstream_up")
+
+	f := families["arangodb_test_total"]
+	require.Equal(t, dto.MetricType_COUNTER, f.GetType())
+	require.Equal(t, `Test "quoted"`, f.GetHelp())
+	require.Len(t, f.Metric, 1)
+	require.Equal(t, float64(2), f.Metric[0].GetCounter().GetValue())
+	require.Equal(t, int64(1600000000500), f.Metric[0].GetTimestampMs())
+
+	labels := map[string]string{}
+	for _, l := range f.Metric[0].GetLabel() {
+		labels[l.GetName()] = l.GetValue()
+	}
+	require.Equal(t, "/a # {b}", labels["path"])
+}
+
+func Test_Passthru_OpenMetrics_PrometheusText(t *testing.T) {
+	upstream := &testUpstream{
+		contentType: "application/openmetrics-text; version=0.0.1; charset=utf-8",
+		body:        testOpenMetricsUpstream,
+	}
+	p := testPassthru(t, upstream, CacheConfig{})
+
+	r := testScrape(p, "text/plain; version=0.0.4")
+	require.Equal(t, http.StatusOK, r.Code, r.Body.String())
+	require.Equal(t, string(expfmt.FmtText), r.Header().Get("Content-Type"))
+
+	body := r.Body.String()
+	require.Contains(t, body, "# TYPE arangodb_test_total counter\n")
+	require.Contains(t, body, "arangodb_test_total{role=\"dbserver\",path=\"/a # {b}\"} 2 1600000000500\n")
+	require.NotContains(t, body, "trace_id")
+	require.NotContains(t, body, "# EOF")
+}
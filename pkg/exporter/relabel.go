@@ -0,0 +1,204 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/golang/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+)
+
+// Relabel defines modifications applied to the metrics returned by ArangoDB
+type Relabel struct {
+	labels []*dto.LabelPair
+	allow  []*regexp.Regexp
+	deny   []*regexp.Regexp
+}
+
+// NewRelabel creates Relabel which injects labels into every sample and filters metric families by name.
+// Allow and deny are regular expressions which need to match the whole metric name.
+func NewRelabel(labels map[string]string, allow, deny []string) (*Relabel, error) {
+	r := &Relabel{}
+
+	for k, v := range labels {
+		if !model.LabelName(k).IsValid() {
+			return nil, errors.Newf("Invalid label name %s", k)
+		}
+
+		r.labels = append(r.labels, &dto.LabelPair{
+			Name:  proto.String(k),
+			Value: proto.String(v),
+		})
+	}
+
+	sort.Slice(r.labels, func(i, j int) bool {
+		return r.labels[i].GetName() < r.labels[j].GetName()
+	})
+
+	var err error
+
+	if r.allow, err = compileMetricNameExpressions(allow); err != nil {
+		return nil, err
+	}
+
+	if r.deny, err = compileMetricNameExpressions(deny); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ParseLabels parses labels in key=value format
+func ParseLabels(labels []string) (map[string]string, error) {
+	r := make(map[string]string, len(labels))
+
+	for _, l := range labels {
+		parts := strings.SplitN(l, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Newf("Invalid label %s, expected key=value", l)
+		}
+
+		r[parts[0]] = parts[1]
+	}
+
+	return r, nil
+}
+
+func compileMetricNameExpressions(expressions []string) ([]*regexp.Regexp, error) {
+	r := make([]*regexp.Regexp, 0, len(expressions))
+
+	for _, e := range expressions {
+		c, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", e))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid metric name expression %s", e)
+		}
+
+		r = append(r, c)
+	}
+
+	return r, nil
+}
+
+// IsEmpty returns true if metrics are not modified by Relabel
+func (r *Relabel) IsEmpty() bool {
+	return r == nil || (len(r.labels) == 0 && len(r.allow) == 0 && len(r.deny) == 0)
+}
+
+// Accept returns true if metric family with given name should be exposed
+func (r *Relabel) Accept(name string) bool {
+	if r == nil {
+		return true
+	}
+
+	for _, d := range r.deny {
+		if d.MatchString(name) {
+			return false
+		}
+	}
+
+	if len(r.allow) == 0 {
+		return true
+	}
+
+	for _, a := range r.allow {
+		if a.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply parses metrics in the Prometheus text format, filters metric families and injects labels.
+// Labels already present in the sample are not overridden.
+func (r *Relabel) Apply(data []byte) ([]byte, error) {
+	families, err := r.Families(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeFamilies(families, expfmt.FmtText)
+}
+
+// Families parses metrics in the Prometheus text format and returns filtered metric families, sorted by name,
+// with injected labels.
+func (r *Relabel) Families(data []byte) ([]*dto.MetricFamily, error) {
+	var parser expfmt.TextParser
+
+	parsed, err := parser.TextToMetricFamilies(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to parse metrics")
+	}
+
+	names := make([]string, 0, len(parsed))
+	for name := range parsed {
+		if r.Accept(name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	families := make([]*dto.MetricFamily, 0, len(names))
+
+	for _, name := range names {
+		family := parsed[name]
+
+		for _, m := range family.Metric {
+			m.Label = r.injectLabels(m.Label)
+		}
+
+		families = append(families, family)
+	}
+
+	return families, nil
+}
+
+func (r *Relabel) injectLabels(labels []*dto.LabelPair) []*dto.LabelPair {
+	if r == nil || len(r.labels) == 0 {
+		return labels
+	}
+
+	existing := make(map[string]bool, len(labels))
+	for _, l := range labels {
+		existing[l.GetName()] = true
+	}
+
+	for _, l := range r.labels {
+		if !existing[l.GetName()] {
+			labels = append(labels, l)
+		}
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].GetName() < labels[j].GetName()
+	})
+
+	return labels
+}
@@ -0,0 +1,85 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testMetrics = `# HELP arangodb_client_connections Number of client connections
+# TYPE arangodb_client_connections gauge
+arangodb_client_connections 5
+# HELP arangodb_http_requests_total Number of requests
+# TYPE arangodb_http_requests_total counter
+arangodb_http_requests_total{role="SINGLE"} 10
+# HELP rocksdb_cache_limit Cache limit
+# TYPE rocksdb_cache_limit gauge
+rocksdb_cache_limit 1024
+`
+
+func Test_Relabel_Labels(t *testing.T) {
+	r, err := NewRelabel(map[string]string{
+		"deployment": "test",
+		"role":       "dbserver",
+	}, nil, nil)
+	require.NoError(t, err)
+	require.False(t, r.IsEmpty())
+
+	out, err := r.Apply([]byte(testMetrics))
+	require.NoError(t, err)
+
+	require.Contains(t, string(out), `arangodb_client_connections{deployment="test",role="dbserver"} 5`)
+	// Existing labels are not overridden
+	require.Contains(t, string(out), `arangodb_http_requests_total{deployment="test",role="SINGLE"} 10`)
+}
+
+func Test_Relabel_Filters(t *testing.T) {
+	r, err := NewRelabel(nil, []string{"arangodb_.*"}, []string{"arangodb_http_.*"})
+	require.NoError(t, err)
+
+	out, err := r.Apply([]byte(testMetrics))
+	require.NoError(t, err)
+
+	require.Contains(t, string(out), "arangodb_client_connections 5")
+	require.NotContains(t, string(out), "arangodb_http_requests_total")
+	require.NotContains(t, string(out), "rocksdb_cache_limit")
+}
+
+func Test_Relabel_Invalid(t *testing.T) {
+	_, err := NewRelabel(map[string]string{"invalid-name": "value"}, nil, nil)
+	require.Error(t, err)
+
+	_, err = NewRelabel(nil, []string{"("}, nil)
+	require.Error(t, err)
+
+	_, err = ParseLabels([]string{"missing"})
+	require.Error(t, err)
+
+	labels, err := ParseLabels([]string{"deployment=test", "empty="})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"deployment": "test", "empty": ""}, labels)
+
+	var empty *Relabel
+	require.True(t, empty.IsEmpty())
+	require.True(t, empty.Accept("any"))
+}
@@ -0,0 +1,199 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	scheme "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ArangoVolumeSnapshotsGetter has a method to return a ArangoVolumeSnapshotInterface.
+// A group's client should implement this interface.
+type ArangoVolumeSnapshotsGetter interface {
+	ArangoVolumeSnapshots(namespace string) ArangoVolumeSnapshotInterface
+}
+
+// ArangoVolumeSnapshotInterface has methods to work with ArangoVolumeSnapshot resources.
+type ArangoVolumeSnapshotInterface interface {
+	Create(ctx context.Context, arangoVolumeSnapshot *v1.ArangoVolumeSnapshot, opts metav1.CreateOptions) (*v1.ArangoVolumeSnapshot, error)
+	Update(ctx context.Context, arangoVolumeSnapshot *v1.ArangoVolumeSnapshot, opts metav1.UpdateOptions) (*v1.ArangoVolumeSnapshot, error)
+	UpdateStatus(ctx context.Context, arangoVolumeSnapshot *v1.ArangoVolumeSnapshot, opts metav1.UpdateOptions) (*v1.ArangoVolumeSnapshot, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ArangoVolumeSnapshot, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ArangoVolumeSnapshotList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ArangoVolumeSnapshot, err error)
+	ArangoVolumeSnapshotExpansion
+}
+
+// arangoVolumeSnapshots implements ArangoVolumeSnapshotInterface
+type arangoVolumeSnapshots struct {
+	client rest.Interface
+	ns     string
+}
+
+// newArangoVolumeSnapshots returns a ArangoVolumeSnapshots
+func newArangoVolumeSnapshots(c *BackupV1Client, namespace string) *arangoVolumeSnapshots {
+	return &arangoVolumeSnapshots{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the arangoVolumeSnapshot, and returns the corresponding arangoVolumeSnapshot object, and an error if there is any.
+func (c *arangoVolumeSnapshots) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ArangoVolumeSnapshot, err error) {
+	result = &v1.ArangoVolumeSnapshot{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("arangovolumesnapshots").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ArangoVolumeSnapshots that match those selectors.
+func (c *arangoVolumeSnapshots) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ArangoVolumeSnapshotList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ArangoVolumeSnapshotList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("arangovolumesnapshots").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested arangoVolumeSnapshots.
+func (c *arangoVolumeSnapshots) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("arangovolumesnapshots").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a arangoVolumeSnapshot and creates it.  Returns the server's representation of the arangoVolumeSnapshot, and an error, if there is any.
+func (c *arangoVolumeSnapshots) Create(ctx context.Context, arangoVolumeSnapshot *v1.ArangoVolumeSnapshot, opts metav1.CreateOptions) (result *v1.ArangoVolumeSnapshot, err error) {
+	result = &v1.ArangoVolumeSnapshot{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("arangovolumesnapshots").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(arangoVolumeSnapshot).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a arangoVolumeSnapshot and updates it. Returns the server's representation of the arangoVolumeSnapshot, and an error, if there is any.
+func (c *arangoVolumeSnapshots) Update(ctx context.Context, arangoVolumeSnapshot *v1.ArangoVolumeSnapshot, opts metav1.UpdateOptions) (result *v1.ArangoVolumeSnapshot, err error) {
+	result = &v1.ArangoVolumeSnapshot{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("arangovolumesnapshots").
+		Name(arangoVolumeSnapshot.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(arangoVolumeSnapshot).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *arangoVolumeSnapshots) UpdateStatus(ctx context.Context, arangoVolumeSnapshot *v1.ArangoVolumeSnapshot, opts metav1.UpdateOptions) (result *v1.ArangoVolumeSnapshot, err error) {
+	result = &v1.ArangoVolumeSnapshot{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("arangovolumesnapshots").
+		Name(arangoVolumeSnapshot.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(arangoVolumeSnapshot).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the arangoVolumeSnapshot and deletes it. Returns an error if one occurs.
+func (c *arangoVolumeSnapshots) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("arangovolumesnapshots").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *arangoVolumeSnapshots) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("arangovolumesnapshots").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched arangoVolumeSnapshot.
+func (c *arangoVolumeSnapshots) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ArangoVolumeSnapshot, err error) {
+	result = &v1.ArangoVolumeSnapshot{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("arangovolumesnapshots").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,146 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	backupv1 "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeArangoVolumeSnapshots implements ArangoVolumeSnapshotInterface
+type FakeArangoVolumeSnapshots struct {
+	Fake *FakeBackupV1
+	ns   string
+}
+
+var arangovolumesnapshotsResource = schema.GroupVersionResource{Group: "backup.arangodb.com", Version: "v1", Resource: "arangovolumesnapshots"}
+
+var arangovolumesnapshotsKind = schema.GroupVersionKind{Group: "backup.arangodb.com", Version: "v1", Kind: "ArangoVolumeSnapshot"}
+
+// Get takes name of the arangoVolumeSnapshot, and returns the corresponding arangoVolumeSnapshot object, and an error if there is any.
+func (c *FakeArangoVolumeSnapshots) Get(ctx context.Context, name string, options v1.GetOptions) (result *backupv1.ArangoVolumeSnapshot, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(arangovolumesnapshotsResource, c.ns, name), &backupv1.ArangoVolumeSnapshot{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*backupv1.ArangoVolumeSnapshot), err
+}
+
+// List takes label and field selectors, and returns the list of ArangoVolumeSnapshots that match those selectors.
+func (c *FakeArangoVolumeSnapshots) List(ctx context.Context, opts v1.ListOptions) (result *backupv1.ArangoVolumeSnapshotList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(arangovolumesnapshotsResource, arangovolumesnapshotsKind, c.ns, opts), &backupv1.ArangoVolumeSnapshotList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &backupv1.ArangoVolumeSnapshotList{ListMeta: obj.(*backupv1.ArangoVolumeSnapshotList).ListMeta}
+	for _, item := range obj.(*backupv1.ArangoVolumeSnapshotList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested arangoVolumeSnapshots.
+func (c *FakeArangoVolumeSnapshots) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(arangovolumesnapshotsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a arangoVolumeSnapshot and creates it.  Returns the server's representation of the arangoVolumeSnapshot, and an error, if there is any.
+func (c *FakeArangoVolumeSnapshots) Create(ctx context.Context, arangoVolumeSnapshot *backupv1.ArangoVolumeSnapshot, opts v1.CreateOptions) (result *backupv1.ArangoVolumeSnapshot, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(arangovolumesnapshotsResource, c.ns, arangoVolumeSnapshot), &backupv1.ArangoVolumeSnapshot{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*backupv1.ArangoVolumeSnapshot), err
+}
+
+// Update takes the representation of a arangoVolumeSnapshot and updates it. Returns the server's representation of the arangoVolumeSnapshot, and an error, if there is any.
+func (c *FakeArangoVolumeSnapshots) Update(ctx context.Context, arangoVolumeSnapshot *backupv1.ArangoVolumeSnapshot, opts v1.UpdateOptions) (result *backupv1.ArangoVolumeSnapshot, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(arangovolumesnapshotsResource, c.ns, arangoVolumeSnapshot), &backupv1.ArangoVolumeSnapshot{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*backupv1.ArangoVolumeSnapshot), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeArangoVolumeSnapshots) UpdateStatus(ctx context.Context, arangoVolumeSnapshot *backupv1.ArangoVolumeSnapshot, opts v1.UpdateOptions) (*backupv1.ArangoVolumeSnapshot, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(arangovolumesnapshotsResource, "status", c.ns, arangoVolumeSnapshot), &backupv1.ArangoVolumeSnapshot{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*backupv1.ArangoVolumeSnapshot), err
+}
+
+// Delete takes name of the arangoVolumeSnapshot and deletes it. Returns an error if one occurs.
+func (c *FakeArangoVolumeSnapshots) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(arangovolumesnapshotsResource, c.ns, name), &backupv1.ArangoVolumeSnapshot{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeArangoVolumeSnapshots) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(arangovolumesnapshotsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &backupv1.ArangoVolumeSnapshotList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched arangoVolumeSnapshot.
+func (c *FakeArangoVolumeSnapshots) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *backupv1.ArangoVolumeSnapshot, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(arangovolumesnapshotsResource, c.ns, name, pt, data, subresources...), &backupv1.ArangoVolumeSnapshot{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*backupv1.ArangoVolumeSnapshot), err
+}
@@ -0,0 +1,94 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	backupv1 "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	versioned "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/arangodb/kube-arangodb/pkg/generated/informers/externalversions/internalinterfaces"
+	v1 "github.com/arangodb/kube-arangodb/pkg/generated/listers/backup/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ArangoVolumeSnapshotInformer provides access to a shared informer and lister for
+// ArangoVolumeSnapshots.
+type ArangoVolumeSnapshotInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.ArangoVolumeSnapshotLister
+}
+
+type arangoVolumeSnapshotInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewArangoVolumeSnapshotInformer constructs a new informer for ArangoVolumeSnapshot type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewArangoVolumeSnapshotInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredArangoVolumeSnapshotInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredArangoVolumeSnapshotInformer constructs a new informer for ArangoVolumeSnapshot type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredArangoVolumeSnapshotInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.BackupV1().ArangoVolumeSnapshots(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.BackupV1().ArangoVolumeSnapshots(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&backupv1.ArangoVolumeSnapshot{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *arangoVolumeSnapshotInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredArangoVolumeSnapshotInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *arangoVolumeSnapshotInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&backupv1.ArangoVolumeSnapshot{}, f.defaultInformer)
+}
+
+func (f *arangoVolumeSnapshotInformer) Lister() v1.ArangoVolumeSnapshotLister {
+	return v1.NewArangoVolumeSnapshotLister(f.Informer().GetIndexer())
+}
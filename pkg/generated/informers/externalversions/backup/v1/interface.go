@@ -32,6 +32,8 @@ type Interface interface {
 	ArangoBackups() ArangoBackupInformer
 	// ArangoBackupPolicies returns a ArangoBackupPolicyInformer.
 	ArangoBackupPolicies() ArangoBackupPolicyInformer
+	// ArangoVolumeSnapshots returns a ArangoVolumeSnapshotInformer.
+	ArangoVolumeSnapshots() ArangoVolumeSnapshotInformer
 }
 
 type version struct {
@@ -54,3 +56,8 @@ func (v *version) ArangoBackups() ArangoBackupInformer {
 func (v *version) ArangoBackupPolicies() ArangoBackupPolicyInformer {
 	return &arangoBackupPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// ArangoVolumeSnapshots returns a ArangoVolumeSnapshotInformer.
+func (v *version) ArangoVolumeSnapshots() ArangoVolumeSnapshotInformer {
+	return &arangoVolumeSnapshotInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
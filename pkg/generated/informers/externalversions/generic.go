@@ -66,6 +66,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Backup().V1().ArangoBackups().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("arangobackuppolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Backup().V1().ArangoBackupPolicies().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("arangovolumesnapshots"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Backup().V1().ArangoVolumeSnapshots().Informer()}, nil
 
 		// Group=database.arangodb.com, Version=v1
 	case deploymentv1.SchemeGroupVersion.WithResource("arangodeployments"):
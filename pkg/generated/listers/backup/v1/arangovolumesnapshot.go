@@ -0,0 +1,103 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ArangoVolumeSnapshotLister helps list ArangoVolumeSnapshots.
+// All objects returned here must be treated as read-only.
+type ArangoVolumeSnapshotLister interface {
+	// List lists all ArangoVolumeSnapshots in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ArangoVolumeSnapshot, err error)
+	// ArangoVolumeSnapshots returns an object that can list and get ArangoVolumeSnapshots.
+	ArangoVolumeSnapshots(namespace string) ArangoVolumeSnapshotNamespaceLister
+	ArangoVolumeSnapshotListerExpansion
+}
+
+// arangoVolumeSnapshotLister implements the ArangoVolumeSnapshotLister interface.
+type arangoVolumeSnapshotLister struct {
+	indexer cache.Indexer
+}
+
+// NewArangoVolumeSnapshotLister returns a new ArangoVolumeSnapshotLister.
+func NewArangoVolumeSnapshotLister(indexer cache.Indexer) ArangoVolumeSnapshotLister {
+	return &arangoVolumeSnapshotLister{indexer: indexer}
+}
+
+// List lists all ArangoVolumeSnapshots in the indexer.
+func (s *arangoVolumeSnapshotLister) List(selector labels.Selector) (ret []*v1.ArangoVolumeSnapshot, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ArangoVolumeSnapshot))
+	})
+	return ret, err
+}
+
+// ArangoVolumeSnapshots returns an object that can list and get ArangoVolumeSnapshots.
+func (s *arangoVolumeSnapshotLister) ArangoVolumeSnapshots(namespace string) ArangoVolumeSnapshotNamespaceLister {
+	return arangoVolumeSnapshotNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ArangoVolumeSnapshotNamespaceLister helps list and get ArangoVolumeSnapshots.
+// All objects returned here must be treated as read-only.
+type ArangoVolumeSnapshotNamespaceLister interface {
+	// List lists all ArangoVolumeSnapshots in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ArangoVolumeSnapshot, err error)
+	// Get retrieves the ArangoVolumeSnapshot from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ArangoVolumeSnapshot, error)
+	ArangoVolumeSnapshotNamespaceListerExpansion
+}
+
+// arangoVolumeSnapshotNamespaceLister implements the ArangoVolumeSnapshotNamespaceLister
+// interface.
+type arangoVolumeSnapshotNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ArangoVolumeSnapshots in the indexer for a given namespace.
+func (s arangoVolumeSnapshotNamespaceLister) List(selector labels.Selector) (ret []*v1.ArangoVolumeSnapshot, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ArangoVolumeSnapshot))
+	})
+	return ret, err
+}
+
+// Get retrieves the ArangoVolumeSnapshot from the indexer for a given namespace and name.
+func (s arangoVolumeSnapshotNamespaceLister) Get(name string) (*v1.ArangoVolumeSnapshot, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("arangovolumesnapshot"), name)
+	}
+	return obj.(*v1.ArangoVolumeSnapshot), nil
+}
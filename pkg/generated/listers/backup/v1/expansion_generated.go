@@ -37,3 +37,11 @@ type ArangoBackupPolicyListerExpansion interface{}
 // ArangoBackupPolicyNamespaceListerExpansion allows custom methods to be added to
 // ArangoBackupPolicyNamespaceLister.
 type ArangoBackupPolicyNamespaceListerExpansion interface{}
+
+// ArangoVolumeSnapshotListerExpansion allows custom methods to be added to
+// ArangoVolumeSnapshotLister.
+type ArangoVolumeSnapshotListerExpansion interface{}
+
+// ArangoVolumeSnapshotNamespaceListerExpansion allows custom methods to be added to
+// ArangoVolumeSnapshotNamespaceLister.
+type ArangoVolumeSnapshotNamespaceListerExpansion interface{}
@@ -24,8 +24,10 @@ package logging
 
 import (
 	"os"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
@@ -40,16 +42,60 @@ type Service interface {
 	MustGetLogger(name string) zerolog.Logger
 	// MustSetLevel sets the log level for the component with given name to given level.
 	MustSetLevel(name, level string)
+	// SetLevel sets the log level for the component with given name to given level.
+	// If ttl is greater than zero, the level is a temporary override which is reverted after ttl.
+	SetLevel(name, level string, ttl time.Duration) error
+	// ResetLevel removes the temporary override of the log level for the component with given name.
+	ResetLevel(name string) error
+	// GetLevels returns the current log levels of all known components.
+	GetLevels() []LevelInfo
 	// ConfigureRootLogger calls the given callback to modify the root logger.
 	ConfigureRootLogger(cb func(rootLog zerolog.Logger) zerolog.Logger)
 }
 
+// LevelInfo describes the log level of a single component.
+type LevelInfo struct {
+	// Name of the component
+	Name string `json:"name"`
+	// Level currently used by the component
+	Level string `json:"level"`
+	// Base level of the component, used when the override expires
+	BaseLevel string `json:"base_level"`
+	// Expires is set when the level is a temporary override
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// levelOverride keeps a temporary log level of a component.
+type levelOverride struct {
+	level   zerolog.Level
+	expires time.Time
+	timer   *time.Timer
+}
+
 // loggingService implements Service
 type loggingService struct {
-	mutex        sync.Mutex
+	mutex        sync.RWMutex
 	rootLog      zerolog.Logger
 	defaultLevel zerolog.Level
 	levels       map[string]zerolog.Level
+	overrides    map[string]*levelOverride
+	loggers      map[string]*levelSampler
+}
+
+// levelSampler drops events below the current level of the component.
+// It allows to change the level of already created loggers. Level is evaluated
+// before the event is created, so disabled events are not built at all.
+type levelSampler struct {
+	level int32
+}
+
+// Sample implements zerolog.Sampler
+func (l *levelSampler) Sample(level zerolog.Level) bool {
+	return level >= zerolog.Level(atomic.LoadInt32(&l.level))
+}
+
+func (l *levelSampler) set(level zerolog.Level) {
+	atomic.StoreInt32(&l.level, int32(level))
 }
 
 // NewRootLogger creates a new zerolog logger with default settings.
@@ -72,6 +118,8 @@ func NewService(defaultLevel string, overrides []string) (Service, error) {
 		rootLog:      rootLog,
 		defaultLevel: l,
 		levels:       make(map[string]zerolog.Level),
+		overrides:    make(map[string]*levelOverride),
+		loggers:      make(map[string]*levelSampler),
 	}
 
 	for _, override := range overrides {
@@ -109,11 +157,15 @@ func (s *loggingService) MustGetLogger(name string) zerolog.Logger {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	level, found := s.levels[name]
-	if !found {
-		level = s.defaultLevel
+	sampler, ok := s.loggers[name]
+	if !ok {
+		sampler = &levelSampler{}
+		s.loggers[name] = sampler
 	}
-	return s.rootLog.With().Str("component", name).Logger().Level(level)
+	sampler.set(s.getLevel(name))
+
+	// Level is evaluated by the sampler, so the logger itself needs to accept all supported levels
+	return s.rootLog.With().Str("component", name).Logger().Level(zerolog.DebugLevel).Sample(sampler)
 }
 
 // MustSetLevel sets the log level for the component with given name to given level.
@@ -125,6 +177,154 @@ func (s *loggingService) MustSetLevel(name, level string) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 	s.levels[name] = l
+	s.refreshLevel(name)
+}
+
+// SetLevel sets the log level for the component with given name to given level.
+// If ttl is greater than zero, the level is a temporary override which is reverted after ttl.
+func (s *loggingService) SetLevel(name, level string, ttl time.Duration) error {
+	l, err := stringToLevel(level)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.isKnown(name) {
+		return errors.Newf("Unknown logger '%s'", name)
+	}
+
+	s.resetOverride(name)
+
+	if ttl <= 0 {
+		s.levels[name] = l
+		s.refreshLevel(name)
+		return nil
+	}
+
+	o := &levelOverride{
+		level:   l,
+		expires: time.Now().Add(ttl),
+	}
+	o.timer = time.AfterFunc(ttl, func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		// Override could be replaced in the meantime
+		if s.overrides[name] == o {
+			delete(s.overrides, name)
+			s.refreshLevel(name)
+		}
+	})
+	s.overrides[name] = o
+	s.refreshLevel(name)
+
+	return nil
+}
+
+// ResetLevel removes the temporary override of the log level for the component with given name.
+func (s *loggingService) ResetLevel(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.isKnown(name) {
+		return errors.Newf("Unknown logger '%s'", name)
+	}
+
+	s.resetOverride(name)
+
+	return nil
+}
+
+// GetLevels returns the current log levels of all known components.
+func (s *loggingService) GetLevels() []LevelInfo {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	names := make(map[string]struct{}, len(s.loggers))
+	for _, name := range LoggerNames() {
+		names[name] = struct{}{}
+	}
+	for name := range s.loggers {
+		names[name] = struct{}{}
+	}
+
+	result := make([]LevelInfo, 0, len(names))
+	for name := range names {
+		base := s.baseLevel(name)
+		info := LevelInfo{
+			Name:      name,
+			Level:     base.String(),
+			BaseLevel: base.String(),
+		}
+		if o, ok := s.overrides[name]; ok {
+			expires := o.expires
+			info.Level = o.level.String()
+			info.Expires = &expires
+		}
+		result = append(result, info)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// getLevel returns the level currently used by the component with given name.
+// Caller must hold the mutex.
+func (s *loggingService) getLevel(name string) zerolog.Level {
+	if o, ok := s.overrides[name]; ok {
+		return o.level
+	}
+
+	return s.baseLevel(name)
+}
+
+// baseLevel returns the level of the component with given name, ignoring the temporary override.
+// Caller must hold the mutex.
+func (s *loggingService) baseLevel(name string) zerolog.Level {
+	if level, ok := s.levels[name]; ok {
+		return level
+	}
+
+	return s.defaultLevel
+}
+
+// isKnown returns true if logger with given name is defined or was already created.
+// Caller must hold the mutex.
+func (s *loggingService) isKnown(name string) bool {
+	if _, ok := s.loggers[name]; ok {
+		return true
+	}
+
+	for _, n := range LoggerNames() {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resetOverride removes the temporary override of the component with given name.
+// Caller must hold the mutex.
+func (s *loggingService) resetOverride(name string) {
+	if o, ok := s.overrides[name]; ok {
+		o.timer.Stop()
+		delete(s.overrides, name)
+		s.refreshLevel(name)
+	}
+}
+
+// refreshLevel propagates the current level of the component with given name to its loggers.
+// Caller must hold the mutex.
+func (s *loggingService) refreshLevel(name string) {
+	if sampler, ok := s.loggers[name]; ok {
+		sampler.set(s.getLevel(name))
+	}
 }
 
 // stringToLevel converts a level string to a zerolog level
@@ -0,0 +1,130 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package logging
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService(t *testing.T, out *bytes.Buffer, overrides ...string) Service {
+	s, err := NewService("info", overrides)
+	require.NoError(t, err)
+
+	s.ConfigureRootLogger(func(zerolog.Logger) zerolog.Logger {
+		return zerolog.New(out)
+	})
+
+	return s
+}
+
+func Test_Logger_RuntimeLevel(t *testing.T) {
+	var out bytes.Buffer
+	s := newTestService(t, &out)
+
+	log := s.MustGetLogger(LoggerNameDeployment)
+
+	log.Debug().Msg("hidden")
+	require.Empty(t, out.String())
+
+	require.NoError(t, s.SetLevel(LoggerNameDeployment, "debug", 0))
+
+	log.Debug().Msg("visible")
+	require.Contains(t, out.String(), "visible")
+
+	out.Reset()
+	s.MustSetLevel(LoggerNameDeployment, "error")
+
+	log.Warn().Msg("hidden")
+	require.Empty(t, out.String())
+}
+
+func Test_Logger_DisabledEvents(t *testing.T) {
+	var out bytes.Buffer
+	s := newTestService(t, &out)
+
+	log := s.MustGetLogger(LoggerNameDeployment)
+
+	require.Nil(t, log.Debug())
+	require.NotNil(t, log.Info())
+
+	require.NoError(t, s.SetLevel(LoggerNameDeployment, "debug", time.Hour))
+	require.NotNil(t, log.Debug())
+
+	require.NoError(t, s.ResetLevel(LoggerNameDeployment))
+	require.Nil(t, log.Debug())
+}
+
+func Test_Logger_TemporaryLevel(t *testing.T) {
+	var out bytes.Buffer
+	s := newTestService(t, &out, "reconciliation=warn")
+
+	log := s.MustGetLogger(LoggerNameReconciliation)
+
+	require.NoError(t, s.SetLevel(LoggerNameReconciliation, "debug", 50*time.Millisecond))
+
+	levels := s.GetLevels()
+	var info *LevelInfo
+	for id := range levels {
+		if levels[id].Name == LoggerNameReconciliation {
+			info = &levels[id]
+		}
+	}
+	require.NotNil(t, info)
+	require.Equal(t, "debug", info.Level)
+	require.Equal(t, "warn", info.BaseLevel)
+	require.NotNil(t, info.Expires)
+
+	log.Debug().Msg("visible")
+	require.Contains(t, out.String(), "visible")
+
+	require.Eventually(t, func() bool {
+		out.Reset()
+		log.Info().Msg("hidden")
+		return out.Len() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_Logger_ResetLevel(t *testing.T) {
+	var out bytes.Buffer
+	s := newTestService(t, &out)
+
+	log := s.MustGetLogger(LoggerNameServer)
+
+	require.NoError(t, s.SetLevel(LoggerNameServer, "debug", time.Hour))
+	require.NoError(t, s.ResetLevel(LoggerNameServer))
+
+	log.Debug().Msg("hidden")
+	require.Empty(t, out.String())
+}
+
+func Test_Logger_InvalidInput(t *testing.T) {
+	var out bytes.Buffer
+	s := newTestService(t, &out)
+
+	require.Error(t, s.SetLevel("unknown", "debug", 0))
+	require.Error(t, s.SetLevel(LoggerNameServer, "verbose", 0))
+	require.Error(t, s.ResetLevel("unknown"))
+}
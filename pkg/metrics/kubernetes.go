@@ -0,0 +1,60 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package metrics
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	clientMetrics "k8s.io/client-go/tools/metrics"
+)
+
+const kubernetesClientComponent = "kubernetes_client"
+
+var (
+	kubernetesClientRequests        *prometheus.CounterVec
+	kubernetesClientRequestDuration *prometheus.HistogramVec
+)
+
+// RegisterKubernetesClientMetrics registers metrics of the requests made by the Kubernetes clients.
+// Errors are counted with code label set to the response code (or "<error>" when no response was received).
+func RegisterKubernetesClientMetrics() {
+	kubernetesClientRequests = MustRegisterCounterVec(kubernetesClientComponent, "requests", "Number of Kubernetes API requests by response code and method", "code", "method")
+	kubernetesClientRequestDuration = MustRegisterHistogramVec(kubernetesClientComponent, "request_duration", "Duration of Kubernetes API requests (in sec)", nil, "verb")
+
+	clientMetrics.Register(clientMetrics.RegisterOpts{
+		RequestLatency: kubernetesClientLatency{},
+		RequestResult:  kubernetesClientResult{},
+	})
+}
+
+type kubernetesClientLatency struct{}
+
+func (kubernetesClientLatency) Observe(verb string, _ url.URL, latency time.Duration) {
+	kubernetesClientRequestDuration.WithLabelValues(verb).Observe(latency.Seconds())
+}
+
+type kubernetesClientResult struct{}
+
+func (kubernetesClientResult) Increment(code string, method string, _ string) {
+	kubernetesClientRequests.WithLabelValues(code, method).Inc()
+}
@@ -39,8 +39,23 @@ const (
 	Success = "success"
 	// Failed is a label value used for failed actions
 	Failed = "failed"
+	// Aborted is a label value used for aborted actions
+	Aborted = "aborted"
+	// Timeout is a label value used for actions which did not finish in time
+	Timeout = "timeout"
+	// ActionType is a label key used for the type of a plan action
+	ActionType = "action"
+	// Group is a label key used for the server group of a member
+	Group = "group"
+	// Reason is a label key used for the reason of an operation
+	Reason = "reason"
+	// Namespace is a label key used for the namespace
+	Namespace = "namespace"
 )
 
+// DefaultDurationBuckets are histogram buckets (in sec) used for durations of the operator operations
+var DefaultDurationBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300, 900, 3600}
+
 // MustRegisterCounter creates and registers a counter.
 // Must be called from `init`.
 func MustRegisterCounter(component, name, help string) prometheus.Counter {
@@ -93,6 +108,24 @@ func MustRegisterGaugeVec(component, name, help string, labelNames ...string) *p
 	return m
 }
 
+// MustRegisterHistogramVec creates and registers a histogram vector.
+// If buckets are nil, DefaultDurationBuckets are used.
+// Must be called from `init`.
+func MustRegisterHistogramVec(component, name, help string, buckets []float64, labelNames ...string) *prometheus.HistogramVec {
+	if buckets == nil {
+		buckets = DefaultDurationBuckets
+	}
+	m := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: component,
+		Name:      name,
+		Help:      help,
+		Buckets:   buckets,
+	}, labelNames)
+	prometheus.MustRegister(m)
+	return m
+}
+
 // MustRegisterSummary creates and registers a summary.
 // Must be called from `init`.
 func MustRegisterSummary(component, name, help string, objectives map[float64]float64) prometheus.Summary {
@@ -110,6 +143,12 @@ func MustRegisterSummary(component, name, help string, objectives map[float64]fl
 	return m
 }
 
+// ObserveDuration adds an observation of the duration since the given start time
+// in seconds.
+func ObserveDuration(o prometheus.Observer, startTime time.Time) {
+	o.Observe(time.Since(startTime).Seconds())
+}
+
 // SetDuration sets a gauge value for the duration since the given start time
 // in seconds.
 func SetDuration(g prometheus.Gauge, startTime time.Time) {
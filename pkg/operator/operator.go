@@ -25,9 +25,11 @@ package operator
 import (
 	"context"
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/arangodb/kube-arangodb/pkg/operator/scope"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 
 	monitoringClient "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
 
@@ -40,6 +42,7 @@ import (
 	"github.com/rs/zerolog/log"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	kwatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 
@@ -54,6 +57,7 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/logging"
 	"github.com/arangodb/kube-arangodb/pkg/replication"
 	"github.com/arangodb/kube-arangodb/pkg/storage"
+	"github.com/arangodb/kube-arangodb/pkg/util/eventstream"
 	"github.com/arangodb/kube-arangodb/pkg/util/probe"
 	"k8s.io/client-go/rest"
 
@@ -77,6 +81,7 @@ type Operator struct {
 	Dependencies
 
 	log                    zerolog.Logger
+	watch                  *watchFilter
 	deployments            map[string]*deployment.Deployment
 	deploymentReplications map[string]*replication.DeploymentReplication
 	localStorages          map[string]*storage.LocalStorage
@@ -97,6 +102,8 @@ type Config struct {
 	AllowChaos                  bool
 	SingleMode                  bool
 	Scope                       scope.Scope
+	LeaderElection              LeaderElectionConfig
+	Watch                       WatchConfig
 }
 
 type Dependencies struct {
@@ -104,6 +111,7 @@ type Dependencies struct {
 	KubeCli                    kubernetes.Interface
 	KubeExtCli                 apiextensionsclient.Interface
 	KubeMonitoringCli          monitoringClient.MonitoringV1Interface
+	KubeDynamicCli             dynamic.Interface
 	CRCli                      versioned.Interface
 	EventRecorder              record.EventRecorder
 	LivenessProbe              *probe.LivenessProbe
@@ -111,14 +119,21 @@ type Dependencies struct {
 	DeploymentReplicationProbe *probe.ReadyProbe
 	StorageProbe               *probe.ReadyProbe
 	BackupProbe                *probe.ReadyProbe
+	EventStream                eventstream.Stream
 }
 
 // NewOperator instantiates a new operator from given config & dependencies.
 func NewOperator(config Config, deps Dependencies) (*Operator, error) {
+	watch, err := newWatchFilter(config.Watch, config.Namespace)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	o := &Operator{
 		Config:                 config,
 		Dependencies:           deps,
 		log:                    deps.LogService.MustGetLogger(logging.LoggerNameOperator),
+		watch:                  watch,
 		deployments:            make(map[string]*deployment.Deployment),
 		deploymentReplications: make(map[string]*replication.DeploymentReplication),
 		localStorages:          make(map[string]*storage.LocalStorage),
@@ -126,38 +141,39 @@ func NewOperator(config Config, deps Dependencies) (*Operator, error) {
 	return o, nil
 }
 
-// Run the operator
-func (o *Operator) Run() {
-	if o.Config.EnableDeployment {
-		if !o.Config.SingleMode {
-			go o.runLeaderElection("arango-deployment-operator", constants.LabelRole, o.onStartDeployment, o.Dependencies.DeploymentProbe)
-		} else {
-			go o.runWithoutLeaderElection("arango-deployment-operator", constants.LabelRole, o.onStartDeployment, o.Dependencies.DeploymentProbe)
+// Run the operator till the given context is canceled.
+// Leadership of all locks held by the operator is released before Run returns.
+func (o *Operator) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	run := func(lockName, label string, onStart func(stop <-chan struct{}), readyProbe *probe.ReadyProbe) {
+		if o.Config.SingleMode {
+			go o.runWithoutLeaderElection(ctx, lockName, label, onStart, readyProbe)
+			return
 		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.runLeaderElection(ctx, lockName, label, onStart, readyProbe)
+		}()
+	}
+
+	if o.Config.EnableDeployment {
+		// Deployments and deployment replications are split between shards, so each shard has own lock
+		run(o.watch.LockName("arango-deployment-operator"), constants.LabelRole, o.onStartDeployment, o.Dependencies.DeploymentProbe)
 	}
 	if o.Config.EnableDeploymentReplication {
-		if !o.Config.SingleMode {
-			go o.runLeaderElection("arango-deployment-replication-operator", constants.LabelRole, o.onStartDeploymentReplication, o.Dependencies.DeploymentReplicationProbe)
-		} else {
-			go o.runWithoutLeaderElection("arango-deployment-replication-operator", constants.LabelRole, o.onStartDeploymentReplication, o.Dependencies.DeploymentReplicationProbe)
-		}
+		run(o.watch.LockName("arango-deployment-replication-operator"), constants.LabelRole, o.onStartDeploymentReplication, o.Dependencies.DeploymentReplicationProbe)
 	}
 	if o.Config.EnableStorage {
-		if !o.Config.SingleMode {
-			go o.runLeaderElection("arango-storage-operator", constants.LabelRole, o.onStartStorage, o.Dependencies.StorageProbe)
-		} else {
-			go o.runWithoutLeaderElection("arango-storage-operator", constants.LabelRole, o.onStartStorage, o.Dependencies.StorageProbe)
-		}
+		run("arango-storage-operator", constants.LabelRole, o.onStartStorage, o.Dependencies.StorageProbe)
 	}
 	if o.Config.EnableBackup {
-		if !o.Config.SingleMode {
-			go o.runLeaderElection("arango-backup-operator", constants.BackupLabelRole, o.onStartBackup, o.Dependencies.BackupProbe)
-		} else {
-			go o.runWithoutLeaderElection("arango-backup-operator", constants.BackupLabelRole, o.onStartBackup, o.Dependencies.BackupProbe)
-		}
+		run("arango-backup-operator", constants.BackupLabelRole, o.onStartBackup, o.Dependencies.BackupProbe)
 	}
 	// Wait until process terminates
-	<-context.TODO().Done()
+	<-ctx.Done()
+	wg.Wait()
 }
 
 // onStartDeployment starts the deployment operator and run till given channel is closed.
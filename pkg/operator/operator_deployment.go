@@ -23,17 +23,19 @@
 package operator
 
 import (
+	"context"
+
 	deploymentType "github.com/arangodb/kube-arangodb/pkg/apis/deployment"
 	"github.com/arangodb/kube-arangodb/pkg/logging"
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kwatch "k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	"github.com/arangodb/kube-arangodb/pkg/deployment"
 	"github.com/arangodb/kube-arangodb/pkg/metrics"
-	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 )
 
 var (
@@ -47,20 +49,17 @@ var (
 // run the deployments part of the operator.
 // This registers a listener and waits until the process stops.
 func (o *Operator) runDeployments(stop <-chan struct{}) {
-	rw := k8sutil.NewResourceWatcher(
-		o.log,
+	o.Dependencies.DeploymentProbe.SetReady()
+	o.runWatchers(stop,
 		o.Dependencies.CRCli.DatabaseV1().RESTClient(),
 		deploymentType.ArangoDeploymentResourcePlural,
-		o.Config.Namespace,
 		&api.ArangoDeployment{},
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    o.onAddArangoDeployment,
 			UpdateFunc: o.onUpdateArangoDeployment,
 			DeleteFunc: o.onDeleteArangoDeployment,
-		})
-
-	o.Dependencies.DeploymentProbe.SetReady()
-	rw.Run(stop)
+		},
+		o.resyncArangoDeployments)
 }
 
 // onAddArangoDeployment deployment addition callback
@@ -69,7 +68,11 @@ func (o *Operator) onAddArangoDeployment(obj interface{}) {
 	defer o.Dependencies.LivenessProbe.Unlock()
 
 	apiObject := obj.(*api.ArangoDeployment)
+	if !o.watch.IsOwned(apiObject) {
+		return
+	}
 	o.log.Debug().
+		Str("namespace", apiObject.GetNamespace()).
 		Str("name", apiObject.GetObjectMeta().GetName()).
 		Msg("ArangoDeployment added")
 	o.syncArangoDeployment(apiObject)
@@ -81,7 +84,12 @@ func (o *Operator) onUpdateArangoDeployment(oldObj, newObj interface{}) {
 	defer o.Dependencies.LivenessProbe.Unlock()
 
 	apiObject := newObj.(*api.ArangoDeployment)
+	if !o.watch.IsOwned(apiObject) {
+		o.releaseArangoDeployment(resourceKey(apiObject))
+		return
+	}
 	o.log.Debug().
+		Str("namespace", apiObject.GetNamespace()).
 		Str("name", apiObject.GetObjectMeta().GetName()).
 		Msg("ArangoDeployment updated")
 	o.syncArangoDeployment(apiObject)
@@ -106,7 +114,11 @@ func (o *Operator) onDeleteArangoDeployment(obj interface{}) {
 			return
 		}
 	}
+	if _, ok := o.deployments[resourceKey(apiObject)]; !ok && !o.watch.IsOwned(apiObject) {
+		return
+	}
 	log.Debug().
+		Str("namespace", apiObject.GetNamespace()).
 		Str("name", apiObject.GetObjectMeta().GetName()).
 		Msg("ArangoDeployment deleted")
 	ev := &Event{
@@ -131,7 +143,7 @@ func (o *Operator) syncArangoDeployment(apiObject *api.ArangoDeployment) {
 	// re-watch or restart could give ADD event.
 	// If for an ADD event the cluster spec is invalid then it is not added to the local cache
 	// so modifying that deployment will result in another ADD event
-	if _, ok := o.deployments[apiObject.Name]; ok {
+	if _, ok := o.deployments[resourceKey(apiObject)]; ok {
 		ev.Type = kwatch.Modified
 	}
 
@@ -143,6 +155,44 @@ func (o *Operator) syncArangoDeployment(apiObject *api.ArangoDeployment) {
 	//pt.stop()
 }
 
+// releaseArangoDeployment stops handling of the deployment which is not in scope of the operator anymore.
+func (o *Operator) releaseArangoDeployment(key string) {
+	obj, ok := o.deployments[key]
+	if !ok {
+		return
+	}
+	o.log.Info().Str("deployment", key).Msg("ArangoDeployment is not in scope of the operator anymore")
+	obj.Release()
+	delete(o.deployments, key)
+	deploymentsCurrent.Set(float64(len(o.deployments)))
+}
+
+// resyncArangoDeployments synchronizes the deployments in the namespace, which starts or stops to be watched.
+func (o *Operator) resyncArangoDeployments(namespace string) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+
+	if !o.watch.IsNamespaceWatched(namespace) {
+		for key, obj := range o.deployments {
+			if obj.Namespace() == namespace {
+				o.releaseArangoDeployment(key)
+			}
+		}
+		return
+	}
+
+	list, err := o.Dependencies.CRCli.DatabaseV1().ArangoDeployments(namespace).List(context.Background(), meta.ListOptions{})
+	if err != nil {
+		o.log.Warn().Err(err).Str("namespace", namespace).Msg("Failed to list ArangoDeployments")
+		return
+	}
+	for i := range list.Items {
+		if apiObject := &list.Items[i]; o.watch.IsOwned(apiObject) {
+			o.syncArangoDeployment(apiObject)
+		}
+	}
+}
+
 // handleDeploymentEvent processed the given event.
 func (o *Operator) handleDeploymentEvent(event *Event) error {
 	apiObject := event.Deployment
@@ -150,7 +200,7 @@ func (o *Operator) handleDeploymentEvent(event *Event) error {
 	if apiObject.Status.Phase.IsFailed() {
 		deploymentsFailed.Inc()
 		if event.Type == kwatch.Deleted {
-			delete(o.deployments, apiObject.Name)
+			delete(o.deployments, resourceKey(apiObject))
 			return nil
 		}
 		return errors.WithStack(errors.Newf("ignore failed deployment (%s). Please delete its CR", apiObject.Name))
@@ -158,7 +208,7 @@ func (o *Operator) handleDeploymentEvent(event *Event) error {
 
 	switch event.Type {
 	case kwatch.Added:
-		if _, ok := o.deployments[apiObject.Name]; ok {
+		if _, ok := o.deployments[resourceKey(apiObject)]; ok {
 			return errors.WithStack(errors.Newf("unsafe state. deployment (%s) was created before but we received event (%s)", apiObject.Name, event.Type))
 		}
 
@@ -174,13 +224,13 @@ func (o *Operator) handleDeploymentEvent(event *Event) error {
 		if err != nil {
 			return errors.WithStack(errors.Newf("failed to create deployment: %s", err))
 		}
-		o.deployments[apiObject.Name] = nc
+		o.deployments[resourceKey(apiObject)] = nc
 
 		deploymentsCreated.Inc()
 		deploymentsCurrent.Set(float64(len(o.deployments)))
 
 	case kwatch.Modified:
-		depl, ok := o.deployments[apiObject.Name]
+		depl, ok := o.deployments[resourceKey(apiObject)]
 		if !ok {
 			return errors.WithStack(errors.Newf("unsafe state. deployment (%s) was never created but we received event (%s)", apiObject.Name, event.Type))
 		}
@@ -188,12 +238,12 @@ func (o *Operator) handleDeploymentEvent(event *Event) error {
 		deploymentsModified.Inc()
 
 	case kwatch.Deleted:
-		depl, ok := o.deployments[apiObject.Name]
+		depl, ok := o.deployments[resourceKey(apiObject)]
 		if !ok {
 			return errors.WithStack(errors.Newf("unsafe state. deployment (%s) was never created but we received event (%s)", apiObject.Name, event.Type))
 		}
 		depl.Delete()
-		delete(o.deployments, apiObject.Name)
+		delete(o.deployments, resourceKey(apiObject))
 		deploymentsDeleted.Inc()
 		deploymentsCurrent.Set(float64(len(o.deployments)))
 	}
@@ -217,9 +267,11 @@ func (o *Operator) makeDeploymentConfigAndDeps(apiObject *api.ArangoDeployment)
 			Logger(),
 		KubeCli:           o.Dependencies.KubeCli,
 		KubeMonitoringCli: o.Dependencies.KubeMonitoringCli,
+		KubeDynamicCli:    o.Dependencies.KubeDynamicCli,
 		KubeExtCli:        o.Dependencies.KubeExtCli,
 		DatabaseCRCli:     o.Dependencies.CRCli,
 		EventRecorder:     o.Dependencies.EventRecorder,
+		EventStream:       o.Dependencies.EventStream,
 	}
 	return cfg, deps
 }
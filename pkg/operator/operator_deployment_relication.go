@@ -23,17 +23,20 @@
 package operator
 
 import (
+	"context"
+	"reflect"
+
 	replication2 "github.com/arangodb/kube-arangodb/pkg/apis/replication"
 	"github.com/arangodb/kube-arangodb/pkg/logging"
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kwatch "k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
 	"github.com/arangodb/kube-arangodb/pkg/metrics"
 	"github.com/arangodb/kube-arangodb/pkg/replication"
-	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 )
 
 var (
@@ -47,20 +50,17 @@ var (
 // run the deployment replications part of the operator.
 // This registers a listener and waits until the process stops.
 func (o *Operator) runDeploymentReplications(stop <-chan struct{}) {
-	rw := k8sutil.NewResourceWatcher(
-		o.log,
+	o.Dependencies.DeploymentReplicationProbe.SetReady()
+	o.runWatchers(stop,
 		o.Dependencies.CRCli.ReplicationV1().RESTClient(),
 		replication2.ArangoDeploymentReplicationResourcePlural,
-		o.Config.Namespace,
 		&api.ArangoDeploymentReplication{},
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    o.onAddArangoDeploymentReplication,
 			UpdateFunc: o.onUpdateArangoDeploymentReplication,
 			DeleteFunc: o.onDeleteArangoDeploymentReplication,
-		})
-
-	o.Dependencies.DeploymentReplicationProbe.SetReady()
-	rw.Run(stop)
+		},
+		o.resyncArangoDeploymentReplications)
 }
 
 // onAddArangoDeploymentReplication deployment replication addition callback
@@ -69,7 +69,11 @@ func (o *Operator) onAddArangoDeploymentReplication(obj interface{}) {
 	defer o.Dependencies.LivenessProbe.Unlock()
 
 	apiObject := obj.(*api.ArangoDeploymentReplication)
+	if !o.watch.IsOwned(apiObject) {
+		return
+	}
 	o.log.Debug().
+		Str("namespace", apiObject.GetNamespace()).
 		Str("name", apiObject.GetObjectMeta().GetName()).
 		Msg("ArangoDeploymentReplication added")
 	o.syncArangoDeploymentReplication(apiObject)
@@ -80,13 +84,36 @@ func (o *Operator) onUpdateArangoDeploymentReplication(oldObj, newObj interface{
 	o.Dependencies.LivenessProbe.Lock()
 	defer o.Dependencies.LivenessProbe.Unlock()
 
+	oldAPIObject := oldObj.(*api.ArangoDeploymentReplication)
 	apiObject := newObj.(*api.ArangoDeploymentReplication)
+	if !o.watch.IsOwned(apiObject) {
+		o.releaseArangoDeploymentReplication(resourceKey(apiObject))
+		return
+	}
+	if !isArangoDeploymentReplicationChanged(oldAPIObject, apiObject) {
+		// Status updates of the operator itself must not trigger another inspection
+		return
+	}
 	o.log.Debug().
+		Str("namespace", apiObject.GetNamespace()).
 		Str("name", apiObject.GetObjectMeta().GetName()).
 		Msg("ArangoDeploymentReplication updated")
 	o.syncArangoDeploymentReplication(apiObject)
 }
 
+// isArangoDeploymentReplicationChanged returns true when the update of the deployment replication
+// changes its spec or deletion.
+func isArangoDeploymentReplicationChanged(old, new *api.ArangoDeploymentReplication) bool {
+	if old.GetGeneration() > 0 && old.GetGeneration() == new.GetGeneration() {
+		return false
+	}
+	// Without the status subresource the generation is incremented by status updates as well
+	if (old.GetDeletionTimestamp() == nil) != (new.GetDeletionTimestamp() == nil) {
+		return true
+	}
+	return !reflect.DeepEqual(old.Spec, new.Spec)
+}
+
 // onDeleteArangoDeploymentReplication deployment replication delete callback
 func (o *Operator) onDeleteArangoDeploymentReplication(obj interface{}) {
 	o.Dependencies.LivenessProbe.Lock()
@@ -106,7 +133,11 @@ func (o *Operator) onDeleteArangoDeploymentReplication(obj interface{}) {
 			return
 		}
 	}
+	if _, ok := o.deploymentReplications[resourceKey(apiObject)]; !ok && !o.watch.IsOwned(apiObject) {
+		return
+	}
 	log.Debug().
+		Str("namespace", apiObject.GetNamespace()).
 		Str("name", apiObject.GetObjectMeta().GetName()).
 		Msg("ArangoDeploymentReplication deleted")
 	ev := &Event{
@@ -131,7 +162,7 @@ func (o *Operator) syncArangoDeploymentReplication(apiObject *api.ArangoDeployme
 	// re-watch or restart could give ADD event.
 	// If for an ADD event the cluster spec is invalid then it is not added to the local cache
 	// so modifying that deployment will result in another ADD event
-	if _, ok := o.deploymentReplications[apiObject.Name]; ok {
+	if _, ok := o.deploymentReplications[resourceKey(apiObject)]; ok {
 		ev.Type = kwatch.Modified
 	}
 
@@ -143,6 +174,44 @@ func (o *Operator) syncArangoDeploymentReplication(apiObject *api.ArangoDeployme
 	//pt.stop()
 }
 
+// releaseArangoDeploymentReplication stops handling of the deployment replication which is not in scope of the operator anymore.
+func (o *Operator) releaseArangoDeploymentReplication(key string) {
+	obj, ok := o.deploymentReplications[key]
+	if !ok {
+		return
+	}
+	o.log.Info().Str("deployment-replication", key).Msg("ArangoDeploymentReplication is not in scope of the operator anymore")
+	obj.Release()
+	delete(o.deploymentReplications, key)
+	deploymentReplicationsCurrent.Set(float64(len(o.deploymentReplications)))
+}
+
+// resyncArangoDeploymentReplications synchronizes the deployment replications in the namespace, which starts or stops to be watched.
+func (o *Operator) resyncArangoDeploymentReplications(namespace string) {
+	o.Dependencies.LivenessProbe.Lock()
+	defer o.Dependencies.LivenessProbe.Unlock()
+
+	if !o.watch.IsNamespaceWatched(namespace) {
+		for key, obj := range o.deploymentReplications {
+			if obj.Namespace() == namespace {
+				o.releaseArangoDeploymentReplication(key)
+			}
+		}
+		return
+	}
+
+	list, err := o.Dependencies.CRCli.ReplicationV1().ArangoDeploymentReplications(namespace).List(context.Background(), meta.ListOptions{})
+	if err != nil {
+		o.log.Warn().Err(err).Str("namespace", namespace).Msg("Failed to list ArangoDeploymentReplications")
+		return
+	}
+	for i := range list.Items {
+		if apiObject := &list.Items[i]; o.watch.IsOwned(apiObject) {
+			o.syncArangoDeploymentReplication(apiObject)
+		}
+	}
+}
+
 // handleDeploymentReplicationEvent processed the given event.
 func (o *Operator) handleDeploymentReplicationEvent(event *Event) error {
 	apiObject := event.DeploymentReplication
@@ -150,7 +219,7 @@ func (o *Operator) handleDeploymentReplicationEvent(event *Event) error {
 	if apiObject.Status.Phase.IsFailed() {
 		deploymentReplicationsFailed.Inc()
 		if event.Type == kwatch.Deleted {
-			delete(o.deploymentReplications, apiObject.Name)
+			delete(o.deploymentReplications, resourceKey(apiObject))
 			return nil
 		}
 		return errors.WithStack(errors.Newf("ignore failed deployment replication (%s). Please delete its CR", apiObject.Name))
@@ -158,7 +227,7 @@ func (o *Operator) handleDeploymentReplicationEvent(event *Event) error {
 
 	switch event.Type {
 	case kwatch.Added:
-		if _, ok := o.deploymentReplications[apiObject.Name]; ok {
+		if _, ok := o.deploymentReplications[resourceKey(apiObject)]; ok {
 			return errors.WithStack(errors.Newf("unsafe state. deployment replication (%s) was created before but we received event (%s)", apiObject.Name, event.Type))
 		}
 
@@ -174,13 +243,13 @@ func (o *Operator) handleDeploymentReplicationEvent(event *Event) error {
 		if err != nil {
 			return errors.WithStack(errors.Newf("failed to create deployment: %s", err))
 		}
-		o.deploymentReplications[apiObject.Name] = nc
+		o.deploymentReplications[resourceKey(apiObject)] = nc
 
 		deploymentReplicationsCreated.Inc()
 		deploymentReplicationsCurrent.Set(float64(len(o.deploymentReplications)))
 
 	case kwatch.Modified:
-		repl, ok := o.deploymentReplications[apiObject.Name]
+		repl, ok := o.deploymentReplications[resourceKey(apiObject)]
 		if !ok {
 			return errors.WithStack(errors.Newf("unsafe state. deployment replication (%s) was never created but we received event (%s)", apiObject.Name, event.Type))
 		}
@@ -188,12 +257,12 @@ func (o *Operator) handleDeploymentReplicationEvent(event *Event) error {
 		deploymentReplicationsModified.Inc()
 
 	case kwatch.Deleted:
-		repl, ok := o.deploymentReplications[apiObject.Name]
+		repl, ok := o.deploymentReplications[resourceKey(apiObject)]
 		if !ok {
 			return errors.WithStack(errors.Newf("unsafe state. deployment replication (%s) was never created but we received event (%s)", apiObject.Name, event.Type))
 		}
 		repl.Delete()
-		delete(o.deploymentReplications, apiObject.Name)
+		delete(o.deploymentReplications, resourceKey(apiObject))
 		deploymentReplicationsDeleted.Inc()
 		deploymentReplicationsCurrent.Set(float64(len(o.deploymentReplications)))
 	}
@@ -203,7 +272,7 @@ func (o *Operator) handleDeploymentReplicationEvent(event *Event) error {
 // makeDeploymentReplicationConfigAndDeps creates a Config & Dependencies object for a new DeploymentReplication.
 func (o *Operator) makeDeploymentReplicationConfigAndDeps(apiObject *api.ArangoDeploymentReplication) (replication.Config, replication.Dependencies) {
 	cfg := replication.Config{
-		Namespace: o.Config.Namespace,
+		Namespace: apiObject.GetNamespace(),
 	}
 	deps := replication.Dependencies{
 		Log: o.Dependencies.LogService.MustGetLogger(logging.LoggerNameDeploymentReplication).With().
@@ -0,0 +1,88 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+)
+
+func Test_IsArangoDeploymentReplicationChanged(t *testing.T) {
+	repl := func(generation int64, mutate ...func(r *api.ArangoDeploymentReplication)) *api.ArangoDeploymentReplication {
+		r := &api.ArangoDeploymentReplication{
+			ObjectMeta: meta.ObjectMeta{Name: "repl", Namespace: "test", Generation: generation},
+		}
+		for _, m := range mutate {
+			m(r)
+		}
+		return r
+	}
+	withStatus := func(r *api.ArangoDeploymentReplication) {
+		r.Status.Phase = api.DeploymentReplicationPhaseFailed
+	}
+	withSpec := func(r *api.ArangoDeploymentReplication) {
+		r.Spec.Source.DeploymentName = new(string)
+	}
+	deleted := func(r *api.ArangoDeploymentReplication) {
+		now := meta.Now()
+		r.DeletionTimestamp = &now
+	}
+
+	type testCase struct {
+		old, new *api.ArangoDeploymentReplication
+		changed  bool
+	}
+
+	testCases := map[string]testCase{
+		"Same generation": {
+			old: repl(1),
+			new: repl(1, withStatus),
+		},
+		"Status update without status subresource": {
+			old: repl(1),
+			new: repl(2, withStatus),
+		},
+		"Spec update": {
+			old:     repl(1),
+			new:     repl(2, withSpec),
+			changed: true,
+		},
+		"Deletion": {
+			old:     repl(1),
+			new:     repl(2, deleted),
+			changed: true,
+		},
+		"Status update after deletion": {
+			old: repl(2, deleted),
+			new: repl(3, deleted, withStatus),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.changed, isArangoDeploymentReplicationChanged(tc.old, tc.new))
+		})
+	}
+}
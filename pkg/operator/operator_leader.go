@@ -26,6 +26,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
@@ -37,19 +38,69 @@ import (
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 
+	"github.com/arangodb/kube-arangodb/pkg/metrics"
 	"github.com/arangodb/kube-arangodb/pkg/util/constants"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 	"github.com/arangodb/kube-arangodb/pkg/util/probe"
 	"github.com/arangodb/kube-arangodb/pkg/util/retry"
 )
 
+var (
+	leaderElectionIsLeader = metrics.MustRegisterGaugeVec("leader_election", "is_leader", "1 if this operator instance holds the lock, 0 otherwise", lockNameLabel)
+)
+
+const (
+	lockNameLabel = "lock"
+
+	// DefaultLeaderElectionLockType is the default type of the resource used as leader election lock.
+	// Both Endpoints and Lease locks are held, as older operators use Endpoints locks only.
+	// The default is going to be changed to Lease locks in the next release.
+	DefaultLeaderElectionLockType = resourcelock.EndpointsLeasesResourceLock
+	// DefaultLeaderElectionLeaseDuration is the default duration non-leaders wait before trying to acquire the lock
+	DefaultLeaderElectionLeaseDuration = 15 * time.Second
+	// DefaultLeaderElectionRenewDeadline is the default duration the leader retries to renew the lock before giving up
+	DefaultLeaderElectionRenewDeadline = 10 * time.Second
+	// DefaultLeaderElectionRetryPeriod is the default duration between attempts to acquire or renew the lock
+	DefaultLeaderElectionRetryPeriod = 2 * time.Second
+)
+
+// LeaderElectionConfig holds the configuration of the leader election.
+type LeaderElectionConfig struct {
+	LockType      string
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// Validate the leader election configuration.
+func (c LeaderElectionConfig) Validate() error {
+	switch c.LockType {
+	case resourcelock.LeasesResourceLock, resourcelock.EndpointsLeasesResourceLock, resourcelock.EndpointsResourceLock:
+	default:
+		return errors.Newf("unsupported leader election lock type %s, expected one of %s, %s, %s", c.LockType,
+			resourcelock.LeasesResourceLock, resourcelock.EndpointsLeasesResourceLock, resourcelock.EndpointsResourceLock)
+	}
+	if c.RetryPeriod <= 0 {
+		return errors.Newf("leader election retry period must be greater than zero")
+	}
+	if c.LeaseDuration <= c.RenewDeadline {
+		return errors.Newf("leader election lease duration (%s) must be greater than renew deadline (%s)", c.LeaseDuration, c.RenewDeadline)
+	}
+	if c.RenewDeadline <= time.Duration(leaderelection.JitterFactor*float64(c.RetryPeriod)) {
+		return errors.Newf("leader election renew deadline (%s) must be greater than %.1f * retry period (%s)", c.RenewDeadline, leaderelection.JitterFactor, c.RetryPeriod)
+	}
+	return nil
+}
+
 // runLeaderElection performs a leader election on a lock with given name in
 // the namespace that the operator is deployed in.
 // When the leader election is won, the given callback is called.
 // When the leader election is was won once, but then the leadership is lost, the process is killed.
+// When the given context is canceled (operator shutdown), the lock is released, so another
+// instance can take over without waiting for the lease to expire, and the function returns.
 // The given ready probe is set, as soon as this process became the leader, or a new leader
 // is detected.
-func (o *Operator) runLeaderElection(lockName, label string, onStart func(stop <-chan struct{}), readyProbe *probe.ReadyProbe) {
+func (o *Operator) runLeaderElection(ctx context.Context, lockName, label string, onStart func(stop <-chan struct{}), readyProbe *probe.ReadyProbe) {
 	namespace := o.Config.Namespace
 	kubecli := o.Dependencies.KubeCli
 	log := o.log.With().Str("lock-name", lockName).Logger()
@@ -59,7 +110,7 @@ func (o *Operator) runLeaderElection(lockName, label string, onStart func(stop <
 			o.Dependencies.EventRecorder.Event(eventTarget, v1.EventTypeNormal, reason, message)
 		}
 	}
-	rl, err := resourcelock.New(resourcelock.EndpointsResourceLock,
+	rl, err := resourcelock.New(o.Config.LeaderElection.LockType,
 		namespace,
 		lockName,
 		kubecli.CoreV1(),
@@ -72,14 +123,21 @@ func (o *Operator) runLeaderElection(lockName, label string, onStart func(stop <
 		log.Fatal().Err(err).Msg("Failed to create resource lock")
 	}
 
-	ctx := context.Background()
+	isLeader := leaderElectionIsLeader.WithLabelValues(lockName)
+	isLeader.Set(0)
+	var leading int32
+
 	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
-		Lock:          rl,
-		LeaseDuration: 15 * time.Second,
-		RenewDeadline: 10 * time.Second,
-		RetryPeriod:   2 * time.Second,
+		Lock:            rl,
+		LeaseDuration:   o.Config.LeaderElection.LeaseDuration,
+		RenewDeadline:   o.Config.LeaderElection.RenewDeadline,
+		RetryPeriod:     o.Config.LeaderElection.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            lockName,
 		Callbacks: leaderelection.LeaderCallbacks{
 			OnStartedLeading: func(ctx context.Context) {
+				atomic.StoreInt32(&leading, 1)
+				isLeader.Set(1)
 				recordEvent("Leader Election Won", fmt.Sprintf("Pod %s is running as leader", o.Config.PodName))
 				readyProbe.SetReady()
 				if err := o.setRoleLabel(log, label, constants.LabelRoleLeader); err != nil {
@@ -89,6 +147,15 @@ func (o *Operator) runLeaderElection(lockName, label string, onStart func(stop <
 				onStart(ctx.Done())
 			},
 			OnStoppedLeading: func() {
+				isLeader.Set(0)
+				if ctx.Err() != nil {
+					// Operator is shutting down, lock is released
+					if atomic.LoadInt32(&leading) == 1 {
+						recordEvent("Leadership Released", fmt.Sprintf("Pod %s released the leadership on shutdown", o.Config.PodName))
+						log.Info().Msg("Leadership released on shutdown")
+					}
+					return
+				}
 				recordEvent("Stop Leading", fmt.Sprintf("Pod %s is stopping to run as leader", o.Config.PodName))
 				log.Info().Msg("Stop leading. Terminating process")
 				os.Exit(1)
@@ -101,7 +168,7 @@ func (o *Operator) runLeaderElection(lockName, label string, onStart func(stop <
 	})
 }
 
-func (o *Operator) runWithoutLeaderElection(lockName, label string, onStart func(stop <-chan struct{}), readyProbe *probe.ReadyProbe) {
+func (o *Operator) runWithoutLeaderElection(ctx context.Context, lockName, label string, onStart func(stop <-chan struct{}), readyProbe *probe.ReadyProbe) {
 	log := o.log.With().Str("lock-name", lockName).Logger()
 	eventTarget := o.getLeaderElectionEventTarget(log)
 	recordEvent := func(reason, message string) {
@@ -109,9 +176,9 @@ func (o *Operator) runWithoutLeaderElection(lockName, label string, onStart func
 			o.Dependencies.EventRecorder.Event(eventTarget, v1.EventTypeNormal, reason, message)
 		}
 	}
-	ctx := context.Background()
 
 	recordEvent("Leader Election Skipped", fmt.Sprintf("Pod %s is running as leader", o.Config.PodName))
+	leaderElectionIsLeader.WithLabelValues(lockName).Set(1)
 	readyProbe.SetReady()
 	if err := o.setRoleLabel(log, label, constants.LabelRoleLeader); err != nil {
 		log.Error().Msg("Cannot set leader role on Pod. Terminating process")
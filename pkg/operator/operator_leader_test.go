@@ -0,0 +1,66 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package operator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+func Test_LeaderElectionConfig_Validate(t *testing.T) {
+	valid := LeaderElectionConfig{
+		LockType:      DefaultLeaderElectionLockType,
+		LeaseDuration: DefaultLeaderElectionLeaseDuration,
+		RenewDeadline: DefaultLeaderElectionRenewDeadline,
+		RetryPeriod:   DefaultLeaderElectionRetryPeriod,
+	}
+	require.NoError(t, valid.Validate())
+
+	leases := valid
+	leases.LockType = resourcelock.LeasesResourceLock
+	require.NoError(t, leases.Validate())
+
+	testCases := map[string]func(c *LeaderElectionConfig){
+		"unknown lock type": func(c *LeaderElectionConfig) {
+			c.LockType = resourcelock.ConfigMapsResourceLock
+		},
+		"missing retry period": func(c *LeaderElectionConfig) {
+			c.RetryPeriod = 0
+		},
+		"lease duration not greater than renew deadline": func(c *LeaderElectionConfig) {
+			c.LeaseDuration = c.RenewDeadline
+		},
+		"renew deadline too short for retry period": func(c *LeaderElectionConfig) {
+			c.RenewDeadline = 2 * time.Second
+		},
+	}
+
+	for name, mod := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := valid
+			mod(&c)
+			require.Error(t, c.Validate())
+		})
+	}
+}
@@ -0,0 +1,308 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package operator
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/arangodb/kube-arangodb/pkg/operator/scope"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// WatchConfig defines the namespaces and the resources handled by the operator.
+// Applies to the ArangoDeployment and ArangoDeploymentReplication operators.
+type WatchConfig struct {
+	// Namespaces watched by the operator. When empty and NamespaceSelector is not set,
+	// only the namespace of the operator is watched.
+	Namespaces []string
+	// NamespaceSelector is a label selector of the watched namespaces.
+	NamespaceSelector string
+	// Shard limits the resources handled by the operator.
+	Shard ShardConfig
+}
+
+// ShardConfig defines the part of the resources owned by a single operator (shard),
+// when multiple operators watch the same namespaces.
+type ShardConfig struct {
+	// Name of the shard, used as suffix of the leader election locks.
+	Name string
+	// Selector is a label selector of the resources owned by the shard.
+	Selector string
+	// Count of the shards. When greater than zero, resources are split between shards by the hash of namespace and name.
+	Count int
+	// Index of the shard (0 - Count-1), used when Count is greater than zero.
+	Index int
+}
+
+// IsEnabled returns true if the resources are split between multiple operators.
+func (s ShardConfig) IsEnabled() bool {
+	return s.Selector != "" || s.Count > 0
+}
+
+// GetName returns the name of the shard. Empty name is returned when sharding is disabled.
+func (s ShardConfig) GetName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	if s.Count > 0 {
+		return fmt.Sprintf("shard-%d", s.Index)
+	}
+	return ""
+}
+
+// Validate the watch configuration.
+func (w WatchConfig) Validate(s scope.Scope) error {
+	if w.NamespaceSelector != "" {
+		if len(w.Namespaces) > 0 {
+			return errors.Newf("namespaces and namespace selector cannot be used together")
+		}
+		if s.IsNamespaced() {
+			return errors.Newf("namespace selector requires access to namespaces, it cannot be used in %s scope", s)
+		}
+		if _, err := labels.Parse(w.NamespaceSelector); err != nil {
+			return errors.Wrapf(err, "invalid namespace selector")
+		}
+	}
+	if w.Shard.Selector != "" {
+		if _, err := labels.Parse(w.Shard.Selector); err != nil {
+			return errors.Wrapf(err, "invalid shard selector")
+		}
+	}
+	if w.Shard.Count < 0 {
+		return errors.Newf("shard count cannot be negative")
+	}
+	if w.Shard.Count > 0 && (w.Shard.Index < 0 || w.Shard.Index >= w.Shard.Count) {
+		return errors.Newf("shard index %d needs to be in range 0-%d", w.Shard.Index, w.Shard.Count-1)
+	}
+	if w.Shard.IsEnabled() && w.Shard.GetName() == "" {
+		return errors.Newf("shard name is required when shard selector is used")
+	}
+	return nil
+}
+
+// resourceKey returns the key of the resource in the maps of the operator.
+func resourceKey(obj meta.Object) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// watchFilter decides which resources are handled by the operator.
+type watchFilter struct {
+	config    WatchConfig
+	namespace string
+
+	namespaceSelector labels.Selector
+	shardSelector     labels.Selector
+
+	// namespaceLabels contains labels of all namespaces, used with namespace selector.
+	namespaceLock   sync.RWMutex
+	namespaceLabels map[string]labels.Set
+	onNamespace     []func(namespace string)
+}
+
+// newWatchFilter creates a watch filter for given configuration, namespace is the namespace of the operator.
+func newWatchFilter(config WatchConfig, namespace string) (*watchFilter, error) {
+	f := &watchFilter{
+		config:          config,
+		namespace:       namespace,
+		namespaceLabels: map[string]labels.Set{},
+	}
+
+	if config.NamespaceSelector != "" {
+		s, err := labels.Parse(config.NamespaceSelector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid namespace selector")
+		}
+		f.namespaceSelector = s
+	}
+
+	if config.Shard.Selector != "" {
+		s, err := labels.Parse(config.Shard.Selector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid shard selector")
+		}
+		f.shardSelector = s
+	}
+
+	return f, nil
+}
+
+// Namespaces returns the namespaces in which the resources are watched.
+// Empty namespace means all namespaces.
+func (f *watchFilter) Namespaces() []string {
+	if f.namespaceSelector != nil {
+		return []string{meta.NamespaceAll}
+	}
+	if len(f.config.Namespaces) > 0 {
+		return f.config.Namespaces
+	}
+	return []string{f.namespace}
+}
+
+// IsNamespaceWatched returns true if resources in the namespace are handled by the operator.
+func (f *watchFilter) IsNamespaceWatched(namespace string) bool {
+	if f.namespaceSelector == nil {
+		for _, ns := range f.Namespaces() {
+			if ns == namespace {
+				return true
+			}
+		}
+		return false
+	}
+
+	f.namespaceLock.RLock()
+	defer f.namespaceLock.RUnlock()
+
+	l, ok := f.namespaceLabels[namespace]
+	return ok && f.namespaceSelector.Matches(l)
+}
+
+// IsOwned returns true if the resource is handled by the operator.
+func (f *watchFilter) IsOwned(obj meta.Object) bool {
+	if !f.IsNamespaceWatched(obj.GetNamespace()) {
+		return false
+	}
+
+	if f.shardSelector != nil && !f.shardSelector.Matches(labels.Set(obj.GetLabels())) {
+		return false
+	}
+
+	if c := f.config.Shard.Count; c > 0 {
+		h := fnv.New32a()
+		h.Write([]byte(obj.GetNamespace() + "/" + obj.GetName()))
+		if int(h.Sum32()%uint32(c)) != f.config.Shard.Index {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LockName returns the name of the leader election lock for given operator.
+func (f *watchFilter) LockName(name string) string {
+	if shard := f.config.Shard.GetName(); shard != "" {
+		return name + "-" + shard
+	}
+	return name
+}
+
+// OnNamespaceChange registers the callback called when the namespace starts or stops to be watched.
+// Used only with namespace selector.
+func (f *watchFilter) OnNamespaceChange(cb func(namespace string)) {
+	f.namespaceLock.Lock()
+	defer f.namespaceLock.Unlock()
+
+	f.onNamespace = append(f.onNamespace, cb)
+}
+
+// setNamespace updates the labels of the namespace, nil labels mean that the namespace is removed.
+func (f *watchFilter) setNamespace(namespace string, l labels.Set) {
+	f.namespaceLock.Lock()
+	old, existed := f.namespaceLabels[namespace]
+	if l == nil {
+		delete(f.namespaceLabels, namespace)
+	} else {
+		f.namespaceLabels[namespace] = l
+	}
+	callbacks := f.onNamespace
+	f.namespaceLock.Unlock()
+
+	wasWatched := existed && f.namespaceSelector.Matches(old)
+	isWatched := l != nil && f.namespaceSelector.Matches(l)
+	if wasWatched == isWatched {
+		return
+	}
+
+	for _, cb := range callbacks {
+		cb(namespace)
+	}
+}
+
+// newNamespaceWatcher creates a watcher which keeps the labels of namespaces up to date.
+func (o *Operator) newNamespaceWatcher() *k8sutil.ResourceWatcher {
+	f := o.watch
+	return k8sutil.NewResourceWatcher(
+		o.log,
+		o.Dependencies.KubeCli.CoreV1().RESTClient(),
+		"namespaces",
+		meta.NamespaceAll,
+		&core.Namespace{},
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if ns, ok := obj.(*core.Namespace); ok {
+					f.setNamespace(ns.GetName(), labels.Set(ns.GetLabels()))
+				}
+			},
+			UpdateFunc: func(_, obj interface{}) {
+				if ns, ok := obj.(*core.Namespace); ok {
+					f.setNamespace(ns.GetName(), labels.Set(ns.GetLabels()))
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				if ns, ok := obj.(*core.Namespace); ok {
+					f.setNamespace(ns.GetName(), nil)
+				} else if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					if ns, ok := tombstone.Obj.(*core.Namespace); ok {
+						f.setNamespace(ns.GetName(), nil)
+					}
+				}
+			},
+		})
+}
+
+// runWatchers watches the resources in all watched namespaces till the stop channel is closed.
+// With namespace selector, the namespaces are watched as well and onNamespaceChange is called for
+// the namespace which starts or stops to be watched.
+func (o *Operator) runWatchers(stop <-chan struct{}, getter cache.Getter, resource string, objType runtime.Object,
+	h cache.ResourceEventHandlerFuncs, onNamespaceChange func(namespace string)) {
+	var wg sync.WaitGroup
+
+	if o.watch.namespaceSelector != nil {
+		// Resources in namespaces which are not known yet are ignored by the watchers,
+		// so they are synchronized when the namespace starts to be watched.
+		o.watch.OnNamespaceChange(onNamespaceChange)
+		nw := o.newNamespaceWatcher()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nw.Run(stop)
+		}()
+	}
+
+	for _, ns := range o.watch.Namespaces() {
+		rw := k8sutil.NewResourceWatcher(o.log, getter, resource, ns, objType, h)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rw.Run(stop)
+		}()
+	}
+
+	wg.Wait()
+}
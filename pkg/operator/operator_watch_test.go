@@ -0,0 +1,135 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package operator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arangodb/kube-arangodb/pkg/operator/scope"
+
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func testObject(namespace, name string, l map[string]string) meta.Object {
+	return &meta.ObjectMeta{
+		Namespace: namespace,
+		Name:      name,
+		Labels:    l,
+	}
+}
+
+func Test_WatchConfig_Validate(t *testing.T) {
+	require.NoError(t, WatchConfig{}.Validate(scope.NamespacedScope))
+	require.NoError(t, WatchConfig{Namespaces: []string{"a", "b"}}.Validate(scope.NamespacedScope))
+	require.NoError(t, WatchConfig{NamespaceSelector: "tenant=true"}.Validate(scope.LegacyScope))
+	require.NoError(t, WatchConfig{Shard: ShardConfig{Count: 3, Index: 2}}.Validate(scope.LegacyScope))
+	require.NoError(t, WatchConfig{Shard: ShardConfig{Name: "gold", Selector: "tier=gold"}}.Validate(scope.LegacyScope))
+
+	require.Error(t, WatchConfig{NamespaceSelector: "tenant=true"}.Validate(scope.NamespacedScope))
+	require.Error(t, WatchConfig{Namespaces: []string{"a"}, NamespaceSelector: "tenant=true"}.Validate(scope.LegacyScope))
+	require.Error(t, WatchConfig{NamespaceSelector: "tenant in ("}.Validate(scope.LegacyScope))
+	require.Error(t, WatchConfig{Shard: ShardConfig{Count: 3, Index: 3}}.Validate(scope.LegacyScope))
+	require.Error(t, WatchConfig{Shard: ShardConfig{Count: -1}}.Validate(scope.LegacyScope))
+	require.Error(t, WatchConfig{Shard: ShardConfig{Selector: "tier=gold"}}.Validate(scope.LegacyScope))
+}
+
+func Test_WatchFilter_Namespaces(t *testing.T) {
+	f, err := newWatchFilter(WatchConfig{}, "operator")
+	require.NoError(t, err)
+	require.Equal(t, []string{"operator"}, f.Namespaces())
+	require.True(t, f.IsOwned(testObject("operator", "example", nil)))
+	require.False(t, f.IsOwned(testObject("other", "example", nil)))
+	require.Equal(t, "arango-deployment-operator", f.LockName("arango-deployment-operator"))
+
+	f, err = newWatchFilter(WatchConfig{Namespaces: []string{"a", "b"}}, "operator")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, f.Namespaces())
+	require.True(t, f.IsOwned(testObject("b", "example", nil)))
+	require.False(t, f.IsOwned(testObject("operator", "example", nil)))
+}
+
+func Test_WatchFilter_NamespaceSelector(t *testing.T) {
+	f, err := newWatchFilter(WatchConfig{NamespaceSelector: "tenant=true"}, "operator")
+	require.NoError(t, err)
+	require.Equal(t, []string{meta.NamespaceAll}, f.Namespaces())
+
+	var changes []string
+	f.OnNamespaceChange(func(namespace string) {
+		changes = append(changes, namespace)
+	})
+
+	f.setNamespace("a", labels.Set{"tenant": "true"})
+	f.setNamespace("b", labels.Set{})
+	require.True(t, f.IsOwned(testObject("a", "example", nil)))
+	require.False(t, f.IsOwned(testObject("b", "example", nil)))
+	require.Equal(t, []string{"a"}, changes)
+
+	// Labels changed, but namespace is still watched
+	f.setNamespace("a", labels.Set{"tenant": "true", "team": "x"})
+	require.Equal(t, []string{"a"}, changes)
+
+	f.setNamespace("b", labels.Set{"tenant": "true"})
+	f.setNamespace("a", nil)
+	require.False(t, f.IsOwned(testObject("a", "example", nil)))
+	require.True(t, f.IsOwned(testObject("b", "example", nil)))
+	require.Equal(t, []string{"a", "b", "a"}, changes)
+}
+
+func Test_WatchFilter_ShardSelector(t *testing.T) {
+	f, err := newWatchFilter(WatchConfig{Shard: ShardConfig{Name: "gold", Selector: "tier=gold"}}, "operator")
+	require.NoError(t, err)
+
+	require.True(t, f.IsOwned(testObject("operator", "a", map[string]string{"tier": "gold"})))
+	require.False(t, f.IsOwned(testObject("operator", "b", map[string]string{"tier": "silver"})))
+	require.False(t, f.IsOwned(testObject("operator", "c", nil)))
+	require.Equal(t, "arango-deployment-operator-gold", f.LockName("arango-deployment-operator"))
+}
+
+func Test_WatchFilter_ShardHash(t *testing.T) {
+	count := 3
+	filters := make([]*watchFilter, count)
+	for i := range filters {
+		f, err := newWatchFilter(WatchConfig{Shard: ShardConfig{Count: count, Index: i}}, "operator")
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("arango-deployment-operator-shard-%d", i), f.LockName("arango-deployment-operator"))
+		filters[i] = f
+	}
+
+	perShard := make([]int, count)
+	for i := 0; i < 300; i++ {
+		obj := testObject("operator", fmt.Sprintf("deployment-%d", i), nil)
+		owners := 0
+		for s, f := range filters {
+			if f.IsOwned(obj) {
+				owners++
+				perShard[s]++
+			}
+		}
+		require.Equal(t, 1, owners, "resource needs to be owned by exactly one shard")
+	}
+
+	for _, c := range perShard {
+		require.NotZero(t, c)
+	}
+}
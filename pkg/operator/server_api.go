@@ -45,22 +45,34 @@ func (o *Operator) GetDeployments() ([]server.Deployment, error) {
 		result = append(result, d)
 	}
 	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace() != result[j].Namespace() {
+			return result[i].Namespace() < result[j].Namespace()
+		}
 		return result[i].Name() < result[j].Name()
 	})
 	return result, nil
 }
 
-// GetDeployment returns detailed information for a deployment, managed by the operator, with given name
-func (o *Operator) GetDeployment(name string) (server.Deployment, error) {
+// GetDeployment returns detailed information for a deployment, managed by the operator, with given name.
+// Namespace can be empty if the name is unique across all watched namespaces.
+func (o *Operator) GetDeployment(namespace, name string) (server.Deployment, error) {
 	o.Dependencies.LivenessProbe.Lock()
 	defer o.Dependencies.LivenessProbe.Unlock()
 
+	var result server.Deployment
 	for _, d := range o.deployments {
-		if d.Name() == name {
-			return d, nil
+		if d.Name() != name || (namespace != "" && d.Namespace() != namespace) {
+			continue
+		}
+		if result != nil {
+			return nil, errors.Wrapf(server.BadRequestError, "deployment %s exists in multiple namespaces, namespace is required", name)
 		}
+		result = d
 	}
-	return nil, errors.WithStack(server.NotFoundError)
+	if result == nil {
+		return nil, errors.WithStack(server.NotFoundError)
+	}
+	return result, nil
 }
 
 // DeploymentReplicationOperator provides access to the deployment replication operator.
@@ -78,22 +90,34 @@ func (o *Operator) GetDeploymentReplications() ([]server.DeploymentReplication,
 		result = append(result, d)
 	}
 	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace() != result[j].Namespace() {
+			return result[i].Namespace() < result[j].Namespace()
+		}
 		return result[i].Name() < result[j].Name()
 	})
 	return result, nil
 }
 
-// GetDeploymentReplication returns detailed information for a deployment replication, managed by the operator, with given name
-func (o *Operator) GetDeploymentReplication(name string) (server.DeploymentReplication, error) {
+// GetDeploymentReplication returns detailed information for a deployment replication, managed by the operator, with given name.
+// Namespace can be empty if the name is unique across all watched namespaces.
+func (o *Operator) GetDeploymentReplication(namespace, name string) (server.DeploymentReplication, error) {
 	o.Dependencies.LivenessProbe.Lock()
 	defer o.Dependencies.LivenessProbe.Unlock()
 
+	var result server.DeploymentReplication
 	for _, d := range o.deploymentReplications {
-		if d.Name() == name {
-			return d, nil
+		if d.Name() != name || (namespace != "" && d.Namespace() != namespace) {
+			continue
+		}
+		if result != nil {
+			return nil, errors.Wrapf(server.BadRequestError, "deployment replication %s exists in multiple namespaces, namespace is required", name)
 		}
+		result = d
 	}
-	return nil, errors.WithStack(server.NotFoundError)
+	if result == nil {
+		return nil, errors.WithStack(server.NotFoundError)
+	}
+	return result, nil
 }
 
 // StorageOperator provides the local storage operator (if any)
@@ -0,0 +1,368 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package operator
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/server"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupOperator provides access to the backup operator (if any).
+func (o *Operator) BackupOperator() server.BackupOperator {
+	if !o.Config.EnableBackup {
+		return nil
+	}
+	return o
+}
+
+// backupNamespaces returns the namespaces in which backups and backup policies are listed.
+// Empty namespace refers to all watched namespaces.
+func (o *Operator) backupNamespaces(namespace string) []string {
+	if namespace == "" {
+		return o.watch.Namespaces()
+	}
+	if !o.watch.IsNamespaceWatched(namespace) {
+		return nil
+	}
+	return []string{namespace}
+}
+
+// GetBackups returns basic information for all backups managed by the operator in the given namespace.
+// Empty namespace refers to all watched namespaces.
+func (o *Operator) GetBackups(ctx context.Context, namespace string) ([]server.Backup, error) {
+	var result []server.Backup
+	for _, ns := range o.backupNamespaces(namespace) {
+		ctxChild, cancel := context.WithTimeout(ctx, k8sutil.GetRequestTimeout())
+		list, err := o.Dependencies.CRCli.BackupV1().ArangoBackups(ns).List(ctxChild, meta.ListOptions{})
+		cancel()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		for i := range list.Items {
+			if o.watch.IsNamespaceWatched(list.Items[i].GetNamespace()) {
+				result = append(result, serverBackup{backup: &list.Items[i]})
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace() != result[j].Namespace() {
+			return result[i].Namespace() < result[j].Namespace()
+		}
+		return result[i].Name() < result[j].Name()
+	})
+	return result, nil
+}
+
+// GetBackup returns detailed information for a backup, managed by the operator, with given namespace and name
+func (o *Operator) GetBackup(ctx context.Context, namespace, name string) (server.Backup, error) {
+	if !o.watch.IsNamespaceWatched(namespace) {
+		return nil, errors.WithStack(server.NotFoundError)
+	}
+
+	ctxChild, cancel := context.WithTimeout(ctx, k8sutil.GetRequestTimeout())
+	defer cancel()
+
+	backup, err := o.Dependencies.CRCli.BackupV1().ArangoBackups(namespace).Get(ctxChild, name, meta.GetOptions{})
+	if err != nil {
+		if k8sutil.IsNotFound(err) {
+			return nil, errors.WithStack(server.NotFoundError)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return serverBackup{backup: backup}, nil
+}
+
+// GetBackupPolicies returns basic information for all backup policies managed by the operator in the given namespace.
+// Empty namespace refers to all watched namespaces.
+func (o *Operator) GetBackupPolicies(ctx context.Context, namespace string) ([]server.BackupPolicy, error) {
+	var result []server.BackupPolicy
+	for _, ns := range o.backupNamespaces(namespace) {
+		ctxChild, cancel := context.WithTimeout(ctx, k8sutil.GetRequestTimeout())
+		list, err := o.Dependencies.CRCli.BackupV1().ArangoBackupPolicies(ns).List(ctxChild, meta.ListOptions{})
+		cancel()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		for i := range list.Items {
+			if o.watch.IsNamespaceWatched(list.Items[i].GetNamespace()) {
+				result = append(result, serverBackupPolicy{policy: &list.Items[i]})
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace() != result[j].Namespace() {
+			return result[i].Namespace() < result[j].Namespace()
+		}
+		return result[i].Name() < result[j].Name()
+	})
+	return result, nil
+}
+
+// GetBackupPolicy returns detailed information for a backup policy, managed by the operator, with given namespace and name
+func (o *Operator) GetBackupPolicy(ctx context.Context, namespace, name string) (server.BackupPolicy, error) {
+	if !o.watch.IsNamespaceWatched(namespace) {
+		return nil, errors.WithStack(server.NotFoundError)
+	}
+
+	ctxChild, cancel := context.WithTimeout(ctx, k8sutil.GetRequestTimeout())
+	defer cancel()
+
+	policy, err := o.Dependencies.CRCli.BackupV1().ArangoBackupPolicies(namespace).Get(ctxChild, name, meta.GetOptions{})
+	if err != nil {
+		if k8sutil.IsNotFound(err) {
+			return nil, errors.WithStack(server.NotFoundError)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return serverBackupPolicy{policy: policy}, nil
+}
+
+// serverBackup implements server.Backup for an ArangoBackup.
+type serverBackup struct {
+	backup *backupApi.ArangoBackup
+}
+
+// Name returns the name of the backup.
+func (b serverBackup) Name() string {
+	return b.backup.GetName()
+}
+
+// Namespace returns the namespace that contains the backup.
+func (b serverBackup) Namespace() string {
+	return b.backup.GetNamespace()
+}
+
+// DeploymentName returns the name of the deployment of the backup.
+func (b serverBackup) DeploymentName() string {
+	return b.backup.Spec.Deployment.Name
+}
+
+// PolicyName returns the name of the policy which created the backup, if any.
+func (b serverBackup) PolicyName() string {
+	if p := b.backup.Spec.PolicyName; p != nil {
+		return *p
+	}
+	return ""
+}
+
+// StateColor determinates the state of the backup in color codes.
+func (b serverBackup) StateColor() server.StateColor {
+	switch b.backup.Status.State {
+	case backupApi.ArangoBackupStateReady:
+		return server.StateGreen
+	case backupApi.ArangoBackupStateFailed, backupApi.ArangoBackupStateUploadError, backupApi.ArangoBackupStateDownloadError,
+		backupApi.ArangoBackupStateUnavailable, backupApi.ArangoBackupStateDeleted:
+		return server.StateRed
+	}
+	return server.StateYellow
+}
+
+// State returns the current state of the backup.
+func (b serverBackup) State() string {
+	return string(b.backup.Status.State)
+}
+
+// StateMessage returns the message of the current state of the backup.
+func (b serverBackup) StateMessage() string {
+	return b.backup.Status.Message
+}
+
+// StateTime returns the time of the last state change of the backup.
+func (b serverBackup) StateTime() time.Time {
+	return b.backup.Status.Time.Time
+}
+
+// Progress returns the job ID and the progress of the running upload or download.
+func (b serverBackup) Progress() (string, string) {
+	if p := b.backup.Status.Progress; p != nil {
+		return p.JobID, p.Progress
+	}
+	return "", ""
+}
+
+// Available returns true if the backup can be used for restore.
+func (b serverBackup) Available() bool {
+	return b.backup.Status.Available
+}
+
+// BackupID returns the ID of the backup in the database.
+func (b serverBackup) BackupID() string {
+	if d := b.backup.Status.Backup; d != nil {
+		return d.ID
+	}
+	return ""
+}
+
+// Version returns the ArangoDB version of the backup.
+func (b serverBackup) Version() string {
+	if d := b.backup.Status.Backup; d != nil {
+		return d.Version
+	}
+	return ""
+}
+
+// SizeInBytes returns the size of the backup.
+func (b serverBackup) SizeInBytes() uint64 {
+	if d := b.backup.Status.Backup; d != nil {
+		return d.SizeInBytes
+	}
+	return 0
+}
+
+// NumberOfDBServers returns the number of DBServers of the backup.
+func (b serverBackup) NumberOfDBServers() int {
+	if d := b.backup.Status.Backup; d != nil {
+		return int(d.NumberOfDBServers)
+	}
+	return 0
+}
+
+// CreatedAt returns the creation time of the backup in the database.
+func (b serverBackup) CreatedAt() *time.Time {
+	if d := b.backup.Status.Backup; d != nil && !d.CreationTimestamp.IsZero() {
+		t := d.CreationTimestamp.Time
+		return &t
+	}
+	return nil
+}
+
+// Upload returns the status of the upload of the backup.
+func (b serverBackup) Upload() server.BackupTransferInfo {
+	var info server.BackupTransferInfo
+	if u := b.backup.Spec.Upload; u != nil {
+		info.RepositoryURL = u.RepositoryURL
+	}
+
+	switch b.backup.Status.State {
+	case backupApi.ArangoBackupStateUpload:
+		info.Status = server.TransferStatusPending
+	case backupApi.ArangoBackupStateUploading:
+		info.Status = server.TransferStatusRunning
+	case backupApi.ArangoBackupStateUploadError:
+		info.Status = server.TransferStatusFailed
+	default:
+		if d := b.backup.Status.Backup; d != nil && d.Uploaded != nil && *d.Uploaded {
+			info.Status = server.TransferStatusCompleted
+		} else if b.backup.Spec.Upload != nil {
+			info.Status = server.TransferStatusPending
+		}
+	}
+	return info
+}
+
+// Download returns the status of the download of the backup.
+func (b serverBackup) Download() server.BackupTransferInfo {
+	var info server.BackupTransferInfo
+	if d := b.backup.Spec.Download; d != nil {
+		info.RepositoryURL = d.RepositoryURL
+	} else {
+		return info
+	}
+
+	switch b.backup.Status.State {
+	case backupApi.ArangoBackupStateNone, backupApi.ArangoBackupStatePending, backupApi.ArangoBackupStateScheduled,
+		backupApi.ArangoBackupStateDownload:
+		info.Status = server.TransferStatusPending
+	case backupApi.ArangoBackupStateDownloading:
+		info.Status = server.TransferStatusRunning
+	case backupApi.ArangoBackupStateDownloadError:
+		info.Status = server.TransferStatusFailed
+	default:
+		// Download is finished in all other states
+		if d := b.backup.Status.Backup; d != nil && d.Downloaded != nil && *d.Downloaded {
+			info.Status = server.TransferStatusCompleted
+		}
+	}
+	return info
+}
+
+// serverBackupPolicy implements server.BackupPolicy for an ArangoBackupPolicy.
+type serverBackupPolicy struct {
+	policy *backupApi.ArangoBackupPolicy
+}
+
+// Name returns the name of the backup policy.
+func (p serverBackupPolicy) Name() string {
+	return p.policy.GetName()
+}
+
+// Namespace returns the namespace that contains the backup policy.
+func (p serverBackupPolicy) Namespace() string {
+	return p.policy.GetNamespace()
+}
+
+// StateColor determinates the state of the backup policy in color codes.
+func (p serverBackupPolicy) StateColor() server.StateColor {
+	if p.policy.Status.Message != "" {
+		return server.StateRed
+	}
+	if p.policy.Status.Scheduled.IsZero() {
+		return server.StateYellow
+	}
+	return server.StateGreen
+}
+
+// Schedule returns the cron schedule of the backup policy.
+func (p serverBackupPolicy) Schedule() string {
+	return p.policy.Spec.Schedule
+}
+
+// DeploymentSelector returns the selector of the deployments of the backup policy.
+func (p serverBackupPolicy) DeploymentSelector() string {
+	if s := p.policy.Spec.DeploymentSelector; s != nil {
+		return meta.FormatLabelSelector(s)
+	}
+	return ""
+}
+
+// UploadRepositoryURL returns the repository to which the backups are uploaded, if any.
+func (p serverBackupPolicy) UploadRepositoryURL() string {
+	if u := p.policy.Spec.BackupTemplate.Upload; u != nil {
+		return u.RepositoryURL
+	}
+	return ""
+}
+
+// NextSchedule returns the time of the next backup.
+func (p serverBackupPolicy) NextSchedule() *time.Time {
+	if p.policy.Status.Scheduled.IsZero() {
+		return nil
+	}
+	t := p.policy.Status.Scheduled.Time
+	return &t
+}
+
+// Message returns the error message of the backup policy, if any.
+func (p serverBackupPolicy) Message() string {
+	return p.policy.Status.Message
+}
@@ -89,6 +89,8 @@ type DeploymentReplication struct {
 	inspectTrigger         trigger.Trigger
 	recentInspectionErrors int
 	clientCache            client.ClientCache
+	lagMetricsStatus       api.EndpointStatus // Status used for the last update of the lag metrics
+	lagObservedAt          time.Time          // Time at which the status of the destination was observed for the last time
 }
 
 // New creates a new DeploymentReplication from the given API object.
@@ -128,6 +130,15 @@ func (dr *DeploymentReplication) Delete() {
 	}
 }
 
+// Release stops the handling of the deployment replication which still exists,
+// but is not in scope of the operator anymore.
+func (dr *DeploymentReplication) Release() {
+	dr.deps.Log.Info().Msg("deployment replication is released by the operator")
+	if atomic.CompareAndSwapInt32(&dr.stopped, 0, 1) {
+		close(dr.stopCh)
+	}
+}
+
 // send given event into the deployment replication event queue.
 func (dr *DeploymentReplication) send(ev *deploymentReplicationEvent) {
 	select {
@@ -160,6 +171,8 @@ func (dr *DeploymentReplication) run() {
 		select {
 		case <-dr.stopCh:
 			// We're being stopped.
+			dr.removeLagMetrics()
+			dr.removeEndpointMetrics()
 			return
 
 		case event := <-dr.eventCh:
@@ -0,0 +1,144 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package replication
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	"github.com/arangodb/kube-arangodb/pkg/metrics"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+// endpointKind identifies the source or destination endpoint of the replication
+type endpointKind string
+
+const (
+	endpointSource      endpointKind = "source"
+	endpointDestination endpointKind = "destination"
+
+	endpointLabel = "endpoint"
+
+	unreachableFailureThreshold = 3 // Consecutive failures after which an endpoint is reported as unreachable
+)
+
+var (
+	endpointConsecutiveFailures = metrics.MustRegisterGaugeVec(metricsComponent, "endpoint_consecutive_failures",
+		"Number of failed requests to the syncmaster of the endpoint since the last successful one", metrics.Namespace, replicationLabel, endpointLabel)
+)
+
+// probeSyncMaster checks that the syncmaster of the given endpoint is reachable.
+func (dr *DeploymentReplication) probeSyncMaster(ctx context.Context, epSpec api.EndpointSpec) error {
+	c, err := dr.createSyncMasterClient(epSpec)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := c.Health(ctx); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// recordEndpointHealth updates the health of the given endpoint with the result
+// of a request to its syncmaster and sets the matching Unreachable condition.
+// Returns true when the status has been changed.
+func (dr *DeploymentReplication) recordEndpointHealth(kind endpointKind, err error) bool {
+	health, conditionType := &dr.status.SourceHealth, api.ConditionTypeSourceUnreachable
+	if kind == endpointDestination {
+		health, conditionType = &dr.status.DestinationHealth, api.ConditionTypeDestinationUnreachable
+	}
+	changed := false
+	if *health == nil {
+		*health = &api.EndpointHealth{}
+		changed = true
+	}
+	h := *health
+
+	now := metav1.Now()
+	if err != nil {
+		h.RecordFailure(now, err)
+		changed = true
+	} else if h.ConsecutiveFailures > 0 || h.LastSuccessTime == nil || now.Sub(h.LastSuccessTime.Time) >= maxInspectionInterval {
+		// Time of the last success is refreshed at most once per maxInspectionInterval,
+		// so a stable endpoint does not change the status with every inspection
+		h.RecordSuccess(now)
+		changed = true
+	}
+	endpointConsecutiveFailures.WithLabelValues(dr.apiObject.GetNamespace(), dr.apiObject.GetName(), string(kind)).Set(float64(h.ConsecutiveFailures))
+
+	wasUnreachable := dr.status.Conditions.IsTrue(conditionType)
+	if h.ConsecutiveFailures >= unreachableFailureThreshold {
+		if !wasUnreachable {
+			dr.deps.Log.Warn().Err(err).Str("endpoint", string(kind)).Msg("Syncmaster is unreachable")
+			dr.createEvent(k8sutil.NewReplicationEndpointUnreachableEvent(dr.apiObject, string(kind), err))
+		}
+		if dr.status.Conditions.Update(conditionType, true, "Unreachable", h.LastError) {
+			changed = true
+		}
+	} else if err == nil {
+		if wasUnreachable {
+			dr.deps.Log.Info().Str("endpoint", string(kind)).Msg("Syncmaster is reachable again")
+			dr.createEvent(k8sutil.NewReplicationEndpointReachableEvent(dr.apiObject, string(kind)))
+		}
+		if dr.status.Conditions.Update(conditionType, false, "Reachable", "Syncmaster is reachable") {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// getUnreachableInterval returns the inspection interval while an endpoint cannot be reached.
+// The interval doubles with every consecutive failure, up to maxInspectionInterval.
+// Returns 0 when both endpoints are reachable.
+func (dr *DeploymentReplication) getUnreachableInterval() time.Duration {
+	failures := dr.status.SourceHealth.GetConsecutiveFailures()
+	if f := dr.status.DestinationHealth.GetConsecutiveFailures(); f > failures {
+		failures = f
+	}
+	return getBackoffInterval(failures)
+}
+
+// getBackoffInterval returns minInspectionInterval doubled for every failure after the first one,
+// up to maxInspectionInterval. Returns 0 without failures.
+func getBackoffInterval(failures int) time.Duration {
+	if failures == 0 {
+		return 0
+	}
+	interval := minInspectionInterval
+	for i := 1; i < failures && interval < maxInspectionInterval; i++ {
+		interval *= 2
+	}
+	if interval > maxInspectionInterval {
+		interval = maxInspectionInterval
+	}
+	return interval
+}
+
+// removeEndpointMetrics removes the endpoint metric series of the deployment replication.
+func (dr *DeploymentReplication) removeEndpointMetrics() {
+	for _, kind := range []endpointKind{endpointSource, endpointDestination} {
+		endpointConsecutiveFailures.DeleteLabelValues(dr.apiObject.GetNamespace(), dr.apiObject.GetName(), string(kind))
+	}
+}
@@ -0,0 +1,74 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package replication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+)
+
+func TestGetBackoffInterval(t *testing.T) {
+	require.Equal(t, time.Duration(0), getBackoffInterval(0))
+	require.Equal(t, time.Second, getBackoffInterval(1))
+	require.Equal(t, 2*time.Second, getBackoffInterval(2))
+	require.Equal(t, 32*time.Second, getBackoffInterval(6))
+	require.Equal(t, maxInspectionInterval, getBackoffInterval(7))
+	require.Equal(t, maxInspectionInterval, getBackoffInterval(1000))
+}
+
+func TestRecordEndpointHealthStable(t *testing.T) {
+	dr := &DeploymentReplication{
+		apiObject: &api.ArangoDeploymentReplication{
+			ObjectMeta: metav1.ObjectMeta{Name: "health", Namespace: "test"},
+		},
+		deps: Dependencies{
+			Log:           zerolog.Nop(),
+			EventRecorder: record.NewFakeRecorder(10),
+		},
+	}
+	defer dr.removeEndpointMetrics()
+
+	require.True(t, dr.recordEndpointHealth(endpointSource, nil))
+
+	// Stable endpoint does not change the status
+	status := *dr.status.DeepCopy()
+	require.False(t, dr.recordEndpointHealth(endpointSource, nil))
+	require.Equal(t, status, dr.status)
+
+	// Time of the last success is refreshed after the inspection interval
+	dr.status.SourceHealth.LastSuccessTime = &metav1.Time{Time: time.Now().Add(-maxInspectionInterval)}
+	require.True(t, dr.recordEndpointHealth(endpointSource, nil))
+
+	// Failures change the status
+	require.True(t, dr.recordEndpointHealth(endpointSource, errors.Newf("unreachable")))
+	require.Equal(t, 1, dr.status.SourceHealth.GetConsecutiveFailures())
+	require.True(t, dr.recordEndpointHealth(endpointSource, nil))
+	require.Equal(t, 0, dr.status.SourceHealth.GetConsecutiveFailures())
+	require.False(t, dr.recordEndpointHealth(endpointSource, nil))
+}
@@ -0,0 +1,155 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package replication
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	"github.com/arangodb/kube-arangodb/pkg/metrics"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+const (
+	metricsComponent = "deployment_replication"
+
+	replicationLabel = "replication"
+	databaseLabel    = "database"
+	collectionLabel  = "collection"
+)
+
+var (
+	databaseDelay = metrics.MustRegisterGaugeVec(metricsComponent, "database_delay",
+		"Highest delay of the destination shards of the database (in sec)", metrics.Namespace, replicationLabel, databaseLabel)
+	databaseShardsNotInSync = metrics.MustRegisterGaugeVec(metricsComponent, "database_shards_not_in_sync",
+		"Number of destination shards of the database which are not in sync", metrics.Namespace, replicationLabel, databaseLabel)
+	collectionDelay = metrics.MustRegisterGaugeVec(metricsComponent, "collection_delay",
+		"Highest delay of the destination shards of the collection (in sec)", metrics.Namespace, replicationLabel, databaseLabel, collectionLabel)
+	collectionShardsNotInSync = metrics.MustRegisterGaugeVec(metricsComponent, "collection_shards_not_in_sync",
+		"Number of destination shards of the collection which are not in sync", metrics.Namespace, replicationLabel, databaseLabel, collectionLabel)
+	lagExceeded = metrics.MustRegisterGaugeVec(metricsComponent, "lag_exceeded",
+		"1 if the LagExceeded condition is set, 0 otherwise", metrics.Namespace, replicationLabel)
+)
+
+// inspectLag updates the lag metrics and the LagExceeded condition from the observed status of the destination.
+// Returns true when the condition has changed.
+func (dr *DeploymentReplication) inspectLag(status api.EndpointStatus) bool {
+	dr.lagObservedAt = time.Now()
+	dr.updateLagMetrics(status, 0)
+
+	delay, shardsNotInSync := status.Lag()
+	return dr.evaluateLag(delay, shardsNotInSync)
+}
+
+// inspectLagUnavailable updates the lag metrics and the LagExceeded condition when the status of the destination
+// cannot be observed, e.g. the destination is not reachable or the synchronization is not active.
+// Delays keep growing from the last observed status of the destination.
+// Returns true when the condition has changed.
+func (dr *DeploymentReplication) inspectLagUnavailable() bool {
+	if dr.lagObservedAt.IsZero() {
+		// Nothing observed since the start of the operator
+		dr.lagObservedAt = time.Now()
+	}
+	elapsed := time.Since(dr.lagObservedAt)
+	dr.updateLagMetrics(dr.lagMetricsStatus, elapsed)
+
+	delay, shardsNotInSync := dr.lagMetricsStatus.Lag()
+	return dr.evaluateLag(delay+elapsed, shardsNotInSync)
+}
+
+// evaluateLag sets the LagExceeded condition for the given lag.
+// Returns true when the condition has changed.
+func (dr *DeploymentReplication) evaluateLag(delay time.Duration, shardsNotInSync int) bool {
+	ns, name := dr.apiObject.GetNamespace(), dr.apiObject.GetName()
+	lag := dr.apiObject.Spec.Lag
+	if lag == nil {
+		lagExceeded.WithLabelValues(ns, name).Set(0)
+		return dr.status.Conditions.Remove(api.ConditionTypeLagExceeded)
+	}
+
+	exceeded, reason := lag.IsExceeded(delay, shardsNotInSync)
+	wasExceeded := dr.status.Conditions.IsTrue(api.ConditionTypeLagExceeded)
+	if exceeded {
+		lagExceeded.WithLabelValues(ns, name).Set(1)
+		if wasExceeded {
+			// Reason contains the current delay, it is updated only when the threshold is crossed
+			return false
+		}
+		dr.deps.Log.Warn().Str("reason", reason).Msg("Replication lag exceeded")
+		dr.createEvent(k8sutil.NewReplicationLagExceededEvent(dr.apiObject, reason))
+		return dr.status.Conditions.Update(api.ConditionTypeLagExceeded, true, "Exceeded", reason)
+	}
+
+	lagExceeded.WithLabelValues(ns, name).Set(0)
+	if wasExceeded {
+		dr.deps.Log.Info().Msg("Replication lag back within thresholds")
+		dr.createEvent(k8sutil.NewReplicationLagResolvedEvent(dr.apiObject))
+	}
+	return dr.status.Conditions.Update(api.ConditionTypeLagExceeded, false, "WithinThresholds", "Replication lag is within the thresholds")
+}
+
+// updateLagMetrics sets the lag metrics of all databases and collections in the given status, with the elapsed
+// time added to the delays, and removes the series of databases and collections which are gone.
+func (dr *DeploymentReplication) updateLagMetrics(status api.EndpointStatus, elapsed time.Duration) {
+	ns, name := dr.apiObject.GetNamespace(), dr.apiObject.GetName()
+	databases := map[string]bool{}
+	collections := map[[2]string]bool{}
+	for _, db := range status.Databases {
+		databases[db.Name] = true
+		databaseDelay.WithLabelValues(ns, name, db.Name).Set(durationSeconds(db.Delay) + elapsed.Seconds())
+		databaseShardsNotInSync.WithLabelValues(ns, name, db.Name).Set(float64(db.ShardsNotInSync))
+		for _, col := range db.Collections {
+			collections[[2]string{db.Name, col.Name}] = true
+			collectionDelay.WithLabelValues(ns, name, db.Name, col.Name).Set(durationSeconds(col.Delay) + elapsed.Seconds())
+			collectionShardsNotInSync.WithLabelValues(ns, name, db.Name, col.Name).Set(float64(col.ShardsNotInSync))
+		}
+	}
+
+	for _, db := range dr.lagMetricsStatus.Databases {
+		if !databases[db.Name] {
+			databaseDelay.DeleteLabelValues(ns, name, db.Name)
+			databaseShardsNotInSync.DeleteLabelValues(ns, name, db.Name)
+		}
+		for _, col := range db.Collections {
+			if !collections[[2]string{db.Name, col.Name}] {
+				collectionDelay.DeleteLabelValues(ns, name, db.Name, col.Name)
+				collectionShardsNotInSync.DeleteLabelValues(ns, name, db.Name, col.Name)
+			}
+		}
+	}
+	dr.lagMetricsStatus = status
+}
+
+// removeLagMetrics removes all lag metric series of the deployment replication.
+func (dr *DeploymentReplication) removeLagMetrics() {
+	dr.updateLagMetrics(api.EndpointStatus{}, 0)
+	lagExceeded.DeleteLabelValues(dr.apiObject.GetNamespace(), dr.apiObject.GetName())
+}
+
+// durationSeconds returns the given duration in seconds, 0 if not set.
+func durationSeconds(d *metav1.Duration) float64 {
+	if d == nil {
+		return 0
+	}
+	return d.Duration.Seconds()
+}
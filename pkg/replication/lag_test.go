@@ -0,0 +1,100 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package replication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+)
+
+func TestInspectLagUnavailable(t *testing.T) {
+	dr := &DeploymentReplication{
+		apiObject: &api.ArangoDeploymentReplication{
+			ObjectMeta: metav1.ObjectMeta{Name: "lag", Namespace: "test"},
+			Spec: api.DeploymentReplicationSpec{
+				Lag: &api.LagSpec{MaxDelay: &metav1.Duration{Duration: time.Minute}},
+			},
+		},
+		deps: Dependencies{
+			Log:           zerolog.Nop(),
+			EventRecorder: record.NewFakeRecorder(10),
+		},
+	}
+	defer dr.removeLagMetrics()
+
+	status := api.EndpointStatus{
+		Databases: []api.DatabaseStatus{{Name: "db", Delay: &metav1.Duration{Duration: 30 * time.Second}}},
+	}
+	require.True(t, dr.inspectLag(status))
+	require.False(t, dr.status.Conditions.IsTrue(api.ConditionTypeLagExceeded))
+
+	// Destination is not observed, delay keeps growing
+	dr.lagObservedAt = time.Now().Add(-20 * time.Second)
+	require.False(t, dr.inspectLagUnavailable())
+	require.False(t, dr.status.Conditions.IsTrue(api.ConditionTypeLagExceeded))
+
+	dr.lagObservedAt = time.Now().Add(-time.Minute)
+	require.True(t, dr.inspectLagUnavailable())
+	require.True(t, dr.status.Conditions.IsTrue(api.ConditionTypeLagExceeded))
+
+	// Condition is not updated while the threshold stays exceeded
+	dr.lagObservedAt = time.Now().Add(-2 * time.Minute)
+	require.False(t, dr.inspectLagUnavailable())
+
+	// Destination observed again
+	require.True(t, dr.inspectLag(status))
+	require.False(t, dr.status.Conditions.IsTrue(api.ConditionTypeLagExceeded))
+}
+
+func TestSetEndpointStatus(t *testing.T) {
+	status := func(delay time.Duration, notInSync int) api.EndpointStatus {
+		return api.EndpointStatus{
+			Databases: []api.DatabaseStatus{{
+				Name:            "db",
+				Delay:           &metav1.Duration{Duration: delay},
+				ShardsNotInSync: notInSync,
+				Collections: []api.CollectionStatus{{
+					Name:            "col",
+					Delay:           &metav1.Duration{Duration: delay},
+					ShardsNotInSync: notInSync,
+				}},
+			}},
+		}
+	}
+
+	current := status(time.Second, 0)
+
+	setEndpointStatus(&current, status(2*time.Second, 0), false)
+	require.Equal(t, status(time.Second, 0), current, "only delays changed")
+
+	setEndpointStatus(&current, status(2*time.Second, 0), true)
+	require.Equal(t, status(2*time.Second, 0), current, "forced")
+
+	setEndpointStatus(&current, status(3*time.Second, 1), false)
+	require.Equal(t, status(3*time.Second, 1), current, "shards changed")
+}
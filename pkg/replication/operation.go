@@ -0,0 +1,304 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arangodb/arangosync-client/client"
+	driver "github.com/arangodb/go-driver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/arangod"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+const (
+	operationInspectionInterval = time.Second * 5  // Interval used while a switchover or failover is in progress
+	maxInSyncShardDelay         = time.Second      // Maximum delay of a destination shard to consider it in sync
+	operationCancelTimeout      = time.Minute      // Time to wait for the synchronization to become inactive
+	operationSyncTimeout        = time.Minute * 15 // Time to wait for the destination shards to be in sync
+)
+
+// operationClient executes the calls to the deployments needed by the operation.
+type operationClient interface {
+	// SetSourceServerMode sets the server mode of the source deployment.
+	SetSourceServerMode(ctx context.Context, mode driver.ServerMode) error
+	// SetDestinationServerMode sets the server mode of the destination deployment.
+	SetDestinationServerMode(ctx context.Context, mode driver.ServerMode) error
+	// DestinationStatus returns the status of the synchronization on the destination.
+	DestinationStatus(ctx context.Context) (client.SyncInfo, error)
+	// CancelSynchronization cancels the synchronization on the destination.
+	CancelSynchronization(ctx context.Context, force bool) error
+}
+
+// deploymentOperationClient implements operationClient for the deployments of the deployment replication.
+type deploymentOperationClient struct {
+	dr *DeploymentReplication
+}
+
+// SetSourceServerMode sets the server mode of the source deployment.
+func (c deploymentOperationClient) SetSourceServerMode(ctx context.Context, mode driver.ServerMode) error {
+	return c.dr.setServerMode(ctx, c.dr.apiObject.Spec.Source, mode)
+}
+
+// SetDestinationServerMode sets the server mode of the destination deployment.
+func (c deploymentOperationClient) SetDestinationServerMode(ctx context.Context, mode driver.ServerMode) error {
+	return c.dr.setServerMode(ctx, c.dr.apiObject.Spec.Destination, mode)
+}
+
+// DestinationStatus returns the status of the synchronization on the destination.
+func (c deploymentOperationClient) DestinationStatus(ctx context.Context) (client.SyncInfo, error) {
+	destClient, err := c.dr.createSyncMasterClient(c.dr.apiObject.Spec.Destination)
+	if err != nil {
+		return client.SyncInfo{}, errors.WithStack(err)
+	}
+	status, err := destClient.Master().Status(ctx)
+	if err != nil {
+		return client.SyncInfo{}, errors.WithStack(err)
+	}
+	return status, nil
+}
+
+// CancelSynchronization cancels the synchronization on the destination.
+func (c deploymentOperationClient) CancelSynchronization(ctx context.Context, force bool) error {
+	destClient, err := c.dr.createSyncMasterClient(c.dr.apiObject.Spec.Destination)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req := client.CancelSynchronizationRequest{
+		WaitTimeout:  operationCancelTimeout,
+		Force:        force,
+		ForceTimeout: operationCancelTimeout,
+	}
+	if _, err := destClient.Master().CancelSynchronization(ctx, req); err != nil && !client.IsPreconditionFailed(err) {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// inspectOperation drives the switchover or failover operation requested in `spec.operation`.
+// It returns true when an operation is in progress, in which case the synchronization
+// must not be configured or canceled by the regular inspection.
+func (dr *DeploymentReplication) inspectOperation(ctx context.Context) (bool, error) {
+	return dr.runOperation(ctx, deploymentOperationClient{dr: dr})
+}
+
+// runOperation executes the next step of the operation using the given client.
+func (dr *DeploymentReplication) runOperation(ctx context.Context, c operationClient) (bool, error) {
+	spec := dr.apiObject.Spec
+	op := dr.status.Operation
+
+	if !op.IsRunning() {
+		if op != nil && op.DestinationWritesStopped {
+			// The next operation is started once the writes are enabled again
+			return false, dr.enableDestinationWrites(ctx, c)
+		}
+		if spec.Operation == nil || op.Is(spec.Operation) {
+			// Nothing requested or already handled
+			return false, nil
+		}
+		return true, dr.startOperation(*spec.Operation)
+	}
+
+	// The operation can be withdrawn as long as the synchronization is still running
+	if !op.Is(spec.Operation) {
+		switch dr.status.Phase {
+		case api.DeploymentReplicationPhaseStoppingWrites, api.DeploymentReplicationPhaseWaitingForSync:
+			return false, dr.abortOperation(ctx, c, "Operation withdrawn from spec")
+		}
+	}
+
+	switch dr.status.Phase {
+	case api.DeploymentReplicationPhaseStoppingWrites:
+		if err := c.SetSourceServerMode(ctx, driver.ServerModeReadOnly); err != nil {
+			return true, errors.WithStack(err)
+		}
+		op.SourceWritesStopped = true
+		return true, dr.setOperationPhase(api.DeploymentReplicationPhaseWaitingForSync, "Writes on source stopped, waiting for destination shards to be in sync")
+
+	case api.DeploymentReplicationPhaseWaitingForSync:
+		destStatus, err := c.DestinationStatus(ctx)
+		if err != nil {
+			return true, errors.WithStack(err)
+		}
+		if !destStatus.Status.IsActive() {
+			return false, dr.abortOperation(ctx, c, "Synchronization is not active")
+		}
+		if inSync, total := countShardsInSync(destStatus); inSync < total {
+			if time.Since(op.StartTime.Time) > operationSyncTimeout {
+				return false, dr.abortOperation(ctx, c, fmt.Sprintf("Destination shards not in sync within %s", operationSyncTimeout))
+			}
+			op.Message = fmt.Sprintf("%d of %d destination shards in sync", inSync, total)
+			return true, dr.updateCRStatus()
+		}
+		return true, dr.setOperationPhase(api.DeploymentReplicationPhaseCancellingSync, "All destination shards in sync, cancelling synchronization")
+
+	case api.DeploymentReplicationPhaseCancellingSync:
+		force := op.Type == api.OperationTypeFailover
+		dr.deps.Log.Info().Bool("force", force).Msg("Canceling synchronization")
+		if err := c.CancelSynchronization(ctx, force); err != nil {
+			return true, errors.WithStack(err)
+		}
+		return true, dr.setOperationPhase(api.DeploymentReplicationPhaseReconfiguring, "Synchronization canceled, reversing replication direction")
+
+	case api.DeploymentReplicationPhaseReconfiguring:
+		// The old source stays in read-only mode until the synchronization towards it is active
+		return true, dr.completeOperation()
+	}
+
+	// Unexpected phase, continue with canceling as it is safe for both operation types
+	return true, dr.setOperationPhase(api.DeploymentReplicationPhaseCancellingSync, "Resuming operation")
+}
+
+// startOperation records the start of the given operation in the status.
+func (dr *DeploymentReplication) startOperation(spec api.OperationSpec) error {
+	dr.status.Operation = &api.OperationStatus{
+		Type:      spec.Type,
+		ID:        spec.ID,
+		State:     api.OperationStateRunning,
+		StartTime: metav1.Now(),
+	}
+	dr.createEvent(k8sutil.NewReplicationOperationEvent(dr.apiObject, string(spec.Type), fmt.Sprintf("Operation %s started", spec.ID)))
+	if spec.Type == api.OperationTypeSwitchover {
+		return dr.setOperationPhase(api.DeploymentReplicationPhaseStoppingWrites, "Stopping writes on source")
+	}
+	return dr.setOperationPhase(api.DeploymentReplicationPhaseCancellingSync, "Forcefully cancelling synchronization")
+}
+
+// setOperationPhase moves the running operation to the given phase.
+func (dr *DeploymentReplication) setOperationPhase(phase api.DeploymentReplicationPhase, message string) error {
+	dr.deps.Log.Info().Str("phase", string(phase)).Msg(message)
+	dr.status.Phase = phase
+	dr.status.Reason = message
+	dr.status.Operation.Message = message
+	return dr.updateCRStatus()
+}
+
+// abortOperation stops the running operation before the synchronization is canceled
+// and re-enables writes on the source if they were stopped.
+func (dr *DeploymentReplication) abortOperation(ctx context.Context, c operationClient, message string) error {
+	op := dr.status.Operation
+	if op.SourceWritesStopped {
+		if err := c.SetSourceServerMode(ctx, driver.ServerModeDefault); err != nil {
+			return errors.WithStack(err)
+		}
+		op.SourceWritesStopped = false
+	}
+	now := metav1.Now()
+	op.State = api.OperationStateAborted
+	op.Message = message
+	op.FinishTime = &now
+	dr.status.Phase = api.DeploymentReplicationPhaseNone
+	dr.status.Reason = message
+	dr.createEvent(k8sutil.NewReplicationOperationEvent(dr.apiObject, string(op.Type), fmt.Sprintf("Operation %s aborted: %s", op.ID, message)))
+	return dr.updateCRStatus()
+}
+
+// completeOperation reverses the direction of the replication in the spec and
+// marks the operation as completed. Spec and status are stored in a single update,
+// so the direction is never reversed twice.
+func (dr *DeploymentReplication) completeOperation() error {
+	prev := dr.status.DeepCopy()
+	op := dr.status.Operation
+	now := metav1.Now()
+	op.State = api.OperationStateCompleted
+	op.Message = "Replication direction reversed"
+	op.FinishTime = &now
+	if op.SourceWritesStopped {
+		// The old source becomes the destination and is overwritten by the synchronization
+		op.SourceWritesStopped = false
+		op.DestinationWritesStopped = true
+		op.Message = "Replication direction reversed, writes on the destination are enabled once the synchronization is active"
+	}
+	dr.status.Phase = api.DeploymentReplicationPhaseNone
+	dr.status.Reason = ""
+	dr.status.Source = api.EndpointStatus{}
+	dr.status.Destination = api.EndpointStatus{}
+	dr.status.SourceHealth, dr.status.DestinationHealth = dr.status.DestinationHealth, dr.status.SourceHealth
+	dr.status.CancelFailures = 0
+	dr.status.SecretHashes = nil
+	dr.status.Conditions.Update(api.ConditionTypeConfigured, false, "Reversed", "Replication direction reversed, synchronization not yet configured")
+	if err := dr.updateCRSpec(dr.apiObject.Spec.Reversed()); err != nil {
+		dr.status = *prev
+		return errors.WithStack(err)
+	}
+	dr.createEvent(k8sutil.NewReplicationOperationEvent(dr.apiObject, string(op.Type), fmt.Sprintf("Operation %s completed", op.ID)))
+	return nil
+}
+
+// enableDestinationWrites enables the writes on the destination which was the source before the switchover,
+// as soon as the synchronization towards it is active.
+func (dr *DeploymentReplication) enableDestinationWrites(ctx context.Context, c operationClient) error {
+	destStatus, err := c.DestinationStatus(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !destStatus.Status.IsActive() {
+		// Synchronization is not configured yet
+		return nil
+	}
+	if err := c.SetDestinationServerMode(ctx, driver.ServerModeDefault); err != nil {
+		return errors.WithStack(err)
+	}
+	op := dr.status.Operation
+	op.DestinationWritesStopped = false
+	op.Message = "Replication direction reversed"
+	dr.createEvent(k8sutil.NewReplicationOperationEvent(dr.apiObject, string(op.Type), "Synchronization is active, writes on the destination enabled"))
+	return dr.updateCRStatus()
+}
+
+// setServerMode sets the server mode of the deployment of the given endpoint.
+func (dr *DeploymentReplication) setServerMode(ctx context.Context, endpoint api.EndpointSpec, mode driver.ServerMode) error {
+	name := endpoint.GetDeploymentName()
+	if name == "" {
+		return errors.WithStack(errors.Newf("endpoint has no deploymentName"))
+	}
+	depl, err := dr.deps.CRCli.DatabaseV1().ArangoDeployments(dr.apiObject.GetNamespace()).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	c, err := arangod.CreateArangodDatabaseClient(ctx, dr.deps.KubeCli.CoreV1(), depl, false)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := c.SetServerMode(ctx, mode); err != nil {
+		return errors.WithStack(err)
+	}
+	dr.deps.Log.Info().Str("deployment", name).Str("mode", string(mode)).Msg("Changed server mode of deployment")
+	return nil
+}
+
+// countShardsInSync returns the number of incoming shards that are running with
+// a delay below maxInSyncShardDelay, together with the total number of shards.
+func countShardsInSync(status client.SyncInfo) (int, int) {
+	inSync := 0
+	for _, s := range status.Shards {
+		if s.Status == client.SyncStatusRunning && s.Delay <= maxInSyncShardDelay {
+			inSync++
+		}
+	}
+	return inSync, len(status.Shards)
+}
@@ -0,0 +1,352 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package replication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arangodb/arangosync-client/client"
+	driver "github.com/arangodb/go-driver"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/fake"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+// testOperationClient records the calls of the operation.
+type testOperationClient struct {
+	status           client.SyncInfo
+	modes            []driver.ServerMode
+	destinationModes []driver.ServerMode
+	cancel           []bool
+}
+
+func (c *testOperationClient) SetSourceServerMode(_ context.Context, mode driver.ServerMode) error {
+	c.modes = append(c.modes, mode)
+	return nil
+}
+
+func (c *testOperationClient) SetDestinationServerMode(_ context.Context, mode driver.ServerMode) error {
+	c.destinationModes = append(c.destinationModes, mode)
+	return nil
+}
+
+func (c *testOperationClient) DestinationStatus(_ context.Context) (client.SyncInfo, error) {
+	return c.status, nil
+}
+
+func (c *testOperationClient) CancelSynchronization(_ context.Context, force bool) error {
+	c.cancel = append(c.cancel, force)
+	return nil
+}
+
+func TestCountShardsInSync(t *testing.T) {
+	inSync, total := countShardsInSync(client.SyncInfo{})
+	require.Equal(t, 0, inSync)
+	require.Equal(t, 0, total)
+
+	inSync, total = countShardsInSync(client.SyncInfo{
+		Shards: []client.ShardSyncInfo{
+			{Status: client.SyncStatusRunning},
+			{Status: client.SyncStatusRunning, Delay: time.Minute},
+			{Status: client.SyncStatusInitialSync},
+			{Status: client.SyncStatusRunning, Delay: time.Millisecond},
+		},
+	})
+	require.Equal(t, 2, inSync)
+	require.Equal(t, 4, total)
+}
+
+func TestRunOperation(t *testing.T) {
+	switchover := &api.OperationSpec{Type: api.OperationTypeSwitchover, ID: "op-1"}
+	failover := &api.OperationSpec{Type: api.OperationTypeFailover, ID: "op-1"}
+	running := func(spec *api.OperationSpec, writesStopped bool) *api.OperationStatus {
+		return &api.OperationStatus{
+			Type:                spec.Type,
+			ID:                  spec.ID,
+			State:               api.OperationStateRunning,
+			StartTime:           metav1.Now(),
+			SourceWritesStopped: writesStopped,
+		}
+	}
+	shards := func(inSync, notInSync int) client.SyncInfo {
+		info := client.SyncInfo{Status: client.SyncStatusRunning}
+		for i := 0; i < inSync; i++ {
+			info.Shards = append(info.Shards, client.ShardSyncInfo{Status: client.SyncStatusRunning})
+		}
+		for i := 0; i < notInSync; i++ {
+			info.Shards = append(info.Shards, client.ShardSyncInfo{Status: client.SyncStatusInitialSync})
+		}
+		return info
+	}
+
+	type testCase struct {
+		spec      *api.OperationSpec
+		phase     api.DeploymentReplicationPhase
+		operation *api.OperationStatus
+		sync      client.SyncInfo
+
+		inProgress    bool
+		expectedPhase api.DeploymentReplicationPhase
+		expectedState api.OperationState
+		writesStopped bool
+		modes         []driver.ServerMode
+		cancel        []bool
+		reversed      bool
+	}
+
+	testCases := map[string]testCase{
+		"Nothing requested": {},
+		"Operation already completed": {
+			spec:          switchover,
+			operation:     &api.OperationStatus{Type: switchover.Type, ID: switchover.ID, State: api.OperationStateCompleted},
+			expectedState: api.OperationStateCompleted,
+		},
+		"Start switchover": {
+			spec:          switchover,
+			inProgress:    true,
+			expectedPhase: api.DeploymentReplicationPhaseStoppingWrites,
+			expectedState: api.OperationStateRunning,
+		},
+		"Start failover": {
+			spec:          failover,
+			inProgress:    true,
+			expectedPhase: api.DeploymentReplicationPhaseCancellingSync,
+			expectedState: api.OperationStateRunning,
+		},
+		"Stop writes": {
+			spec:          switchover,
+			phase:         api.DeploymentReplicationPhaseStoppingWrites,
+			operation:     running(switchover, false),
+			inProgress:    true,
+			expectedPhase: api.DeploymentReplicationPhaseWaitingForSync,
+			expectedState: api.OperationStateRunning,
+			writesStopped: true,
+			modes:         []driver.ServerMode{driver.ServerModeReadOnly},
+		},
+		"Shards not in sync": {
+			spec:          switchover,
+			phase:         api.DeploymentReplicationPhaseWaitingForSync,
+			operation:     running(switchover, true),
+			sync:          shards(1, 1),
+			inProgress:    true,
+			expectedPhase: api.DeploymentReplicationPhaseWaitingForSync,
+			expectedState: api.OperationStateRunning,
+			writesStopped: true,
+		},
+		"Shards in sync": {
+			spec:          switchover,
+			phase:         api.DeploymentReplicationPhaseWaitingForSync,
+			operation:     running(switchover, true),
+			sync:          shards(2, 0),
+			inProgress:    true,
+			expectedPhase: api.DeploymentReplicationPhaseCancellingSync,
+			expectedState: api.OperationStateRunning,
+			writesStopped: true,
+		},
+		"No shards": {
+			spec:          switchover,
+			phase:         api.DeploymentReplicationPhaseWaitingForSync,
+			operation:     running(switchover, true),
+			sync:          shards(0, 0),
+			inProgress:    true,
+			expectedPhase: api.DeploymentReplicationPhaseCancellingSync,
+			expectedState: api.OperationStateRunning,
+			writesStopped: true,
+		},
+		"Shards not in sync before timeout": {
+			spec:  switchover,
+			phase: api.DeploymentReplicationPhaseWaitingForSync,
+			operation: func() *api.OperationStatus {
+				op := running(switchover, true)
+				op.StartTime = metav1.NewTime(time.Now().Add(-operationSyncTimeout - time.Minute))
+				return op
+			}(),
+			sync:          shards(1, 1),
+			expectedState: api.OperationStateAborted,
+			modes:         []driver.ServerMode{driver.ServerModeDefault},
+		},
+		"Synchronization not active": {
+			spec:          switchover,
+			phase:         api.DeploymentReplicationPhaseWaitingForSync,
+			operation:     running(switchover, true),
+			sync:          client.SyncInfo{Status: client.SyncStatusInactive},
+			expectedState: api.OperationStateAborted,
+			modes:         []driver.ServerMode{driver.ServerModeDefault},
+		},
+		"Withdrawn while waiting for sync": {
+			phase:         api.DeploymentReplicationPhaseWaitingForSync,
+			operation:     running(switchover, true),
+			expectedState: api.OperationStateAborted,
+			modes:         []driver.ServerMode{driver.ServerModeDefault},
+		},
+		"Withdrawn after cancel is ignored": {
+			phase:         api.DeploymentReplicationPhaseCancellingSync,
+			operation:     running(switchover, true),
+			inProgress:    true,
+			expectedPhase: api.DeploymentReplicationPhaseReconfiguring,
+			expectedState: api.OperationStateRunning,
+			writesStopped: true,
+			cancel:        []bool{false},
+		},
+		"Cancel failover": {
+			spec:          failover,
+			phase:         api.DeploymentReplicationPhaseCancellingSync,
+			operation:     running(failover, false),
+			inProgress:    true,
+			expectedPhase: api.DeploymentReplicationPhaseReconfiguring,
+			expectedState: api.OperationStateRunning,
+			cancel:        []bool{true},
+		},
+		"Reverse switchover": {
+			spec:          switchover,
+			phase:         api.DeploymentReplicationPhaseReconfiguring,
+			operation:     running(switchover, true),
+			inProgress:    true,
+			expectedState: api.OperationStateCompleted,
+			reversed:      true,
+		},
+		"Reverse failover": {
+			spec:          failover,
+			phase:         api.DeploymentReplicationPhaseReconfiguring,
+			operation:     running(failover, false),
+			inProgress:    true,
+			expectedState: api.OperationStateCompleted,
+			reversed:      true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiObject := &api.ArangoDeploymentReplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: api.DeploymentReplicationSpec{
+					Source:      api.EndpointSpec{DeploymentName: util.NewString("dc1")},
+					Destination: api.EndpointSpec{DeploymentName: util.NewString("dc2")},
+					Operation:   tc.spec,
+				},
+				Status: api.DeploymentReplicationStatus{
+					Phase:     tc.phase,
+					Operation: tc.operation,
+				},
+			}
+			dr := &DeploymentReplication{
+				apiObject: apiObject,
+				status:    *apiObject.Status.DeepCopy(),
+				deps: Dependencies{
+					Log:           zerolog.Nop(),
+					CRCli:         fake.NewSimpleClientset(apiObject.DeepCopy()),
+					EventRecorder: record.NewFakeRecorder(10),
+				},
+			}
+			c := &testOperationClient{status: tc.sync}
+
+			inProgress, err := dr.runOperation(context.Background(), c)
+			require.NoError(t, err)
+			require.Equal(t, tc.inProgress, inProgress)
+			require.Equal(t, tc.modes, c.modes)
+			require.Equal(t, tc.cancel, c.cancel)
+
+			// Status is stored in the API object
+			status := dr.apiObject.Status
+			require.Equal(t, dr.status, status)
+			require.Equal(t, tc.expectedPhase, status.Phase)
+			if tc.expectedState == "" {
+				require.Nil(t, status.Operation)
+				return
+			}
+			require.NotNil(t, status.Operation)
+			require.Equal(t, tc.expectedState, status.Operation.State)
+			require.Equal(t, tc.writesStopped, status.Operation.SourceWritesStopped)
+
+			source, destination := "dc1", "dc2"
+			if tc.reversed {
+				source, destination = destination, source
+			}
+			require.Equal(t, source, dr.apiObject.Spec.Source.GetDeploymentName())
+			require.Equal(t, destination, dr.apiObject.Spec.Destination.GetDeploymentName())
+		})
+	}
+}
+
+func TestRunOperationEnablesDestinationWritesAfterSync(t *testing.T) {
+	spec := &api.OperationSpec{Type: api.OperationTypeSwitchover, ID: "op-1"}
+	apiObject := &api.ArangoDeploymentReplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: api.DeploymentReplicationSpec{
+			Source:      api.EndpointSpec{DeploymentName: util.NewString("dc1")},
+			Destination: api.EndpointSpec{DeploymentName: util.NewString("dc2")},
+			Operation:   spec,
+		},
+		Status: api.DeploymentReplicationStatus{
+			Phase: api.DeploymentReplicationPhaseReconfiguring,
+			Operation: &api.OperationStatus{
+				Type:                spec.Type,
+				ID:                  spec.ID,
+				State:               api.OperationStateRunning,
+				StartTime:           metav1.Now(),
+				SourceWritesStopped: true,
+			},
+		},
+	}
+	dr := &DeploymentReplication{
+		apiObject: apiObject,
+		status:    *apiObject.Status.DeepCopy(),
+		deps: Dependencies{
+			Log:           zerolog.Nop(),
+			CRCli:         fake.NewSimpleClientset(apiObject.DeepCopy()),
+			EventRecorder: record.NewFakeRecorder(10),
+		},
+	}
+	c := &testOperationClient{status: client.SyncInfo{Status: client.SyncStatusInactive}}
+
+	// Direction is reversed while the old source stays read-only
+	_, err := dr.runOperation(context.Background(), c)
+	require.NoError(t, err)
+	require.Equal(t, "dc1", dr.apiObject.Spec.Destination.GetDeploymentName())
+	require.Equal(t, api.OperationStateCompleted, dr.status.Operation.State)
+	require.False(t, dr.status.Operation.SourceWritesStopped)
+	require.True(t, dr.status.Operation.DestinationWritesStopped)
+	require.Empty(t, c.modes)
+	require.Empty(t, c.destinationModes)
+
+	// Synchronization in the new direction is not active yet
+	inProgress, err := dr.runOperation(context.Background(), c)
+	require.NoError(t, err)
+	require.False(t, inProgress)
+	require.True(t, dr.status.Operation.DestinationWritesStopped)
+	require.Empty(t, c.destinationModes)
+
+	// Writes are enabled once the synchronization is active
+	c.status = client.SyncInfo{Status: client.SyncStatusRunning}
+	inProgress, err = dr.runOperation(context.Background(), c)
+	require.NoError(t, err)
+	require.False(t, inProgress)
+	require.False(t, dr.apiObject.Status.Operation.DestinationWritesStopped)
+	require.Equal(t, []driver.ServerMode{driver.ServerModeDefault}, c.destinationModes)
+	require.Empty(t, c.modes)
+}
@@ -0,0 +1,101 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package replication
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+// syncSecret is a secret referenced in the spec of the deployment replication.
+type syncSecret struct {
+	// name of the secret, empty when the secret is not used
+	name string
+	// hash returns the field of the given hashes keeping the hash of this secret
+	hash func(h *api.SecretHashes) *string
+}
+
+// getSyncSecrets returns all secrets referenced by the endpoints of the given spec.
+func (dr *DeploymentReplication) getSyncSecrets(spec api.DeploymentReplicationSpec) ([]syncSecret, error) {
+	srcKeyfile, srcUser, srcJWT, srcTLSCA, err := dr.getEndpointSecretNames(spec.Source)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	dstKeyfile, dstUser, dstJWT, dstTLSCA, err := dr.getEndpointSecretNames(spec.Destination)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return []syncSecret{
+		{name: srcKeyfile, hash: func(h *api.SecretHashes) *string { return &h.SourceKeyfile }},
+		{name: srcUser, hash: func(h *api.SecretHashes) *string { return &h.SourceUser }},
+		{name: srcJWT, hash: func(h *api.SecretHashes) *string { return &h.SourceJWT }},
+		{name: srcTLSCA, hash: func(h *api.SecretHashes) *string { return &h.SourceTLSCA }},
+		{name: dstKeyfile, hash: func(h *api.SecretHashes) *string { return &h.DestinationKeyfile }},
+		{name: dstUser, hash: func(h *api.SecretHashes) *string { return &h.DestinationUser }},
+		{name: dstJWT, hash: func(h *api.SecretHashes) *string { return &h.DestinationJWT }},
+		{name: dstTLSCA, hash: func(h *api.SecretHashes) *string { return &h.DestinationTLSCA }},
+	}, nil
+}
+
+// getSyncSecretHashes returns the hashes of the given secrets.
+func (dr *DeploymentReplication) getSyncSecretHashes(ctx context.Context, syncSecrets []syncSecret) (*api.SecretHashes, error) {
+	secrets := dr.deps.KubeCli.CoreV1().Secrets(dr.apiObject.GetNamespace())
+	hashes := &api.SecretHashes{}
+	for _, s := range syncSecrets {
+		hash, err := getSecretHash(ctx, secrets, s.name)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		*s.hash(hashes) = hash
+	}
+	return hashes, nil
+}
+
+// getChangedSyncSecrets returns the names of the secrets which differ between the given hashes.
+// Secrets without an old hash have not been tracked when the synchronization was configured, so they are skipped.
+func getChangedSyncSecrets(syncSecrets []syncSecret, old, current *api.SecretHashes) []string {
+	var changed []string
+	for _, s := range syncSecrets {
+		if o := *s.hash(old); o != "" && o != *s.hash(current) {
+			changed = append(changed, s.name)
+		}
+	}
+	return changed
+}
+
+// getSecretHash fetches a secret with given name and returns a hash over its value.
+// An empty name results in an empty hash.
+func getSecretHash(ctx context.Context, secrets corev1.SecretInterface, secretName string) (string, error) {
+	if secretName == "" {
+		return "", nil
+	}
+	s, err := secrets.Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return k8sutil.GetSecretHash(s), nil
+}
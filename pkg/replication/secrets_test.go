@@ -0,0 +1,69 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package replication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+)
+
+func TestGetChangedSyncSecrets(t *testing.T) {
+	syncSecrets := []syncSecret{
+		{name: "src-keyfile", hash: func(h *api.SecretHashes) *string { return &h.SourceKeyfile }},
+		{name: "dst-jwt", hash: func(h *api.SecretHashes) *string { return &h.DestinationJWT }},
+		{name: "dst-ca", hash: func(h *api.SecretHashes) *string { return &h.DestinationTLSCA }},
+	}
+
+	type testCase struct {
+		old, current api.SecretHashes
+		changed      []string
+	}
+
+	testCases := map[string]testCase{
+		"Unchanged": {
+			old:     api.SecretHashes{SourceKeyfile: "a", DestinationJWT: "b"},
+			current: api.SecretHashes{SourceKeyfile: "a", DestinationJWT: "b"},
+		},
+		"Changed destination JWT": {
+			old:     api.SecretHashes{SourceKeyfile: "a", DestinationJWT: "b"},
+			current: api.SecretHashes{SourceKeyfile: "a", DestinationJWT: "c"},
+			changed: []string{"dst-jwt"},
+		},
+		"Changed source keyfile and destination JWT": {
+			old:     api.SecretHashes{SourceKeyfile: "a", DestinationJWT: "b"},
+			current: api.SecretHashes{SourceKeyfile: "c", DestinationJWT: "c"},
+			changed: []string{"src-keyfile", "dst-jwt"},
+		},
+		"Not tracked destination CA": {
+			old:     api.SecretHashes{SourceKeyfile: "a", DestinationJWT: "b"},
+			current: api.SecretHashes{SourceKeyfile: "a", DestinationJWT: "b", DestinationTLSCA: "c"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.changed, getChangedSyncSecrets(syncSecrets, &tc.old, &tc.current))
+		})
+	}
+}
@@ -43,6 +43,12 @@ func (dr *DeploymentReplication) StateColor() server.StateColor {
 	case api.DeploymentReplicationPhaseFailed:
 		return server.StateRed
 	}
+	if dr.status.Conditions.IsTrue(api.ConditionTypeSourceUnreachable) || dr.status.Conditions.IsTrue(api.ConditionTypeDestinationUnreachable) {
+		return server.StateRed
+	}
+	if dr.status.Phase.IsOperation() {
+		return server.StateYellow
+	}
 	if dr.status.Conditions.IsTrue(api.ConditionTypeConfigured) {
 		return server.StateGreen
 	}
@@ -52,15 +58,19 @@ func (dr *DeploymentReplication) StateColor() server.StateColor {
 // Source provides info on the source of the replication
 func (dr *DeploymentReplication) Source() server.Endpoint {
 	return serverEndpoint{
-		dr:      dr,
-		getSpec: func() api.EndpointSpec { return dr.apiObject.Spec.Source },
+		dr:        dr,
+		getSpec:   func() api.EndpointSpec { return dr.apiObject.Spec.Source },
+		getHealth: func() *api.EndpointHealth { return dr.status.SourceHealth },
+		condition: api.ConditionTypeSourceUnreachable,
 	}
 }
 
 // Destination provides info on the destination of the replication
 func (dr *DeploymentReplication) Destination() server.Endpoint {
 	return serverEndpoint{
-		dr:      dr,
-		getSpec: func() api.EndpointSpec { return dr.apiObject.Spec.Destination },
+		dr:        dr,
+		getSpec:   func() api.EndpointSpec { return dr.apiObject.Spec.Destination },
+		getHealth: func() *api.EndpointHealth { return dr.status.DestinationHealth },
+		condition: api.ConditionTypeDestinationUnreachable,
 	}
 }
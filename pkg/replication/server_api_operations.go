@@ -0,0 +1,48 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package replication
+
+import (
+	"context"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/server"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+// Abort stops the replication by removing the ArangoDeploymentReplication resource.
+// The stop-sync finalizer cancels the synchronization before the resource is gone.
+func (dr *DeploymentReplication) Abort(ctx context.Context) error {
+	err := k8sutil.RunWithTimeout(ctx, func(ctxChild context.Context) error {
+		return dr.deps.CRCli.ReplicationV1().ArangoDeploymentReplications(dr.Namespace()).Delete(ctxChild, dr.Name(), metav1.DeleteOptions{})
+	})
+	if err != nil {
+		if k8sutil.IsNotFound(err) {
+			return errors.Wrapf(server.NotFoundError, "deployment replication %s", dr.Name())
+		}
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
@@ -24,14 +24,17 @@ package replication
 
 import (
 	"context"
+	"time"
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 )
 
 type serverEndpoint struct {
-	dr      *DeploymentReplication
-	getSpec func() api.EndpointSpec
+	dr        *DeploymentReplication
+	getSpec   func() api.EndpointSpec
+	getHealth func() *api.EndpointHealth
+	condition api.ConditionType
 }
 
 // DeploymentName returns the name of the ArangoDeployment of this endpoint
@@ -71,3 +74,30 @@ func (ep serverEndpoint) TLSCACert() string {
 func (ep serverEndpoint) TLSCACertSecretName() string {
 	return ep.getSpec().TLS.GetCASecretName()
 }
+
+// Reachable returns false when the syncmaster of this endpoint cannot be reached by the operator
+func (ep serverEndpoint) Reachable() bool {
+	return !ep.dr.status.Conditions.IsTrue(ep.condition)
+}
+
+// ConsecutiveFailures returns the number of failed requests to the syncmaster of this endpoint since the last successful one
+func (ep serverEndpoint) ConsecutiveFailures() int {
+	return ep.getHealth().GetConsecutiveFailures()
+}
+
+// LastSuccessTime returns the time of the last successful request to the syncmaster of this endpoint
+func (ep serverEndpoint) LastSuccessTime() *time.Time {
+	if h := ep.getHealth(); h != nil && h.LastSuccessTime != nil {
+		t := h.LastSuccessTime.Time
+		return &t
+	}
+	return nil
+}
+
+// LastError returns the error of the last failed request to the syncmaster of this endpoint
+func (ep serverEndpoint) LastError() string {
+	if h := ep.getHealth(); h != nil {
+		return h.LastError
+	}
+	return ""
+}
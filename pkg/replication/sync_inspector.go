@@ -24,13 +24,17 @@ package replication
 
 import (
 	"context"
+	"reflect"
 	"sort"
 	"time"
 
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 
 	"github.com/arangodb/arangosync-client/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	api "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 )
 
 // inspectDeploymentReplication inspects the entire deployment replication
@@ -66,23 +70,45 @@ func (dr *DeploymentReplication) inspectDeploymentReplication(lastInterval time.
 			log.Warn().Err(err).Msg("Failed to run finalizers")
 			hasError = true
 		}
+	} else if inProgress, err := dr.inspectOperation(ctx); inProgress || err != nil {
+		// Switchover or failover is in progress, leave the synchronization alone
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to inspect replication operation")
+			hasError = true
+		}
+		nextInterval = operationInspectionInterval
 	} else {
 		// Inspect configuration status
 		destClient, err := dr.createSyncMasterClient(spec.Destination)
 		if err != nil {
 			log.Warn().Err(err).Msg("Failed to create destination syncmaster client")
+			dr.recordEndpointHealth(endpointDestination, err)
+			dr.inspectLagUnavailable()
+			// Keep track of the source, even when the destination cannot be reached
+			dr.recordEndpointHealth(endpointSource, dr.probeSyncMaster(ctx, spec.Source))
+			if err := dr.updateCRStatus(); err != nil {
+				log.Warn().Err(err).Msg("Failed to update status")
+				hasError = true
+			}
 		} else {
 			// Fetch status of destination
 			updateStatusNeeded := false
 			configureSyncNeeded := false
 			cancelSyncNeeded := false
+			reconfigureSyncNeeded := false
 			destEndpoint, err := destClient.Master().GetEndpoints(ctx)
 			if err != nil {
 				log.Warn().Err(err).Msg("Failed to fetch endpoints from destination syncmaster")
 			}
 			destStatus, err := destClient.Master().Status(ctx)
+			if dr.recordEndpointHealth(endpointDestination, err) {
+				updateStatusNeeded = true
+			}
 			if err != nil {
 				log.Warn().Err(err).Msg("Failed to fetch status from destination syncmaster")
+				if dr.inspectLagUnavailable() {
+					updateStatusNeeded = true
+				}
 			} else {
 				// Inspect destination status
 				if destStatus.Status.IsActive() {
@@ -90,11 +116,17 @@ func (dr *DeploymentReplication) inspectDeploymentReplication(lastInterval time.
 					if err != nil {
 						log.Warn().Err(err).Msg("Failed to check is-incoming-endpoint")
 					} else {
-						if isIncomingEndpoint {
+						if isIncomingEndpoint && dr.inspectSyncSecrets(ctx, spec) {
+							// Secrets have been rotated, configure synchronization again
+							reconfigureSyncNeeded = true
+							dr.status.Conditions.Update(api.ConditionTypeConfigured, false, "SecretsChanged", "Secrets used by the synchronization have changed")
+							updateStatusNeeded = true
+						} else if isIncomingEndpoint {
 							// Destination is correctly configured
 							dr.status.Conditions.Update(api.ConditionTypeConfigured, true, "Active", "Destination syncmaster is configured correctly and active")
 							// Fetch shard status
-							dr.status.Destination = createEndpointStatus(destStatus, "")
+							destination := createEndpointStatus(destStatus, "")
+							setEndpointStatus(&dr.status.Destination, destination, dr.inspectLag(destination))
 							updateStatusNeeded = true
 						} else {
 							// Sync is active, but from different source
@@ -108,6 +140,9 @@ func (dr *DeploymentReplication) inspectDeploymentReplication(lastInterval time.
 				} else {
 					// Destination has correct source, but is inactive
 					configureSyncNeeded = true
+					if dr.inspectLagUnavailable() {
+						updateStatusNeeded = true
+					}
 					if dr.status.Conditions.Update(api.ConditionTypeConfigured, false, "Inactive", "Destination syncmaster is configured correctly but in-active") {
 						updateStatusNeeded = true
 					}
@@ -118,10 +153,17 @@ func (dr *DeploymentReplication) inspectDeploymentReplication(lastInterval time.
 			sourceClient, err := dr.createSyncMasterClient(spec.Source)
 			if err != nil {
 				log.Warn().Err(err).Msg("Failed to create source syncmaster client")
+				if dr.recordEndpointHealth(endpointSource, err) {
+					updateStatusNeeded = true
+				}
+			} else if sourceStatus, err := sourceClient.Master().Status(ctx); err != nil {
+				log.Warn().Err(err).Msg("Failed to fetch status from source syncmaster")
+				if dr.recordEndpointHealth(endpointSource, err) {
+					updateStatusNeeded = true
+				}
 			} else {
-				sourceStatus, err := sourceClient.Master().Status(ctx)
-				if err != nil {
-					log.Warn().Err(err).Msg("Failed to fetch status from source syncmaster")
+				if dr.recordEndpointHealth(endpointSource, nil) {
+					updateStatusNeeded = true
 				}
 
 				//if sourceStatus.Status.IsActive() {
@@ -131,7 +173,7 @@ func (dr *DeploymentReplication) inspectDeploymentReplication(lastInterval time.
 				} else if hasOutgoingEndpoint {
 					// Destination is know in source
 					// Fetch shard status
-					dr.status.Source = createEndpointStatus(sourceStatus, outgoingID)
+					setEndpointStatus(&dr.status.Source, createEndpointStatus(sourceStatus, outgoingID), false)
 					updateStatusNeeded = true
 				} else {
 					// We cannot find the destination in the source status
@@ -139,6 +181,26 @@ func (dr *DeploymentReplication) inspectDeploymentReplication(lastInterval time.
 				}
 			}
 
+			// Cancel sync configured with old secrets
+			if reconfigureSyncNeeded {
+				abort := dr.status.CancelFailures > maxCancelFailures
+				req := client.CancelSynchronizationRequest{
+					WaitTimeout:  time.Minute,
+					Force:        abort,
+					ForceTimeout: time.Minute,
+				}
+				log.Info().Bool("abort", abort).Msg("Canceling synchronization to apply changed secrets")
+				if _, err := destClient.Master().CancelSynchronization(ctx, req); err != nil && !client.IsPreconditionFailed(err) {
+					log.Warn().Err(err).Msg("Failed to cancel synchronization")
+					dr.status.CancelFailures++
+					hasError = true
+				} else {
+					log.Info().Msg("Canceled synchronization, configuring it with changed secrets")
+					dr.status.CancelFailures = 0
+					nextInterval = time.Second * 10
+				}
+			}
+
 			// Update status if needed
 			if updateStatusNeeded {
 				if err := dr.updateCRStatus(); err != nil {
@@ -167,6 +229,14 @@ func (dr *DeploymentReplication) inspectDeploymentReplication(lastInterval time.
 					log.Warn().Err(err).Msg("Failed to create syncmaster endpoint")
 					hasError = true
 				} else {
+					var hashes *api.SecretHashes
+					syncSecrets, err := dr.getSyncSecrets(spec)
+					if err == nil {
+						hashes, err = dr.getSyncSecretHashes(ctx, syncSecrets)
+					}
+					if err != nil {
+						log.Warn().Err(err).Msg("Failed to get hashes of synchronization secrets")
+					}
 					auth, err := dr.createArangoSyncTLSAuthentication(spec)
 					if err != nil {
 						log.Warn().Err(err).Msg("Failed to configure synchronization authentication")
@@ -183,6 +253,11 @@ func (dr *DeploymentReplication) inspectDeploymentReplication(lastInterval time.
 						} else {
 							log.Info().Msg("Configured synchronization")
 							nextInterval = time.Second * 10
+							dr.status.SecretHashes = hashes
+							if err := dr.updateCRStatus(); err != nil {
+								log.Warn().Err(err).Msg("Failed to update secret hashes")
+								hasError = true
+							}
 						}
 					}
 				}
@@ -199,12 +274,49 @@ func (dr *DeploymentReplication) inspectDeploymentReplication(lastInterval time.
 	} else {
 		dr.recentInspectionErrors = 0
 	}
+	// Back off while an endpoint is unreachable
+	if interval := dr.getUnreachableInterval(); interval > 0 {
+		nextInterval = interval
+	}
 	if nextInterval > maxInspectionInterval {
 		nextInterval = maxInspectionInterval
 	}
 	return nextInterval
 }
 
+// inspectSyncSecrets compares the secrets used by the synchronization with the hashes stored
+// when it was configured. Returns true when they have been changed.
+func (dr *DeploymentReplication) inspectSyncSecrets(ctx context.Context, spec api.DeploymentReplicationSpec) bool {
+	log := dr.deps.Log
+	syncSecrets, err := dr.getSyncSecrets(spec)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get synchronization secrets")
+		return false
+	}
+	hashes, err := dr.getSyncSecretHashes(ctx, syncSecrets)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get hashes of synchronization secrets")
+		return false
+	}
+	if dr.status.SecretHashes == nil {
+		// Synchronization was configured before the hashes were tracked
+		dr.status.SecretHashes = hashes
+		return false
+	}
+	if dr.status.SecretHashes.Equal(hashes) {
+		return false
+	}
+	changed := getChangedSyncSecrets(syncSecrets, dr.status.SecretHashes, hashes)
+	if len(changed) == 0 {
+		// Only secrets which have not been tracked yet
+		dr.status.SecretHashes = hashes
+		return false
+	}
+	log.Info().Strs("secrets", changed).Msg("Secrets used by the synchronization have changed")
+	dr.createEvent(k8sutil.NewReplicationSecretsChangedEvent(dr.apiObject, changed))
+	return true
+}
+
 // isIncomingEndpoint returns true when given sync status's endpoint
 // intersects with the given endpoint spec.
 func (dr *DeploymentReplication) isIncomingEndpoint(status client.SyncInfo, epSpec api.EndpointSpec) (bool, error) {
@@ -248,6 +360,27 @@ func createEndpointStatus(status client.SyncInfo, outgoingID string) api.Endpoin
 	return result
 }
 
+// setEndpointStatus stores the given status of the endpoint. Delays change on every inspection, so the status
+// is stored only when other fields have changed or when forced, e.g. when a lag threshold has been crossed.
+func setEndpointStatus(current *api.EndpointStatus, status api.EndpointStatus, force bool) {
+	if force || !reflect.DeepEqual(withoutDelays(*current), withoutDelays(status)) {
+		*current = status
+	}
+}
+
+// withoutDelays returns a copy of the given status without delays of databases and collections.
+func withoutDelays(status api.EndpointStatus) api.EndpointStatus {
+	result := *status.DeepCopy()
+	for i := range result.Databases {
+		db := &result.Databases[i]
+		db.Delay = nil
+		for j := range db.Collections {
+			db.Collections[j].Delay = nil
+		}
+	}
+	return result
+}
+
 // createEndpointStatusFromShards creates an api EndpointStatus from the given list of shard statuses.
 func createEndpointStatusFromShards(shards []client.ShardSyncInfo) api.EndpointStatus {
 	result := api.EndpointStatus{}
@@ -289,6 +422,14 @@ func createEndpointStatusFromShards(shards []client.ShardSyncInfo) api.EndpointS
 
 		// Add current shard
 		col.Shards = append(col.Shards, api.ShardStatus{Status: string(s.Status)})
+
+		// Update lag
+		if s.Status != client.SyncStatusRunning {
+			col.ShardsNotInSync++
+			db.ShardsNotInSync++
+		}
+		col.Delay = maxDelay(col.Delay, s.Delay)
+		db.Delay = maxDelay(db.Delay, s.Delay)
 	}
 
 	// Sort result
@@ -299,3 +440,11 @@ func createEndpointStatusFromShards(shards []client.ShardSyncInfo) api.EndpointS
 	}
 	return result
 }
+
+// maxDelay returns the highest of the given delays.
+func maxDelay(current *metav1.Duration, delay time.Duration) *metav1.Duration {
+	if current != nil && current.Duration >= delay {
+		return current
+	}
+	return &metav1.Duration{Duration: delay}
+}
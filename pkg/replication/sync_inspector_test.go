@@ -0,0 +1,58 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package replication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arangodb/arangosync-client/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEndpointStatusFromShardsLag(t *testing.T) {
+	status := createEndpointStatusFromShards([]client.ShardSyncInfo{
+		{Database: "db1", Collection: "c1", ShardIndex: 0, Status: client.SyncStatusRunning, Delay: time.Second},
+		{Database: "db1", Collection: "c1", ShardIndex: 1, Status: client.SyncStatusInitialSync, Delay: time.Minute},
+		{Database: "db1", Collection: "c2", ShardIndex: 0, Status: client.SyncStatusRunning, Delay: 2 * time.Second},
+		{Database: "db2", Collection: "c1", ShardIndex: 0, Status: client.SyncStatusRunning},
+	})
+
+	require.Len(t, status.Databases, 2)
+	db1 := status.Databases[0]
+	require.Equal(t, "db1", db1.Name)
+	require.Equal(t, 1, db1.ShardsNotInSync)
+	require.Equal(t, time.Minute, db1.Delay.Duration)
+
+	require.Len(t, db1.Collections, 2)
+	require.Equal(t, 1, db1.Collections[0].ShardsNotInSync)
+	require.Equal(t, time.Minute, db1.Collections[0].Delay.Duration)
+	require.Equal(t, 0, db1.Collections[1].ShardsNotInSync)
+	require.Equal(t, 2*time.Second, db1.Collections[1].Delay.Duration)
+
+	db2 := status.Databases[1]
+	require.Equal(t, 0, db2.ShardsNotInSync)
+	require.Equal(t, time.Duration(0), db2.Delay.Duration)
+
+	delay, shardsNotInSync := status.Lag()
+	require.Equal(t, time.Minute, delay)
+	require.Equal(t, 1, shardsNotInSync)
+}
@@ -0,0 +1,73 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// audit returns a log event recording that the authenticated user of the request executed the given action.
+// Failed and denied actions are logged with warning level.
+func (s *Server) audit(c *gin.Context, action string, err error) *zerolog.Event {
+	id := getIdentity(c)
+
+	var e *zerolog.Event
+	switch {
+	case err == nil:
+		e = s.deps.AuditLog.Info().Str("result", "success")
+	case isForbidden(err):
+		e = s.deps.AuditLog.Warn().Str("result", "denied")
+	default:
+		e = s.deps.AuditLog.Warn().Str("result", "failure").Err(err)
+	}
+
+	return e.Bool("audit", true).
+		Str("action", action).
+		Str("user", id.Username).
+		Strs("groups", id.Groups).
+		Str("client", c.ClientIP())
+}
+
+// authorize checks if the authenticated user of the request has the required role in the given namespace.
+// Empty namespace refers to cluster-wide resources. When access is denied, an audit entry is recorded,
+// the error is sent and false is returned.
+func (s *Server) authorize(c *gin.Context, action, namespace string, required Role) bool {
+	if getIdentity(c).IsAllowed(namespace, required) {
+		return true
+	}
+
+	err := errors.WithStack(errors.Wrapf(ForbiddenError, "role %s required", required))
+	s.audit(c, action, err).Str("namespace", namespace).Str("role", string(required)).Msg("Access denied")
+	sendError(c, err)
+	return false
+}
+
+// requireRole returns a handler which aborts requests of users without the required cluster-wide role.
+func (s *Server) requireRole(action string, required Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !s.authorize(c, action, "", required) {
+			c.Abort()
+		}
+	}
+}
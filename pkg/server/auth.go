@@ -43,6 +43,8 @@ import (
 const (
 	tokenExpirationTime = time.Hour
 	bearerPrefix        = "bearer "
+	identityContextKey  = "identity"
+	anonymousUsername   = "anonymous"
 )
 
 type serverAuthentication struct {
@@ -59,11 +61,13 @@ type serverAuthentication struct {
 	}
 	adminSecretName string
 	allowAnonymous  bool
+	oidc            *oidcAuthentication
 }
 
 type tokenEntry struct {
 	Token     string
 	ExpiresAt time.Time
+	Identity  Identity
 }
 
 func (t *tokenEntry) IsExpired() bool {
@@ -81,19 +85,60 @@ type loginResponse struct {
 	Token string `json:"token"`
 }
 
+// loginMethodsResponse is the JSON structure returned from `/login/methods`.
+type loginMethodsResponse struct {
+	Password bool `json:"password"`
+	OIDC     bool `json:"oidc"`
+}
+
 // newServerAuthentication creates a new server authentication service
 // for the given arguments.
-func newServerAuthentication(log zerolog.Logger, secrets typedv1.SecretInterface, adminSecretName string, allowAnonymous bool) *serverAuthentication {
+func newServerAuthentication(log zerolog.Logger, secrets typedv1.SecretInterface, adminSecretName string, allowAnonymous bool,
+	oidcConfig OIDCConfig, bindings []RoleBinding) *serverAuthentication {
 	auth := &serverAuthentication{
 		log:             log,
 		secrets:         secrets,
 		adminSecretName: adminSecretName,
 		allowAnonymous:  allowAnonymous,
 	}
+	if oidcConfig.Enabled() {
+		auth.oidc = newOIDCAuthentication(log, secrets, oidcConfig, bindings)
+	}
 	auth.tokens.tokens = make(map[string]*tokenEntry)
 	return auth
 }
 
+// newToken creates a new token for the given identity.
+func (s *serverAuthentication) newToken(id Identity) string {
+	token := strings.ToLower(uniuri.New())
+	s.tokens.mutex.Lock()
+	defer s.tokens.mutex.Unlock()
+
+	now := time.Now()
+	for k, v := range s.tokens.tokens {
+		if v.ExpiresAt.Before(now) {
+			delete(s.tokens.tokens, k)
+		}
+	}
+
+	s.tokens.tokens[token] = &tokenEntry{
+		Token:     token,
+		ExpiresAt: now.Add(tokenExpirationTime),
+		Identity:  id,
+	}
+	return token
+}
+
+// getIdentity returns the identity of the authenticated user of the request.
+func getIdentity(c *gin.Context) Identity {
+	if v, ok := c.Get(identityContextKey); ok {
+		if id, ok := v.(Identity); ok {
+			return id
+		}
+	}
+	return Identity{}
+}
+
 // fetchAdminSecret tries to fetch the admin username & password from the configured Secret.
 // Returns username, password, error
 func (s *serverAuthentication) fetchAdminSecret() (string, string, error) {
@@ -146,13 +191,14 @@ func (s *serverAuthentication) checkLogin(username, password string) error {
 
 // Handle the authentication check
 func (s *serverAuthentication) checkAuthentication(c *gin.Context) {
-	if s.allowAnonymous {
-		// All ok
-		return
-	}
 	// Fetch authorization token
 	authHdr := strings.ToLower(c.Request.Header.Get("Authorization"))
 	if !strings.HasPrefix(authHdr, bearerPrefix) {
+		if s.allowAnonymous {
+			// Anonymous users can only see the resources, operations require login
+			c.Set(identityContextKey, newAnonymousIdentity())
+			return
+		}
 		sendError(c, errors.WithStack(errors.Wrap(UnauthorizedError, "missing bearer token")))
 		c.Abort()
 		return
@@ -174,9 +220,18 @@ func (s *serverAuthentication) checkAuthentication(c *gin.Context) {
 	} else {
 		// All good, renew expiration
 		entry.ExpiresAt = time.Now().Add(tokenExpirationTime)
+		c.Set(identityContextKey, entry.Identity)
 	}
 }
 
+// Handle a GET /login/methods request
+func (s *serverAuthentication) handleLoginMethods(c *gin.Context) {
+	c.JSON(http.StatusOK, loginMethodsResponse{
+		Password: s.adminSecretName != "",
+		OIDC:     s.oidc != nil,
+	})
+}
+
 // Handle a POST /login request
 func (s *serverAuthentication) handleLogin(c *gin.Context) {
 	var req loginRequest
@@ -189,13 +244,7 @@ func (s *serverAuthentication) handleLogin(c *gin.Context) {
 		return
 	}
 	// Create new token
-	token := strings.ToLower(uniuri.New())
-	s.tokens.mutex.Lock()
-	defer s.tokens.mutex.Unlock()
-	s.tokens.tokens[token] = &tokenEntry{
-		Token:     token,
-		ExpiresAt: time.Now().Add(tokenExpirationTime),
-	}
+	token := s.newToken(newAdminIdentity(req.Username))
 	// Send response
 	c.JSON(http.StatusOK, loginResponse{
 		Token: token,
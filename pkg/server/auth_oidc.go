@@ -0,0 +1,310 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/dchest/uniuri"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"golang.org/x/oauth2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	// OIDCClientSecretKey is the key of the client secret in the OIDC client Secret
+	OIDCClientSecretKey = "client-secret"
+
+	oidcStateExpirationTime = 10 * time.Minute
+)
+
+// OIDCConfig settings of the OpenID Connect login
+type OIDCConfig struct {
+	IssuerURL        string   // URL of the OpenID Connect issuer. OIDC login is disabled when empty
+	ClientID         string   // Client ID registered in the identity provider
+	ClientSecretName string   // Name of secret containing the client secret
+	RedirectURL      string   // Public URL of the /login/oidc/callback endpoint
+	UsernameClaim    string   // Claim of the ID token used as username
+	GroupsClaim      string   // Claim of the ID token containing groups of the user
+	Scopes           []string // Additional scopes requested from the identity provider
+}
+
+// Enabled returns true if OIDC login is configured.
+func (c OIDCConfig) Enabled() bool {
+	return c.IssuerURL != ""
+}
+
+// Validate the OIDC configuration.
+func (c OIDCConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.ClientID == "" {
+		return errors.Newf("OIDC client ID is required")
+	}
+	if c.RedirectURL == "" {
+		return errors.Newf("OIDC redirect URL is required")
+	}
+	if c.GroupsClaim == "" {
+		return errors.Newf("OIDC groups claim is required")
+	}
+	return nil
+}
+
+type oidcState struct {
+	nonce     string
+	expiresAt time.Time
+}
+
+// oidcAuthentication implements the OpenID Connect authorization code flow.
+type oidcAuthentication struct {
+	log      zerolog.Logger
+	secrets  typedv1.SecretInterface
+	cfg      OIDCConfig
+	bindings []RoleBinding
+
+	mutex    sync.Mutex
+	provider *oidc.Provider
+	oauth2   *oauth2.Config
+	states   map[string]oidcState
+}
+
+func newOIDCAuthentication(log zerolog.Logger, secrets typedv1.SecretInterface, cfg OIDCConfig, bindings []RoleBinding) *oidcAuthentication {
+	return &oidcAuthentication{
+		log:      log,
+		secrets:  secrets,
+		cfg:      cfg,
+		bindings: bindings,
+		states:   make(map[string]oidcState),
+	}
+}
+
+// init discovers the provider and loads the client secret, if not done yet.
+// It is done lazily, so unavailable identity provider does not prevent the operator from starting.
+// Caller must hold the mutex.
+func (o *oidcAuthentication) init(ctx context.Context) error {
+	if o.provider != nil {
+		return nil
+	}
+
+	var clientSecret string
+	if o.cfg.ClientSecretName != "" {
+		secret, err := o.secrets.Get(ctx, o.cfg.ClientSecretName, metav1.GetOptions{})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		raw, found := secret.Data[OIDCClientSecretKey]
+		if !found {
+			return errors.Newf("Secret '%s' contains no '%s' field", o.cfg.ClientSecretName, OIDCClientSecretKey)
+		}
+		clientSecret = string(raw)
+	}
+
+	provider, err := oidc.NewProvider(ctx, o.cfg.IssuerURL)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	o.provider = provider
+	o.oauth2 = &oauth2.Config{
+		ClientID:     o.cfg.ClientID,
+		ClientSecret: clientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  o.cfg.RedirectURL,
+		Scopes:       append([]string{oidc.ScopeOpenID}, o.cfg.Scopes...),
+	}
+
+	return nil
+}
+
+// newState registers new login attempt and returns its state and nonce.
+// Caller must hold the mutex.
+func (o *oidcAuthentication) newState() (string, string) {
+	now := time.Now()
+	for k, v := range o.states {
+		if v.expiresAt.Before(now) {
+			delete(o.states, k)
+		}
+	}
+
+	state := uniuri.NewLen(32)
+	nonce := uniuri.NewLen(32)
+	o.states[state] = oidcState{
+		nonce:     nonce,
+		expiresAt: now.Add(oidcStateExpirationTime),
+	}
+
+	return state, nonce
+}
+
+// popState removes the login attempt and returns its nonce.
+// Caller must hold the mutex.
+func (o *oidcAuthentication) popState(state string) (string, bool) {
+	s, ok := o.states[state]
+	if !ok {
+		return "", false
+	}
+	delete(o.states, state)
+
+	if s.expiresAt.Before(time.Now()) {
+		return "", false
+	}
+
+	return s.nonce, true
+}
+
+// handleLogin handles GET /login/oidc by redirecting to the identity provider.
+func (o *oidcAuthentication) handleLogin(c *gin.Context) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if err := o.init(c.Request.Context()); err != nil {
+		o.log.Error().Err(err).Msg("Failed to initialize OIDC provider")
+		sendError(c, errors.WithStack(errors.Wrap(UnauthorizedError, "identity provider is not available")))
+		return
+	}
+
+	state, nonce := o.newState()
+
+	c.Redirect(http.StatusFound, o.oauth2.AuthCodeURL(state, oidc.Nonce(nonce)))
+}
+
+// callback verifies the authorization code and returns identity of the user.
+func (o *oidcAuthentication) callback(ctx context.Context, state, code string) (Identity, error) {
+	o.mutex.Lock()
+	nonce, ok := o.popState(state)
+	provider, cfg := o.provider, o.oauth2
+	o.mutex.Unlock()
+
+	if !ok || provider == nil {
+		return Identity{}, errors.WithStack(errors.Wrap(UnauthorizedError, "invalid or expired login state"))
+	}
+
+	token, err := cfg.Exchange(ctx, code)
+	if err != nil {
+		return Identity{}, errors.WithStack(errors.Wrap(UnauthorizedError, err.Error()))
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return Identity{}, errors.WithStack(errors.Wrap(UnauthorizedError, "id_token is missing"))
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: o.cfg.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return Identity{}, errors.WithStack(errors.Wrap(UnauthorizedError, err.Error()))
+	}
+
+	if idToken.Nonce != nonce {
+		return Identity{}, errors.WithStack(errors.Wrap(UnauthorizedError, "invalid nonce"))
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return Identity{}, errors.WithStack(errors.Wrap(UnauthorizedError, err.Error()))
+	}
+
+	return identityFromClaims(claims, idToken.Subject, o.cfg.UsernameClaim, o.cfg.GroupsClaim, o.bindings), nil
+}
+
+// identityFromClaims creates identity from the claims of the ID token.
+func identityFromClaims(claims map[string]interface{}, subject, usernameClaim, groupsClaim string, bindings []RoleBinding) Identity {
+	username := subject
+	if v, ok := claims[usernameClaim].(string); ok && v != "" {
+		username = v
+	}
+
+	var groups []string
+	switch v := claims[groupsClaim].(type) {
+	case string:
+		groups = []string{v}
+	case []interface{}:
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+	}
+
+	return newIdentity(username, groups, bindings)
+}
+
+var oidcCallbackTemplate = template.Must(template.New("callback").Parse(`<!DOCTYPE html>
+<html>
+<head><title>ArangoDB Operator</title></head>
+<body>
+<script>
+sessionStorage.setItem("kube-arangodb:v1:auth-token", {{.}});
+window.location.replace("/");
+</script>
+</body>
+</html>
+`))
+
+// handleOIDCCallback handles GET /login/oidc/callback by creating a token for the authenticated user.
+// The token is stored in the session storage of the dashboard.
+func (s *serverAuthentication) handleOIDCCallback(c *gin.Context) {
+	if errMsg := c.Query("error"); errMsg != "" {
+		sendError(c, errors.WithStack(errors.Wrapf(UnauthorizedError, "%s: %s", errMsg, c.Query("error_description"))))
+		return
+	}
+
+	id, err := s.oidc.callback(c.Request.Context(), c.Query("state"), c.Query("code"))
+	if err != nil {
+		s.log.Debug().Err(err).Msg("OIDC login failed")
+		sendError(c, err)
+		return
+	}
+
+	if !id.HasAnyRole() {
+		s.log.Info().Str("user", id.Username).Strs("groups", id.Groups).Msg("OIDC login rejected, no role assigned")
+		sendError(c, errors.WithStack(errors.Wrap(ForbiddenError, "no role assigned to the user")))
+		return
+	}
+
+	token := s.newToken(id)
+
+	s.log.Info().Str("user", id.Username).Strs("groups", id.Groups).Msg("OIDC login")
+
+	// Token is stored as JSON string
+	value, err := json.Marshal(token)
+	if err != nil {
+		sendError(c, errors.WithStack(err))
+		return
+	}
+
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+	if err := oidcCallbackTemplate.Execute(c.Writer, string(value)); err != nil {
+		s.log.Error().Err(err).Msg("Failed to render OIDC callback")
+	}
+}
@@ -0,0 +1,77 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arangodb/kube-arangodb/pkg/logging"
+	"github.com/arangodb/kube-arangodb/pkg/util/probe"
+)
+
+type noOperators struct{}
+
+func (noOperators) DeploymentOperator() DeploymentOperator                       { return nil }
+func (noOperators) DeploymentReplicationOperator() DeploymentReplicationOperator { return nil }
+func (noOperators) StorageOperator() StorageOperator                             { return nil }
+func (noOperators) BackupOperator() BackupOperator                               { return nil }
+func (noOperators) FindOtherOperators() []OperatorReference                      { return nil }
+
+func Test_Anonymous_Access(t *testing.T) {
+	logService, err := logging.NewService("info", nil)
+	require.NoError(t, err)
+
+	s, err := NewServer(nil, Config{AllowAnonymous: true}, Dependencies{
+		Log:           zerolog.Nop(),
+		AuditLog:      zerolog.Nop(),
+		LogService:    logService,
+		LivenessProbe: &probe.LivenessProbe{},
+		Operators:     noOperators{},
+	})
+	require.NoError(t, err)
+
+	t.Run("Read is allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.httpServer.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/storage", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Write is forbidden", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/api/logging/operator", strings.NewReader(`{"level":"debug"}`))
+		s.httpServer.Handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Invalid token is rejected", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/api/logging/operator", strings.NewReader(`{"level":"debug"}`))
+		req.Header.Set("Authorization", "bearer invalid")
+		s.httpServer.Handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
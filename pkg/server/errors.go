@@ -33,6 +33,8 @@ import (
 var (
 	NotFoundError     = errors.New("not found")
 	UnauthorizedError = errors.New("unauthorized")
+	BadRequestError   = errors.New("bad request")
+	ForbiddenError    = errors.New("forbidden")
 )
 
 func isNotFound(err error) bool {
@@ -43,6 +45,14 @@ func isUnauthorized(err error) bool {
 	return err == UnauthorizedError || errors.Cause(err) == UnauthorizedError
 }
 
+func isBadRequest(err error) bool {
+	return err == BadRequestError || errors.Cause(err) == BadRequestError
+}
+
+func isForbidden(err error) bool {
+	return err == ForbiddenError || errors.Cause(err) == ForbiddenError
+}
+
 // sendError sends an error on the given context
 func sendError(c *gin.Context, err error) {
 	// TODO proper status handling
@@ -51,6 +61,10 @@ func sendError(c *gin.Context, err error) {
 		code = http.StatusNotFound
 	} else if isUnauthorized(err) {
 		code = http.StatusUnauthorized
+	} else if isBadRequest(err) {
+		code = http.StatusBadRequest
+	} else if isForbidden(err) {
+		code = http.StatusForbidden
 	}
 	c.JSON(code, gin.H{
 		"error": err.Error(),
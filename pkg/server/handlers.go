@@ -25,6 +25,8 @@ package server
 import (
 	"net/http"
 
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -34,6 +36,7 @@ type operatorsResponse struct {
 	Deployment            bool                `json:"deployment"`
 	DeploymentReplication bool                `json:"deployment_replication"`
 	Storage               bool                `json:"storage"`
+	Backup                bool                `json:"backup"`
 	Other                 []OperatorReference `json:"other"`
 }
 
@@ -43,6 +46,7 @@ const (
 	OperatorTypeDeployment            OperatorType = "deployment"
 	OperatorTypeDeploymentReplication OperatorType = "deployment_replication"
 	OperatorTypeStorage               OperatorType = "storage"
+	OperatorTypeBackup                OperatorType = "backup"
 )
 
 // OperatorReference contains a reference to another operator
@@ -54,12 +58,18 @@ type OperatorReference struct {
 
 // Handle a GET /api/operators request
 func (s *Server) handleGetOperators(c *gin.Context) {
+	if !getIdentity(c).IsAllowedAnywhere(RoleViewer) {
+		sendError(c, errors.WithStack(errors.Wrapf(ForbiddenError, "role %s required", RoleViewer)))
+		return
+	}
+
 	result := operatorsResponse{
 		PodName:               s.cfg.PodName,
 		Namespace:             s.cfg.Namespace,
 		Deployment:            s.deps.Deployment.Probe.IsReady(),
 		DeploymentReplication: s.deps.DeploymentReplication.Probe.IsReady(),
 		Storage:               s.deps.Storage.Probe.IsReady(),
+		Backup:                s.deps.Backup.Probe.IsReady(),
 		Other:                 s.deps.Operators.FindOtherOperators(),
 	}
 	s.deps.Log.Info().Interface("result", result).Msg("handleGetOperators")
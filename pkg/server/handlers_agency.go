@@ -0,0 +1,59 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handle a GET /api/deployment/:name/agency request
+func (s *Server) handleGetDeploymentAgency(c *gin.Context) {
+	depl, ok := s.getDeployment(c, "deployment.agency", RoleOperator)
+	if !ok {
+		return
+	}
+
+	state, err := depl.AgencyState(c.Request.Context())
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+// Handle a GET /api/deployment/:name/shards request
+func (s *Server) handleGetDeploymentShards(c *gin.Context) {
+	depl, ok := s.getDeployment(c, "deployment.shards", RoleViewer)
+	if !ok {
+		return
+	}
+
+	state, err := depl.AgencyState(c.Request.Context())
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, state.ShardDistribution())
+}
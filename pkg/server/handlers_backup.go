@@ -0,0 +1,338 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+)
+
+// TransferStatus is the status of an upload or download of a backup.
+type TransferStatus string
+
+const (
+	// TransferStatusNone indicates that the transfer is not requested
+	TransferStatusNone TransferStatus = ""
+	// TransferStatusPending indicates that the transfer is requested, but not started yet
+	TransferStatusPending TransferStatus = "Pending"
+	// TransferStatusRunning indicates that the transfer is in progress
+	TransferStatusRunning TransferStatus = "Running"
+	// TransferStatusCompleted indicates that the transfer is done
+	TransferStatusCompleted TransferStatus = "Completed"
+	// TransferStatusFailed indicates that the transfer failed
+	TransferStatusFailed TransferStatus = "Failed"
+)
+
+// Backup is the API implemented by an ArangoBackup.
+type Backup interface {
+	Name() string
+	Namespace() string
+	DeploymentName() string
+	PolicyName() string
+	StateColor() StateColor
+	State() string
+	StateMessage() string
+	StateTime() time.Time
+	// Progress returns the job ID and the progress of the running operation, if any
+	Progress() (string, string)
+	Available() bool
+	BackupID() string
+	Version() string
+	SizeInBytes() uint64
+	NumberOfDBServers() int
+	CreatedAt() *time.Time
+	Upload() BackupTransferInfo
+	Download() BackupTransferInfo
+}
+
+// BackupPolicy is the API implemented by an ArangoBackupPolicy.
+type BackupPolicy interface {
+	Name() string
+	Namespace() string
+	StateColor() StateColor
+	Schedule() string
+	DeploymentSelector() string
+	UploadRepositoryURL() string
+	NextSchedule() *time.Time
+	Message() string
+}
+
+// BackupOperator is the API implemented by the backup operator.
+type BackupOperator interface {
+	// GetBackups returns basic information for all backups managed by the operator in the given namespace.
+	// Empty namespace refers to all watched namespaces.
+	GetBackups(ctx context.Context, namespace string) ([]Backup, error)
+	// GetBackup returns detailed information for a backup, managed by the operator, with given namespace and name
+	GetBackup(ctx context.Context, namespace, name string) (Backup, error)
+	// GetBackupPolicies returns basic information for all backup policies managed by the operator in the given namespace.
+	// Empty namespace refers to all watched namespaces.
+	GetBackupPolicies(ctx context.Context, namespace string) ([]BackupPolicy, error)
+	// GetBackupPolicy returns detailed information for a backup policy, managed by the operator, with given namespace and name
+	GetBackupPolicy(ctx context.Context, namespace, name string) (BackupPolicy, error)
+}
+
+// BackupTransferInfo is the information returned per upload or download of a backup.
+type BackupTransferInfo struct {
+	RepositoryURL string         `json:"repository_url,omitempty"`
+	Status        TransferStatus `json:"status,omitempty"`
+}
+
+// BackupInfo is the information returned per backup.
+type BackupInfo struct {
+	Name              string             `json:"name"`
+	Namespace         string             `json:"namespace"`
+	Deployment        string             `json:"deployment"`
+	Policy            string             `json:"policy,omitempty"`
+	StateColor        StateColor         `json:"state_color"`
+	State             string             `json:"state"`
+	Progress          string             `json:"progress,omitempty"`
+	Available         bool               `json:"available"`
+	SizeInBytes       uint64             `json:"size_in_bytes,omitempty"`
+	NumberOfDBServers int                `json:"dbservers,omitempty"`
+	CreatedAt         *time.Time         `json:"created_at,omitempty"`
+	Upload            BackupTransferInfo `json:"upload"`
+	Download          BackupTransferInfo `json:"download"`
+}
+
+// newBackupInfo initializes a BackupInfo for the given backup.
+func newBackupInfo(b Backup) BackupInfo {
+	_, progress := b.Progress()
+	return BackupInfo{
+		Name:              b.Name(),
+		Namespace:         b.Namespace(),
+		Deployment:        b.DeploymentName(),
+		Policy:            b.PolicyName(),
+		StateColor:        b.StateColor(),
+		State:             b.State(),
+		Progress:          progress,
+		Available:         b.Available(),
+		SizeInBytes:       b.SizeInBytes(),
+		NumberOfDBServers: b.NumberOfDBServers(),
+		CreatedAt:         b.CreatedAt(),
+		Upload:            b.Upload(),
+		Download:          b.Download(),
+	}
+}
+
+// BackupInfoDetails is the detailed information returned per backup.
+type BackupInfoDetails struct {
+	BackupInfo
+	Message   string    `json:"message,omitempty"`
+	StateTime time.Time `json:"state_time"`
+	JobID     string    `json:"job_id,omitempty"`
+	BackupID  string    `json:"backup_id,omitempty"`
+	Version   string    `json:"version,omitempty"`
+}
+
+// newBackupInfoDetails initializes a BackupInfoDetails for the given backup.
+func newBackupInfoDetails(b Backup) BackupInfoDetails {
+	jobID, _ := b.Progress()
+	return BackupInfoDetails{
+		BackupInfo: newBackupInfo(b),
+		Message:    b.StateMessage(),
+		StateTime:  b.StateTime(),
+		JobID:      jobID,
+		BackupID:   b.BackupID(),
+		Version:    b.Version(),
+	}
+}
+
+// BackupPolicyInfo is the information returned per backup policy.
+type BackupPolicyInfo struct {
+	Name                string     `json:"name"`
+	Namespace           string     `json:"namespace"`
+	StateColor          StateColor `json:"state_color"`
+	Schedule            string     `json:"schedule"`
+	DeploymentSelector  string     `json:"deployment_selector,omitempty"`
+	UploadRepositoryURL string     `json:"upload_repository_url,omitempty"`
+	NextSchedule        *time.Time `json:"next_schedule,omitempty"`
+	Message             string     `json:"message,omitempty"`
+}
+
+// newBackupPolicyInfo initializes a BackupPolicyInfo for the given backup policy.
+func newBackupPolicyInfo(p BackupPolicy) BackupPolicyInfo {
+	return BackupPolicyInfo{
+		Name:                p.Name(),
+		Namespace:           p.Namespace(),
+		StateColor:          p.StateColor(),
+		Schedule:            p.Schedule(),
+		DeploymentSelector:  p.DeploymentSelector(),
+		UploadRepositoryURL: p.UploadRepositoryURL(),
+		NextSchedule:        p.NextSchedule(),
+		Message:             p.Message(),
+	}
+}
+
+// BackupPolicyInfoDetails is the detailed information returned per backup policy.
+type BackupPolicyInfoDetails struct {
+	BackupPolicyInfo
+	Backups []BackupInfo `json:"backups"`
+}
+
+// Handle a GET /api/backup request
+func (s *Server) handleGetBackups(c *gin.Context) {
+	if o := s.deps.Operators.BackupOperator(); o != nil {
+		// Fetch backups
+		backups, err := o.GetBackups(c.Request.Context(), c.Query("namespace"))
+		if err != nil {
+			sendError(c, err)
+		} else {
+			id := getIdentity(c)
+			result := make([]BackupInfo, 0, len(backups))
+			for _, b := range backups {
+				if id.IsAllowed(b.Namespace(), RoleViewer) {
+					result = append(result, newBackupInfo(b))
+				}
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"backups": result,
+			})
+		}
+	}
+}
+
+// Handle a GET /api/backup/:name request
+func (s *Server) handleGetBackupDetails(c *gin.Context) {
+	if o := s.deps.Operators.BackupOperator(); o != nil {
+		// Fetch backup
+		b, err := findBackup(c, o)
+		if err != nil {
+			sendError(c, err)
+		} else {
+			c.JSON(http.StatusOK, newBackupInfoDetails(b))
+		}
+	}
+}
+
+// Handle a GET /api/backup-policy request
+func (s *Server) handleGetBackupPolicies(c *gin.Context) {
+	if o := s.deps.Operators.BackupOperator(); o != nil {
+		// Fetch backup policies
+		policies, err := o.GetBackupPolicies(c.Request.Context(), c.Query("namespace"))
+		if err != nil {
+			sendError(c, err)
+		} else {
+			id := getIdentity(c)
+			result := make([]BackupPolicyInfo, 0, len(policies))
+			for _, p := range policies {
+				if id.IsAllowed(p.Namespace(), RoleViewer) {
+					result = append(result, newBackupPolicyInfo(p))
+				}
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"policies": result,
+			})
+		}
+	}
+}
+
+// Handle a GET /api/backup-policy/:name request
+func (s *Server) handleGetBackupPolicyDetails(c *gin.Context) {
+	if o := s.deps.Operators.BackupOperator(); o != nil {
+		// Fetch backup policy
+		p, err := findBackupPolicy(c, o)
+		if err != nil {
+			sendError(c, err)
+			return
+		}
+
+		// Fetch backups created by the policy
+		backups, err := o.GetBackups(c.Request.Context(), p.Namespace())
+		if err != nil {
+			sendError(c, err)
+			return
+		}
+
+		result := BackupPolicyInfoDetails{
+			BackupPolicyInfo: newBackupPolicyInfo(p),
+			Backups:          make([]BackupInfo, 0),
+		}
+		for _, b := range backups {
+			if b.Namespace() == p.Namespace() && b.PolicyName() == p.Name() {
+				result.Backups = append(result.Backups, newBackupInfo(b))
+			}
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// findBackup returns the backup with name taken from the request path, among the backups
+// which the authenticated user is allowed to view. Backups in other namespaces are reported
+// in the same way as missing ones, so their names are not disclosed.
+func findBackup(c *gin.Context, o BackupOperator) (Backup, error) {
+	id := getIdentity(c)
+	namespace, name := c.Query("namespace"), c.Params.ByName("name")
+
+	backups, err := o.GetBackups(c.Request.Context(), namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Backup
+	for _, b := range backups {
+		if b.Name() != name || !id.IsAllowed(b.Namespace(), RoleViewer) {
+			continue
+		}
+		if result != nil {
+			return nil, errors.Wrapf(BadRequestError, "backup %s exists in multiple namespaces, namespace is required", name)
+		}
+		result = b
+	}
+	if result == nil {
+		return nil, errors.Wrapf(NotFoundError, "backup %s", name)
+	}
+
+	return o.GetBackup(c.Request.Context(), result.Namespace(), result.Name())
+}
+
+// findBackupPolicy returns the backup policy with name taken from the request path, among the backup policies
+// which the authenticated user is allowed to view. Backup policies in other namespaces are reported
+// in the same way as missing ones, so their names are not disclosed.
+func findBackupPolicy(c *gin.Context, o BackupOperator) (BackupPolicy, error) {
+	id := getIdentity(c)
+	namespace, name := c.Query("namespace"), c.Params.ByName("name")
+
+	policies, err := o.GetBackupPolicies(c.Request.Context(), namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BackupPolicy
+	for _, p := range policies {
+		if p.Name() != name || !id.IsAllowed(p.Namespace(), RoleViewer) {
+			continue
+		}
+		if result != nil {
+			return nil, errors.Wrapf(BadRequestError, "backup policy %s exists in multiple namespaces, namespace is required", name)
+		}
+		result = p
+	}
+	if result == nil {
+		return nil, errors.Wrapf(NotFoundError, "backup policy %s", name)
+	}
+
+	return o.GetBackupPolicy(c.Request.Context(), result.Namespace(), result.Name())
+}
@@ -23,6 +23,8 @@
 package server
 
 import (
+	"context"
+	"io"
 	"net/http"
 	"sort"
 	"strings"
@@ -30,6 +32,8 @@ import (
 	"github.com/gin-gonic/gin"
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/agency"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 )
 
 // Deployment is the API implemented by an ArangoDeployment.
@@ -47,6 +51,21 @@ type Deployment interface {
 	DatabaseURL() string
 	DatabaseVersion() (string, string)
 	Members() map[api.ServerGroup][]Member
+
+	// Scale changes the number of servers in the given group
+	Scale(ctx context.Context, group api.ServerGroup, count int) error
+	// SetMaintenance enables or disables the maintenance mode of the cluster
+	SetMaintenance(ctx context.Context, enabled bool) error
+	// RotateMember marks the pod of the member with given ID to be rotated
+	RotateMember(ctx context.Context, id string) error
+	// CreateBackup creates an ArangoBackup of the deployment and returns its name
+	CreateBackup(ctx context.Context, name string) (string, error)
+	// RestoreBackup restores the deployment from the ArangoBackup with given name
+	RestoreBackup(ctx context.Context, name string) error
+	// SupportBundle writes the support bundle (tar.gz) of the deployment into the writer
+	SupportBundle(ctx context.Context, out io.Writer, logLines int64) error
+	// AgencyState reads the state of the agency of the deployment
+	AgencyState(ctx context.Context) (*agency.ArangoState, error)
 }
 
 // Member is the API implemented by a member of an ArangoDeployment.
@@ -63,8 +82,9 @@ type Member interface {
 type DeploymentOperator interface {
 	// GetDeployments returns basic information for all deployments managed by the operator
 	GetDeployments() ([]Deployment, error)
-	// GetDeployment returns detailed information for a deployment, managed by the operator, with given name
-	GetDeployment(name string) (Deployment, error)
+	// GetDeployment returns detailed information for a deployment, managed by the operator, with given name.
+	// Namespace can be empty if the name is unique across all watched namespaces.
+	GetDeployment(namespace, name string) (Deployment, error)
 }
 
 // StateColor is a strongly typed indicator of state
@@ -185,9 +205,12 @@ func (s *Server) handleGetDeployments(c *gin.Context) {
 		if err != nil {
 			sendError(c, err)
 		} else {
-			result := make([]DeploymentInfo, len(depls))
-			for i, d := range depls {
-				result[i] = newDeploymentInfo(d)
+			id := getIdentity(c)
+			result := make([]DeploymentInfo, 0, len(depls))
+			for _, d := range depls {
+				if id.IsAllowed(d.Namespace(), RoleViewer) {
+					result = append(result, newDeploymentInfo(d))
+				}
 			}
 			c.JSON(http.StatusOK, gin.H{
 				"deployments": result,
@@ -200,7 +223,7 @@ func (s *Server) handleGetDeployments(c *gin.Context) {
 func (s *Server) handleGetDeploymentDetails(c *gin.Context) {
 	if do := s.deps.Operators.DeploymentOperator(); do != nil {
 		// Fetch deployments
-		depl, err := do.GetDeployment(c.Params.ByName("name"))
+		depl, err := findDeployment(c, do)
 		if err != nil {
 			sendError(c, err)
 		} else {
@@ -209,3 +232,202 @@ func (s *Server) handleGetDeploymentDetails(c *gin.Context) {
 		}
 	}
 }
+
+// ScaleRequest is the request body of a scale operation.
+type ScaleRequest struct {
+	Group api.ServerGroup `json:"group"`
+	Count *int            `json:"count"`
+}
+
+// MaintenanceRequest is the request body of a maintenance operation.
+type MaintenanceRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// BackupRequest is the request body of a backup operation.
+type BackupRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
+// RestoreRequest is the request body of a restore operation.
+type RestoreRequest struct {
+	Backup string `json:"backup"`
+}
+
+// getDeployment returns the deployment with name taken from the request path,
+// if the authenticated user has the required role in the namespace of the deployment.
+func (s *Server) getDeployment(c *gin.Context, action string, required Role) (Deployment, bool) {
+	do := s.deps.Operators.DeploymentOperator()
+	if do == nil {
+		sendError(c, errors.WithStack(NotFoundError))
+		return nil, false
+	}
+
+	depl, err := findDeployment(c, do)
+	if err != nil {
+		sendError(c, err)
+		return nil, false
+	}
+
+	if !s.authorize(c, action, depl.Namespace(), required) {
+		return nil, false
+	}
+
+	return depl, true
+}
+
+// findDeployment returns the deployment with name taken from the request path, among the deployments
+// which the authenticated user is allowed to view. Deployments in other namespaces are reported
+// in the same way as missing ones, so their names are not disclosed.
+func findDeployment(c *gin.Context, do DeploymentOperator) (Deployment, error) {
+	id := getIdentity(c)
+	namespace, name := c.Query("namespace"), c.Params.ByName("name")
+
+	depls, err := do.GetDeployments()
+	if err != nil {
+		return nil, err
+	}
+
+	var result Deployment
+	for _, d := range depls {
+		if d.Name() != name || (namespace != "" && d.Namespace() != namespace) || !id.IsAllowed(d.Namespace(), RoleViewer) {
+			continue
+		}
+		if result != nil {
+			return nil, errors.Wrapf(BadRequestError, "deployment %s exists in multiple namespaces, namespace is required", name)
+		}
+		result = d
+	}
+	if result == nil {
+		return nil, errors.Wrapf(NotFoundError, "deployment %s", name)
+	}
+
+	return do.GetDeployment(result.Namespace(), result.Name())
+}
+
+// Handle a POST /api/deployment/:name/scale request
+func (s *Server) handleScaleDeployment(c *gin.Context) {
+	var req ScaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		sendError(c, errors.Wrap(BadRequestError, err.Error()))
+		return
+	}
+	if req.Count == nil || *req.Count < 0 {
+		sendError(c, errors.Wrap(BadRequestError, "count needs to be defined and not negative"))
+		return
+	}
+
+	depl, ok := s.getDeployment(c, "deployment.scale", RoleOperator)
+	if !ok {
+		return
+	}
+
+	err := depl.Scale(c.Request.Context(), req.Group, *req.Count)
+	s.audit(c, "deployment.scale", err).Str("namespace", depl.Namespace()).Str("deployment", depl.Name()).
+		Str("group", req.Group.AsRole()).Int("count", *req.Count).Msg("Deployment scale")
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{})
+}
+
+// Handle a POST /api/deployment/:name/maintenance request
+func (s *Server) handleSetDeploymentMaintenance(c *gin.Context) {
+	var req MaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		sendError(c, errors.Wrap(BadRequestError, err.Error()))
+		return
+	}
+
+	depl, ok := s.getDeployment(c, "deployment.maintenance", RoleOperator)
+	if !ok {
+		return
+	}
+
+	err := depl.SetMaintenance(c.Request.Context(), req.Enabled)
+	s.audit(c, "deployment.maintenance", err).Str("namespace", depl.Namespace()).Str("deployment", depl.Name()).
+		Bool("enabled", req.Enabled).Msg("Deployment maintenance change")
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{})
+}
+
+// Handle a POST /api/deployment/:name/member/:id/rotate request
+func (s *Server) handleRotateDeploymentMember(c *gin.Context) {
+	depl, ok := s.getDeployment(c, "deployment.member.rotate", RoleOperator)
+	if !ok {
+		return
+	}
+
+	id := c.Params.ByName("id")
+	err := depl.RotateMember(c.Request.Context(), id)
+	s.audit(c, "deployment.member.rotate", err).Str("namespace", depl.Namespace()).Str("deployment", depl.Name()).
+		Str("member", id).Msg("Member rotation")
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{})
+}
+
+// Handle a POST /api/deployment/:name/backup request
+func (s *Server) handleCreateDeploymentBackup(c *gin.Context) {
+	var req BackupRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			sendError(c, errors.Wrap(BadRequestError, err.Error()))
+			return
+		}
+	}
+
+	depl, ok := s.getDeployment(c, "deployment.backup", RoleOperator)
+	if !ok {
+		return
+	}
+
+	name, err := depl.CreateBackup(c.Request.Context(), req.Name)
+	s.audit(c, "deployment.backup", err).Str("namespace", depl.Namespace()).Str("deployment", depl.Name()).
+		Str("backup", name).Msg("Backup creation")
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"name": name,
+	})
+}
+
+// Handle a POST /api/deployment/:name/restore request
+func (s *Server) handleRestoreDeploymentBackup(c *gin.Context) {
+	var req RestoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		sendError(c, errors.Wrap(BadRequestError, err.Error()))
+		return
+	}
+	if req.Backup == "" {
+		sendError(c, errors.Wrap(BadRequestError, "backup needs to be defined"))
+		return
+	}
+
+	depl, ok := s.getDeployment(c, "deployment.restore", RoleAdmin)
+	if !ok {
+		return
+	}
+
+	err := depl.RestoreBackup(c.Request.Context(), req.Backup)
+	s.audit(c, "deployment.restore", err).Str("namespace", depl.Namespace()).Str("deployment", depl.Name()).
+		Str("backup", req.Backup).Msg("Backup restore")
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{})
+}
@@ -0,0 +1,111 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/eventstream"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// eventStreamDuration is the time after which the event stream is closed, it needs to be lower than the write timeout of the server.
+	// Clients reconnect with the Last-Event-ID header and receive the events they missed.
+	eventStreamDuration = 25 * time.Second
+	// eventStreamKeepAlive is the interval of comments sent to keep idle connections open
+	eventStreamKeepAlive = 10 * time.Second
+	// eventStreamRetry is the reconnection time (in milliseconds) sent to the clients
+	eventStreamRetry = 1000
+)
+
+// Handle a GET /api/deployment/:name/events request
+func (s *Server) handleGetDeploymentEvents(c *gin.Context) {
+	var lastID uint64
+	if v := c.GetHeader("Last-Event-ID"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			sendError(c, errors.Wrapf(BadRequestError, "invalid Last-Event-ID: %s", v))
+			return
+		}
+		lastID = id
+	}
+
+	depl, ok := s.getDeployment(c, "deployment.events", RoleViewer)
+	if !ok {
+		return
+	}
+
+	filter := eventstream.Filter{
+		Namespace:  depl.Namespace(),
+		Deployment: depl.Name(),
+	}
+	if types := c.Query("type"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			filter.Types = append(filter.Types, eventstream.Type(t))
+		}
+	}
+
+	sub := s.deps.EventStream.Subscribe(filter, lastID)
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", eventStreamRetry)
+	c.Writer.Flush()
+
+	timeout := time.NewTimer(eventStreamDuration)
+	defer timeout.Stop()
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-timeout.C:
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(c.Writer, ": keep-alive\n\n")
+		case e, ok := <-sub.Events():
+			if !ok {
+				// Subscription dropped, client reconnects
+				return
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				s.deps.Log.Warn().Err(err).Msg("Failed to encode event")
+				continue
+			}
+			fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+		}
+		c.Writer.Flush()
+	}
+}
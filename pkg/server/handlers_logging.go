@@ -0,0 +1,93 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LogLevelRequest is the request body used to change the log level of a logger.
+type LogLevelRequest struct {
+	// Level to set (debug, info, warn, error, fatal, panic)
+	Level string `json:"level"`
+	// TTL of the temporary override (e.g. 15m). When empty, the level is changed permanently (until operator restart).
+	TTL string `json:"ttl,omitempty"`
+}
+
+// handleGetLogLevels returns the current log levels of all loggers.
+func (s *Server) handleGetLogLevels(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"loggers": s.deps.LogService.GetLevels(),
+	})
+}
+
+// handleSetLogLevel changes the log level of the logger with given name.
+func (s *Server) handleSetLogLevel(c *gin.Context) {
+	name := c.Params.ByName("name")
+
+	var req LogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		sendError(c, errors.Wrap(BadRequestError, err.Error()))
+		return
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		d, err := time.ParseDuration(req.TTL)
+		if err != nil {
+			sendError(c, errors.Wrap(BadRequestError, err.Error()))
+			return
+		}
+		if d <= 0 {
+			sendError(c, errors.Wrapf(BadRequestError, "TTL needs to be greater than 0, got %s", req.TTL))
+			return
+		}
+		ttl = d
+	}
+
+	err := s.deps.LogService.SetLevel(name, req.Level, ttl)
+	s.audit(c, "logging.set", err).Str("logger", name).Str("level", req.Level).Dur("ttl", ttl).Msg("Log level change")
+	if err != nil {
+		sendError(c, errors.Wrap(BadRequestError, err.Error()))
+		return
+	}
+
+	s.handleGetLogLevels(c)
+}
+
+// handleResetLogLevel removes the temporary override of the log level of the logger with given name.
+func (s *Server) handleResetLogLevel(c *gin.Context) {
+	name := c.Params.ByName("name")
+
+	err := s.deps.LogService.ResetLevel(name)
+	s.audit(c, "logging.reset", err).Str("logger", name).Msg("Log level override removal")
+	if err != nil {
+		sendError(c, errors.Wrap(BadRequestError, err.Error()))
+		return
+	}
+
+	s.handleGetLogLevels(c)
+}
@@ -23,7 +23,11 @@
 package server
 
 import (
+	"context"
 	"net/http"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 
 	"github.com/gin-gonic/gin"
 )
@@ -35,14 +39,18 @@ type DeploymentReplication interface {
 	StateColor() StateColor
 	Source() Endpoint
 	Destination() Endpoint
+
+	// Abort stops the replication
+	Abort(ctx context.Context) error
 }
 
 // DeploymentReplicationOperator is the API implemented by the deployment operator.
 type DeploymentReplicationOperator interface {
 	// GetDeploymentReplications returns basic information for all deployment replications managed by the operator
 	GetDeploymentReplications() ([]DeploymentReplication, error)
-	// GetDeploymentReplication returns detailed information for a deployment replication, managed by the operator, with given name
-	GetDeploymentReplication(name string) (DeploymentReplication, error)
+	// GetDeploymentReplication returns detailed information for a deployment replication, managed by the operator, with given name.
+	// Namespace can be empty if the name is unique across all watched namespaces.
+	GetDeploymentReplication(namespace, name string) (DeploymentReplication, error)
 }
 
 // DeploymentReplicationInfo is the information returned per deployment replication.
@@ -73,6 +81,11 @@ type Endpoint interface {
 	AuthUserSecretName() string
 	TLSCACert() string
 	TLSCACertSecretName() string
+	// Reachable returns false when the syncmaster of the endpoint cannot be reached by the operator
+	Reachable() bool
+	ConsecutiveFailures() int
+	LastSuccessTime() *time.Time
+	LastError() string
 }
 
 // EndpointInfo is the information returned per source/destination endpoint of the replication.
@@ -83,6 +96,11 @@ type EndpointInfo struct {
 	AuthUserSecretName    string   `json:"auth_user_secret_name"`
 	TLSCACert             string   `json:"tls_ca_cert"`
 	TLSCACertSecretName   string   `json:"tls_ca_cert_secret_name"`
+
+	Reachable           bool       `json:"reachable"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastSuccessTime     *time.Time `json:"last_success_time,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
 }
 
 // newEndpointInfo initializes an EndpointInfo for the given Endpoint.
@@ -94,6 +112,10 @@ func newEndpointInfo(ep Endpoint) EndpointInfo {
 		AuthUserSecretName:    ep.AuthUserSecretName(),
 		TLSCACert:             ep.TLSCACert(),
 		TLSCACertSecretName:   ep.TLSCACertSecretName(),
+		Reachable:             ep.Reachable(),
+		ConsecutiveFailures:   ep.ConsecutiveFailures(),
+		LastSuccessTime:       ep.LastSuccessTime(),
+		LastError:             ep.LastError(),
 	}
 }
 
@@ -118,9 +140,12 @@ func (s *Server) handleGetDeploymentReplications(c *gin.Context) {
 		if err != nil {
 			sendError(c, err)
 		} else {
-			result := make([]DeploymentReplicationInfo, len(repls))
-			for i, dr := range repls {
-				result[i] = newDeploymentReplicationInfo(dr)
+			id := getIdentity(c)
+			result := make([]DeploymentReplicationInfo, 0, len(repls))
+			for _, dr := range repls {
+				if id.IsAllowed(dr.Namespace(), RoleViewer) {
+					result = append(result, newDeploymentReplicationInfo(dr))
+				}
 			}
 			c.JSON(http.StatusOK, gin.H{
 				"replications": result,
@@ -133,7 +158,7 @@ func (s *Server) handleGetDeploymentReplications(c *gin.Context) {
 func (s *Server) handleGetDeploymentReplicationDetails(c *gin.Context) {
 	if do := s.deps.Operators.DeploymentReplicationOperator(); do != nil {
 		// Fetch deployments
-		dr, err := do.GetDeploymentReplication(c.Params.ByName("name"))
+		dr, err := findDeploymentReplication(c, do)
 		if err != nil {
 			sendError(c, err)
 		} else {
@@ -142,3 +167,61 @@ func (s *Server) handleGetDeploymentReplicationDetails(c *gin.Context) {
 		}
 	}
 }
+
+// Handle a POST /api/deployment-replication/:name/abort request
+func (s *Server) handleAbortDeploymentReplication(c *gin.Context) {
+	do := s.deps.Operators.DeploymentReplicationOperator()
+	if do == nil {
+		sendError(c, errors.WithStack(NotFoundError))
+		return
+	}
+
+	dr, err := findDeploymentReplication(c, do)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	if !s.authorize(c, "deployment-replication.abort", dr.Namespace(), RoleAdmin) {
+		return
+	}
+
+	err = dr.Abort(c.Request.Context())
+	s.audit(c, "deployment-replication.abort", err).Str("namespace", dr.Namespace()).Str("replication", dr.Name()).
+		Msg("Replication abort")
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{})
+}
+
+// findDeploymentReplication returns the replication with name taken from the request path, among the replications
+// which the authenticated user is allowed to view. Replications in other namespaces are reported
+// in the same way as missing ones, so their names are not disclosed.
+func findDeploymentReplication(c *gin.Context, do DeploymentReplicationOperator) (DeploymentReplication, error) {
+	id := getIdentity(c)
+	namespace, name := c.Query("namespace"), c.Params.ByName("name")
+
+	repls, err := do.GetDeploymentReplications()
+	if err != nil {
+		return nil, err
+	}
+
+	var result DeploymentReplication
+	for _, dr := range repls {
+		if dr.Name() != name || (namespace != "" && dr.Namespace() != namespace) || !id.IsAllowed(dr.Namespace(), RoleViewer) {
+			continue
+		}
+		if result != nil {
+			return nil, errors.Wrapf(BadRequestError, "replication %s exists in multiple namespaces, namespace is required", name)
+		}
+		result = dr
+	}
+	if result == nil {
+		return nil, errors.Wrapf(NotFoundError, "replication %s", name)
+	}
+
+	return do.GetDeploymentReplication(result.Namespace(), result.Name())
+}
@@ -0,0 +1,75 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// supportBundleTimeout is the time in which the support bundle needs to be collected and sent.
+	// Server-wide write timeout is too short for large deployments.
+	supportBundleTimeout = 10 * time.Minute
+)
+
+// Handle a GET /api/deployment/:name/support-bundle request
+func (s *Server) handleGetDeploymentSupportBundle(c *gin.Context) {
+	var logLines int64
+	if v := c.Query("log_lines"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			sendError(c, errors.Wrapf(BadRequestError, "log_lines needs to be a positive number, got %s", v))
+			return
+		}
+		logLines = n
+	}
+
+	depl, ok := s.getDeployment(c, "deployment.support-bundle", RoleOperator)
+	if !ok {
+		return
+	}
+
+	if err := setWriteDeadline(c.Request, time.Now().Add(supportBundleTimeout)); err != nil {
+		s.deps.Log.Warn().Err(err).Msg("Failed to extend write deadline of support bundle")
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), supportBundleTimeout)
+	defer cancel()
+
+	fileName := fmt.Sprintf("%s-support-%s.tar.gz", depl.Name(), time.Now().UTC().Format("20060102T150405Z"))
+	c.Header("Content-Type", "application/gzip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+
+	err := depl.SupportBundle(ctx, c.Writer, logLines)
+	s.audit(c, "deployment.support-bundle", err).Str("namespace", depl.Namespace()).Str("deployment", depl.Name()).
+		Msg("Support bundle")
+	if err != nil && !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		sendError(c, err)
+	}
+}
@@ -0,0 +1,207 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"strings"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+)
+
+// Role defines the set of operations allowed in the dashboard.
+type Role string
+
+const (
+	// RoleViewer allows to inspect resources
+	RoleViewer Role = "viewer"
+	// RoleOperator allows to inspect resources and run common day-2 operations (scale, rotate, maintenance, backup)
+	RoleOperator Role = "operator"
+	// RoleAdmin allows all operations
+	RoleAdmin Role = "admin"
+)
+
+// level returns the position of the role in the hierarchy. Unknown roles have level 0.
+func (r Role) level() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleOperator:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+// Includes returns true if the role allows everything that the other role allows.
+func (r Role) Includes(other Role) bool {
+	return r.level() > 0 && r.level() >= other.level()
+}
+
+// Validate the role.
+func (r Role) Validate() error {
+	if r.level() == 0 {
+		return errors.Newf("Unknown role '%s'. Possible roles: %s, %s, %s", r, RoleViewer, RoleOperator, RoleAdmin)
+	}
+	return nil
+}
+
+// RoleBinding assigns a role to the members of a group, optionally limited to the given namespaces.
+type RoleBinding struct {
+	Group      string
+	Role       Role
+	Namespaces []string
+}
+
+// IsClusterWide returns true if the binding is not limited to namespaces.
+func (r RoleBinding) IsClusterWide() bool {
+	return len(r.Namespaces) == 0
+}
+
+// matchesNamespace returns true if the binding applies to the given namespace.
+// Empty namespace refers to cluster-wide resources, so only cluster-wide bindings apply.
+func (r RoleBinding) matchesNamespace(namespace string) bool {
+	if r.IsClusterWide() {
+		return true
+	}
+	if namespace == "" {
+		return false
+	}
+	for _, ns := range r.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRoleBindings parses role bindings in format <group>=<role>[:<namespace>[,<namespace>...]]
+func ParseRoleBindings(bindings []string) ([]RoleBinding, error) {
+	result := make([]RoleBinding, 0, len(bindings))
+
+	for _, binding := range bindings {
+		parts := strings.SplitN(binding, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Newf("invalid role binding '%s': expected <group>=<role>[:<namespace>,...]", binding)
+		}
+
+		rb := RoleBinding{
+			Group: parts[0],
+		}
+
+		roleParts := strings.SplitN(parts[1], ":", 2)
+		rb.Role = Role(roleParts[0])
+		if err := rb.Role.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid role binding '%s'", binding)
+		}
+
+		if len(roleParts) == 2 {
+			for _, ns := range strings.Split(roleParts[1], ",") {
+				if ns = strings.TrimSpace(ns); ns != "" {
+					rb.Namespaces = append(rb.Namespaces, ns)
+				}
+			}
+			if len(rb.Namespaces) == 0 {
+				return nil, errors.Newf("invalid role binding '%s': namespace list is empty", binding)
+			}
+		}
+
+		result = append(result, rb)
+	}
+
+	return result, nil
+}
+
+// Identity is the authenticated user of the dashboard.
+type Identity struct {
+	Username string
+	Groups   []string
+	Bindings []RoleBinding
+}
+
+// newAdminIdentity returns identity which is allowed to do everything.
+func newAdminIdentity(username string) Identity {
+	return Identity{
+		Username: username,
+		Bindings: []RoleBinding{
+			{
+				Role: RoleAdmin,
+			},
+		},
+	}
+}
+
+// newAnonymousIdentity returns identity of the not authenticated user, which is allowed only to see the resources.
+func newAnonymousIdentity() Identity {
+	return Identity{
+		Username: anonymousUsername,
+		Bindings: []RoleBinding{
+			{
+				Role: RoleViewer,
+			},
+		},
+	}
+}
+
+// newIdentity returns identity with bindings matching the given groups.
+func newIdentity(username string, groups []string, bindings []RoleBinding) Identity {
+	id := Identity{
+		Username: username,
+		Groups:   groups,
+	}
+
+	for _, b := range bindings {
+		for _, g := range groups {
+			if b.Group == g {
+				id.Bindings = append(id.Bindings, b)
+				break
+			}
+		}
+	}
+
+	return id
+}
+
+// HasAnyRole returns true if identity has at least one role assigned.
+func (i Identity) HasAnyRole() bool {
+	return len(i.Bindings) > 0
+}
+
+// IsAllowed returns true if identity has the required role in the given namespace.
+// Empty namespace refers to cluster-wide resources.
+func (i Identity) IsAllowed(namespace string, required Role) bool {
+	for _, b := range i.Bindings {
+		if b.Role.Includes(required) && b.matchesNamespace(namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAllowedAnywhere returns true if identity has the required role in at least one namespace.
+func (i Identity) IsAllowedAnywhere(required Role) bool {
+	for _, b := range i.Bindings {
+		if b.Role.Includes(required) {
+			return true
+		}
+	}
+	return false
+}
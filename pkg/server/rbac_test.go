@@ -0,0 +1,86 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseRoleBindings(t *testing.T) {
+	bindings, err := ParseRoleBindings([]string{"admins=admin", "dev=operator:team-a,team-b", "qa=viewer:team-a"})
+	require.NoError(t, err)
+	require.Equal(t, []RoleBinding{
+		{Group: "admins", Role: RoleAdmin},
+		{Group: "dev", Role: RoleOperator, Namespaces: []string{"team-a", "team-b"}},
+		{Group: "qa", Role: RoleViewer, Namespaces: []string{"team-a"}},
+	}, bindings)
+
+	for _, invalid := range []string{"admins", "=admin", "admins=", "admins=root", "dev=operator:", "dev=operator:,"} {
+		t.Run(invalid, func(t *testing.T) {
+			_, err := ParseRoleBindings([]string{invalid})
+			require.Error(t, err)
+		})
+	}
+}
+
+func Test_Identity_IsAllowed(t *testing.T) {
+	bindings, err := ParseRoleBindings([]string{"admins=admin", "dev=operator:team-a", "qa=viewer:team-a,team-b"})
+	require.NoError(t, err)
+
+	admin := newIdentity("admin", []string{"admins"}, bindings)
+	require.True(t, admin.IsAllowed("", RoleAdmin))
+	require.True(t, admin.IsAllowed("team-c", RoleAdmin))
+
+	dev := newIdentity("dev", []string{"dev", "qa"}, bindings)
+	require.True(t, dev.IsAllowed("team-a", RoleOperator))
+	require.False(t, dev.IsAllowed("team-a", RoleAdmin))
+	require.True(t, dev.IsAllowed("team-b", RoleViewer))
+	require.False(t, dev.IsAllowed("team-b", RoleOperator))
+	require.False(t, dev.IsAllowed("team-c", RoleViewer))
+	require.False(t, dev.IsAllowed("", RoleViewer))
+	require.True(t, dev.IsAllowedAnywhere(RoleOperator))
+	require.False(t, dev.IsAllowedAnywhere(RoleAdmin))
+
+	unknown := newIdentity("unknown", []string{"other"}, bindings)
+	require.False(t, unknown.HasAnyRole())
+	require.False(t, unknown.IsAllowedAnywhere(RoleViewer))
+}
+
+func Test_IdentityFromClaims(t *testing.T) {
+	bindings, err := ParseRoleBindings([]string{"dev=operator"})
+	require.NoError(t, err)
+
+	id := identityFromClaims(map[string]interface{}{
+		"email":  "jane@example.com",
+		"groups": []interface{}{"dev", "other"},
+	}, "sub-1", "email", "groups", bindings)
+	require.Equal(t, "jane@example.com", id.Username)
+	require.Equal(t, []string{"dev", "other"}, id.Groups)
+	require.True(t, id.IsAllowed("any", RoleOperator))
+
+	id = identityFromClaims(map[string]interface{}{
+		"groups": "dev",
+	}, "sub-1", "email", "groups", bindings)
+	require.Equal(t, "sub-1", id.Username)
+	require.True(t, id.HasAnyRole())
+}
@@ -25,6 +25,7 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -44,19 +45,23 @@ import (
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
 	"github.com/arangodb/kube-arangodb/dashboard"
+	"github.com/arangodb/kube-arangodb/pkg/logging"
+	"github.com/arangodb/kube-arangodb/pkg/util/eventstream"
 	"github.com/arangodb/kube-arangodb/pkg/util/probe"
 )
 
 // Config settings for the Server
 type Config struct {
 	Namespace          string
-	Address            string // Address to listen on
-	TLSSecretName      string // Name of secret containing TLS certificate
-	TLSSecretNamespace string // Namespace of secret containing TLS certificate
-	PodName            string // Name of the Pod we're running in
-	PodIP              string // IP address of the Pod we're running in
-	AdminSecretName    string // Name of basic authentication secret containing the admin username+password of the dashboard
-	AllowAnonymous     bool   // If set, anonymous access to dashboard is allowed
+	Address            string        // Address to listen on
+	TLSSecretName      string        // Name of secret containing TLS certificate
+	TLSSecretNamespace string        // Namespace of secret containing TLS certificate
+	PodName            string        // Name of the Pod we're running in
+	PodIP              string        // IP address of the Pod we're running in
+	AdminSecretName    string        // Name of basic authentication secret containing the admin username+password of the dashboard
+	AllowAnonymous     bool          // If set, anonymous access to dashboard is allowed
+	OIDC               OIDCConfig    // OIDC login configuration of the dashboard
+	RoleBindings       []RoleBinding // Mapping of OIDC groups to dashboard roles
 }
 
 type OperatorDependency struct {
@@ -67,12 +72,15 @@ type OperatorDependency struct {
 // Dependencies of the Server
 type Dependencies struct {
 	Log                   zerolog.Logger
+	AuditLog              zerolog.Logger // Log of the audit entries, its level can not be changed at runtime
+	LogService            logging.Service
 	LivenessProbe         *probe.LivenessProbe
 	Deployment            OperatorDependency
 	DeploymentReplication OperatorDependency
 	Storage               OperatorDependency
 	Backup                OperatorDependency
 	Operators             Operators
+	EventStream           eventstream.Stream
 	Secrets               corev1.SecretInterface
 }
 
@@ -84,6 +92,8 @@ type Operators interface {
 	DeploymentReplicationOperator() DeploymentReplicationOperator
 	// Return the local storage operator (if any)
 	StorageOperator() StorageOperator
+	// Return the backup operator (if any)
+	BackupOperator() BackupOperator
 	// FindOtherOperators looks up references to other operators in the same Kubernetes cluster.
 	FindOtherOperators() []OperatorReference
 }
@@ -104,6 +114,7 @@ func NewServer(cli corev1.CoreV1Interface, cfg Config, deps Dependencies) (*Serv
 		ReadHeaderTimeout: time.Second * 15,
 		WriteTimeout:      time.Second * 30,
 		TLSNextProto:      make(map[string]func(*http.Server, *tls.Conn, http.Handler)),
+		ConnContext:       connContext,
 	}
 
 	var cert, key string
@@ -151,7 +162,7 @@ func NewServer(cli corev1.CoreV1Interface, cfg Config, deps Dependencies) (*Serv
 		cfg:        cfg,
 		deps:       deps,
 		httpServer: httpServer,
-		auth:       newServerAuthentication(deps.Log, deps.Secrets, cfg.AdminSecretName, cfg.AllowAnonymous),
+		auth:       newServerAuthentication(deps.Log, deps.Secrets, cfg.AdminSecretName, cfg.AllowAnonymous, cfg.OIDC, cfg.RoleBindings),
 	}
 
 	// Build router
@@ -183,6 +194,11 @@ func NewServer(cli corev1.CoreV1Interface, cfg Config, deps Dependencies) (*Serv
 	r.GET("/ready", gin.WrapF(ready(readyProbes...)))
 	r.GET("/metrics", gin.WrapH(prometheus.Handler()))
 	r.POST("/login", s.auth.handleLogin)
+	r.GET("/login/methods", s.auth.handleLoginMethods)
+	if s.auth.oidc != nil {
+		r.GET("/login/oidc", s.auth.oidc.handleLogin)
+		r.GET("/login/oidc/callback", s.auth.handleOIDCCallback)
+	}
 	api := r.Group("/api", s.auth.checkAuthentication)
 	{
 		api.GET("/operators", s.handleGetOperators)
@@ -190,14 +206,41 @@ func NewServer(cli corev1.CoreV1Interface, cfg Config, deps Dependencies) (*Serv
 		// Deployment operator
 		api.GET("/deployment", s.handleGetDeployments)
 		api.GET("/deployment/:name", s.handleGetDeploymentDetails)
+		api.POST("/deployment/:name/scale", s.handleScaleDeployment)
+		api.POST("/deployment/:name/maintenance", s.handleSetDeploymentMaintenance)
+		api.POST("/deployment/:name/member/:id/rotate", s.handleRotateDeploymentMember)
+		api.POST("/deployment/:name/backup", s.handleCreateDeploymentBackup)
+		api.POST("/deployment/:name/restore", s.handleRestoreDeploymentBackup)
+		api.GET("/deployment/:name/support-bundle", s.handleGetDeploymentSupportBundle)
+		api.GET("/deployment/:name/agency", s.handleGetDeploymentAgency)
+		api.GET("/deployment/:name/shards", s.handleGetDeploymentShards)
+		if deps.EventStream != nil {
+			api.GET("/deployment/:name/events", s.handleGetDeploymentEvents)
+		}
 
 		// Deployment replication operator
 		api.GET("/deployment-replication", s.handleGetDeploymentReplications)
 		api.GET("/deployment-replication/:name", s.handleGetDeploymentReplicationDetails)
+		api.POST("/deployment-replication/:name/abort", s.handleAbortDeploymentReplication)
 
 		// Local storage operator
-		api.GET("/storage", s.handleGetLocalStorages)
-		api.GET("/storage/:name", s.handleGetLocalStorageDetails)
+		storage := api.Group("/storage", s.requireRole("storage.get", RoleViewer))
+		storage.GET("", s.handleGetLocalStorages)
+		storage.GET("/:name", s.handleGetLocalStorageDetails)
+
+		// Backup operator
+		api.GET("/backup", s.handleGetBackups)
+		api.GET("/backup/:name", s.handleGetBackupDetails)
+		api.GET("/backup-policy", s.handleGetBackupPolicies)
+		api.GET("/backup-policy/:name", s.handleGetBackupPolicyDetails)
+
+		// Logging
+		if deps.LogService != nil {
+			logs := api.Group("/logging", s.requireRole("logging", RoleAdmin))
+			logs.GET("", s.handleGetLogLevels)
+			logs.PUT("/:name", s.handleSetLogLevel)
+			logs.DELETE("/:name", s.handleResetLogLevel)
+		}
 	}
 	// Dashboard
 	r.GET("/", createAssetFileHandler(dashboard.Assets.Files["index.html"]))
@@ -210,6 +253,24 @@ func NewServer(cli corev1.CoreV1Interface, cfg Config, deps Dependencies) (*Serv
 	return s, nil
 }
 
+// connContextKey is the key of the connection of the request in the request context.
+type connContextKey struct{}
+
+// connContext stores the connection in the context of its requests.
+func connContext(ctx context.Context, c net.Conn) context.Context {
+	return context.WithValue(ctx, connContextKey{}, c)
+}
+
+// setWriteDeadline overrides the write deadline of the server for the connection of the request,
+// in the same way as http.ResponseController does. Zero deadline disables the timeout.
+func setWriteDeadline(r *http.Request, deadline time.Time) error {
+	conn, ok := r.Context().Value(connContextKey{}).(net.Conn)
+	if !ok {
+		return errors.Newf("connection of the request is not known")
+	}
+	return conn.SetWriteDeadline(deadline)
+}
+
 // createAssetFileHandler creates a gin handler to serve the content
 // of the given asset file.
 func createAssetFileHandler(file *assets.File) func(c *gin.Context) {
@@ -0,0 +1,63 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SetWriteDeadline(t *testing.T) {
+	body := make([]byte, 1024*1024)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/extended" {
+			require.NoError(t, setWriteDeadline(r, time.Now().Add(time.Minute)))
+		}
+		time.Sleep(200 * time.Millisecond)
+		w.Write(body)
+	}))
+	ts.Config.WriteTimeout = 50 * time.Millisecond
+	ts.Config.ConnContext = connContext
+	ts.Start()
+	defer ts.Close()
+
+	get := func(path string) ([]byte, error) {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		return ioutil.ReadAll(resp.Body)
+	}
+
+	_, err := get("/default")
+	require.Error(t, err)
+
+	data, err := get("/extended")
+	require.NoError(t, err)
+	require.Len(t, data, len(body))
+}
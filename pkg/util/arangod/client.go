@@ -40,6 +40,7 @@ import (
 
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/arangodb/kube-arangodb/pkg/util/tracing"
 )
 
 type (
@@ -217,7 +218,7 @@ func createArangodHTTPConfigForDNSNames(apiObject *api.ArangoDeployment, dnsName
 		}
 	}
 	connConfig := http.ConnectionConfig{
-		Transport:          transport,
+		Transport:          tracing.WrapTransport(transport),
 		DontFollowRedirect: true,
 	}
 	for _, dnsName := range dnsNames {
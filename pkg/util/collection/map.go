@@ -45,6 +45,7 @@ var (
 		k8sutil.LabelKeyApp,
 		k8sutil.LabelKeyRole,
 		k8sutil.LabelKeyArangoExporter,
+		k8sutil.LabelKeyArangoNodeDrainPrepared,
 	}
 )
 
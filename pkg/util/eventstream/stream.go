@@ -0,0 +1,203 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package eventstream
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHistorySize is the number of events kept to be replayed for reconnecting subscribers
+	DefaultHistorySize = 256
+	// subscriptionBufferSize is the number of events buffered per subscription
+	subscriptionBufferSize = 64
+)
+
+// Type of the streamed event.
+type Type string
+
+const (
+	// TypeStatus is published when the status of a deployment changes
+	TypeStatus Type = "status"
+	// TypePlan is published when an action of the plan of a deployment is added, started or finished
+	TypePlan Type = "plan"
+	// TypeEvent is published when a Kubernetes event is created for a deployment
+	TypeEvent Type = "event"
+)
+
+// Event is a single item of the stream.
+type Event struct {
+	// ID is a sequence number of the event, assigned during publishing
+	ID         uint64      `json:"id"`
+	Type       Type        `json:"type"`
+	Namespace  string      `json:"namespace"`
+	Deployment string      `json:"deployment"`
+	Time       time.Time   `json:"time"`
+	Data       interface{} `json:"data,omitempty"`
+}
+
+// Filter selects events delivered to a subscription. Empty fields match everything.
+type Filter struct {
+	Namespace  string
+	Deployment string
+	Types      []Type
+}
+
+// Matches returns true if the event is selected by the filter.
+func (f Filter) Matches(e Event) bool {
+	if f.Namespace != "" && f.Namespace != e.Namespace {
+		return false
+	}
+	if f.Deployment != "" && f.Deployment != e.Deployment {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if t == e.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// Stream distributes events to subscribers.
+type Stream interface {
+	// Publish sends the event to all matching subscribers. Publish never blocks.
+	Publish(e Event)
+	// Subscribe returns new subscription for events matching the filter.
+	// When lastID is set, events from history published after lastID are delivered first.
+	Subscribe(filter Filter, lastID uint64) *Subscription
+}
+
+// Subscription receives events from the stream.
+type Subscription struct {
+	stream *stream
+	filter Filter
+	events chan Event
+	closed bool
+}
+
+// Events returns channel with events of the subscription.
+// The channel is closed when the subscription is closed, or when the subscriber is too slow to receive events.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close stops the subscription.
+func (s *Subscription) Close() {
+	s.stream.unsubscribe(s)
+}
+
+// NewStream creates new stream which keeps the given number of events in history.
+func NewStream(historySize int) Stream {
+	return &stream{
+		historySize:   historySize,
+		subscriptions: map[*Subscription]struct{}{},
+	}
+}
+
+type stream struct {
+	mutex         sync.Mutex
+	lastID        uint64
+	historySize   int
+	history       []Event
+	subscriptions map[*Subscription]struct{}
+}
+
+func (s *stream) Publish(e Event) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.lastID++
+	e.ID = s.lastID
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	if s.historySize > 0 {
+		if len(s.history) >= s.historySize {
+			s.history = append(s.history[:0], s.history[len(s.history)-s.historySize+1:]...)
+		}
+		s.history = append(s.history, e)
+	}
+
+	for sub := range s.subscriptions {
+		if !sub.filter.Matches(e) {
+			continue
+		}
+		select {
+		case sub.events <- e:
+		default:
+			// Subscriber is too slow, it needs to reconnect and replay history
+			s.closeSubscription(sub)
+		}
+	}
+}
+
+func (s *stream) Subscribe(filter Filter, lastID uint64) *Subscription {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var replay []Event
+	if lastID > 0 && lastID < s.lastID {
+		for _, e := range s.history {
+			if e.ID > lastID && filter.Matches(e) {
+				replay = append(replay, e)
+			}
+		}
+	}
+
+	size := subscriptionBufferSize
+	if len(replay) > size {
+		size = len(replay)
+	}
+
+	sub := &Subscription{
+		stream: s,
+		filter: filter,
+		events: make(chan Event, size),
+	}
+	for _, e := range replay {
+		sub.events <- e
+	}
+
+	s.subscriptions[sub] = struct{}{}
+	return sub
+}
+
+func (s *stream) unsubscribe(sub *Subscription) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closeSubscription(sub)
+}
+
+func (s *stream) closeSubscription(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	delete(s.subscriptions, sub)
+	close(sub.events)
+}
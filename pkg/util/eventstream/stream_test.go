@@ -0,0 +1,110 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package eventstream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, sub *Subscription, count int) []Event {
+	var result []Event
+	for i := 0; i < count; i++ {
+		select {
+		case e, ok := <-sub.Events():
+			require.True(t, ok)
+			result = append(result, e)
+		default:
+			require.FailNow(t, "missing event")
+		}
+	}
+	select {
+	case e, ok := <-sub.Events():
+		if ok {
+			require.FailNow(t, "unexpected event", "%v", e)
+		}
+	default:
+	}
+	return result
+}
+
+func Test_Stream_Filter(t *testing.T) {
+	s := NewStream(DefaultHistorySize)
+
+	all := s.Subscribe(Filter{}, 0)
+	defer all.Close()
+	depl := s.Subscribe(Filter{Namespace: "ns", Deployment: "a", Types: []Type{TypePlan}}, 0)
+	defer depl.Close()
+
+	s.Publish(Event{Type: TypePlan, Namespace: "ns", Deployment: "a"})
+	s.Publish(Event{Type: TypeStatus, Namespace: "ns", Deployment: "a"})
+	s.Publish(Event{Type: TypePlan, Namespace: "ns", Deployment: "b"})
+	s.Publish(Event{Type: TypePlan, Namespace: "other", Deployment: "a"})
+
+	events := receive(t, all, 4)
+	for i, e := range events {
+		require.EqualValues(t, i+1, e.ID)
+		require.False(t, e.Time.IsZero())
+	}
+
+	events = receive(t, depl, 1)
+	require.EqualValues(t, 1, events[0].ID)
+}
+
+func Test_Stream_Replay(t *testing.T) {
+	s := NewStream(3)
+
+	for i := 0; i < 5; i++ {
+		s.Publish(Event{Type: TypeStatus, Deployment: "a"})
+	}
+
+	// Only events after lastID, limited by history size
+	events := receive(t, s.Subscribe(Filter{}, 1), 3)
+	require.EqualValues(t, 3, events[0].ID)
+	require.EqualValues(t, 5, events[2].ID)
+
+	events = receive(t, s.Subscribe(Filter{}, 4), 1)
+	require.EqualValues(t, 5, events[0].ID)
+
+	// Nothing to replay without lastID
+	receive(t, s.Subscribe(Filter{}, 0), 0)
+}
+
+func Test_Stream_SlowSubscriber(t *testing.T) {
+	s := NewStream(0)
+
+	sub := s.Subscribe(Filter{}, 0)
+	for i := 0; i < subscriptionBufferSize+1; i++ {
+		s.Publish(Event{Type: TypeStatus})
+	}
+
+	// Buffered events are delivered, then channel is closed
+	for i := 0; i < subscriptionBufferSize; i++ {
+		_, ok := <-sub.Events()
+		require.True(t, ok)
+	}
+	_, ok := <-sub.Events()
+	require.False(t, ok)
+
+	// Close after drop is safe
+	sub.Close()
+}
@@ -31,9 +31,11 @@ import (
 	monitoringClient "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
 
 	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/tracing"
 	"k8s.io/client-go/tools/clientcmd"
 
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
@@ -42,6 +44,18 @@ const Kubeconfig util.EnvironmentVariable = "KUBECONFIG"
 
 // NewKubeConfig loads config from KUBECONFIG or as incluster
 func NewKubeConfig() (*rest.Config, error) {
+	cfg, err := newKubeConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create span for each request to the Kubernetes API, if tracing is enabled
+	cfg.Wrap(tracing.WrapTransport)
+
+	return cfg, nil
+}
+
+func newKubeConfig() (*rest.Config, error) {
 	// If KUBECONFIG is defined use this variable
 	if kubeconfig, ok := Kubeconfig.Lookup(); ok {
 		return clientcmd.BuildConfigFromFlags("", kubeconfig)
@@ -109,3 +123,16 @@ func NewKubeMonitoringV1Client() (monitoringClient.MonitoringV1Interface, error)
 	}
 	return c, nil
 }
+
+// NewKubeDynamicClient creates a new k8s dynamic client
+func NewKubeDynamicClient() (dynamic.Interface, error) {
+	cfg, err := NewKubeConfig()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	c, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return c, nil
+}
@@ -67,6 +67,16 @@ func NewMemberRemoveEvent(memberName, role string, apiObject APIObject) *Event {
 	return event
 }
 
+// NewMemberNodeDrainEvent creates an event indicating that the node of a member pod is cordoned
+// and the member is prepared for the eviction of its pod.
+func NewMemberNodeDrainEvent(memberName, role, nodeName string, apiObject APIObject) *Event {
+	event := newDeploymentEvent(apiObject)
+	event.Type = v1.EventTypeNormal
+	event.Reason = fmt.Sprintf("%s Node Drain", strings.Title(role))
+	event.Message = fmt.Sprintf("Node %s of %s %s is cordoned, preparing member for eviction", nodeName, role, memberName)
+	return event
+}
+
 // NewPodCreatedEvent creates an event indicating that a pod has been created
 func NewPodCreatedEvent(podName, role string, apiObject APIObject) *Event {
 	event := newDeploymentEvent(apiObject)
@@ -258,6 +268,66 @@ func NewUpgradeNotAllowedEvent(apiObject APIObject,
 	return event
 }
 
+// NewReplicationOperationEvent creates an event indicating the progress of a
+// switchover or failover operation of a deployment replication.
+func NewReplicationOperationEvent(apiObject APIObject, operation, message string) *Event {
+	event := newDeploymentEvent(apiObject)
+	event.Type = v1.EventTypeNormal
+	event.Reason = fmt.Sprintf("Replication %s", operation)
+	event.Message = message
+	return event
+}
+
+// NewReplicationSecretsChangedEvent creates an event indicating that secrets used by the
+// synchronization of a deployment replication have changed.
+func NewReplicationSecretsChangedEvent(apiObject APIObject, changedSecretNames []string) *Event {
+	event := newDeploymentEvent(apiObject)
+	event.Type = v1.EventTypeNormal
+	event.Reason = "Replication Secrets Changed"
+	event.Message = fmt.Sprintf("Found %d changed secrets, synchronization is configured again. Secrets: %v", len(changedSecretNames), changedSecretNames)
+	return event
+}
+
+// NewReplicationLagExceededEvent creates an event indicating that the lag of a
+// deployment replication exceeds its thresholds.
+func NewReplicationLagExceededEvent(apiObject APIObject, reason string) *Event {
+	event := newDeploymentEvent(apiObject)
+	event.Type = v1.EventTypeWarning
+	event.Reason = "Replication Lag Exceeded"
+	event.Message = reason
+	return event
+}
+
+// NewReplicationLagResolvedEvent creates an event indicating that the lag of a
+// deployment replication is back within its thresholds.
+func NewReplicationLagResolvedEvent(apiObject APIObject) *Event {
+	event := newDeploymentEvent(apiObject)
+	event.Type = v1.EventTypeNormal
+	event.Reason = "Replication Lag Resolved"
+	event.Message = "The replication lag is back within the thresholds"
+	return event
+}
+
+// NewReplicationEndpointUnreachableEvent creates an event indicating that the operator
+// cannot reach the syncmaster of a deployment replication endpoint.
+func NewReplicationEndpointUnreachableEvent(apiObject APIObject, endpoint string, err error) *Event {
+	event := newDeploymentEvent(apiObject)
+	event.Type = v1.EventTypeWarning
+	event.Reason = "Replication Endpoint Unreachable"
+	event.Message = fmt.Sprintf("The %s syncmaster cannot be reached: %v", endpoint, err)
+	return event
+}
+
+// NewReplicationEndpointReachableEvent creates an event indicating that the syncmaster
+// of a deployment replication endpoint can be reached again.
+func NewReplicationEndpointReachableEvent(apiObject APIObject, endpoint string) *Event {
+	event := newDeploymentEvent(apiObject)
+	event.Type = v1.EventTypeNormal
+	event.Reason = "Replication Endpoint Reachable"
+	event.Message = fmt.Sprintf("The %s syncmaster can be reached again", endpoint)
+	return event
+}
+
 // NewErrorEvent creates an even of type error.
 func NewErrorEvent(reason string, err error, apiObject APIObject) *Event {
 	event := newDeploymentEvent(apiObject)
@@ -83,9 +83,10 @@ func ExtractStorageResourceRequirement(resources v1.ResourceRequirements) v1.Res
 }
 
 // CreatePersistentVolumeClaim creates a persistent volume claim with given name and configuration.
+// If dataSource is set, the pvc is populated from it (e.g. from a VolumeSnapshot).
 // If the pvc already exists, nil is returned.
 // If another error occurs, that error is returned.
-func CreatePersistentVolumeClaim(ctx context.Context, pvcs PersistentVolumeClaimInterface, pvcName, deploymentName, ns, storageClassName, role string, enforceAntiAffinity bool, resources v1.ResourceRequirements, vct *v1.PersistentVolumeClaim, finalizers []string, owner metav1.OwnerReference) error {
+func CreatePersistentVolumeClaim(ctx context.Context, pvcs PersistentVolumeClaimInterface, pvcName, deploymentName, ns, storageClassName, role string, enforceAntiAffinity bool, resources v1.ResourceRequirements, vct *v1.PersistentVolumeClaim, dataSource *v1.TypedLocalObjectReference, finalizers []string, owner metav1.OwnerReference) error {
 	labels := LabelsForDeployment(deploymentName, role)
 	volumeMode := v1.PersistentVolumeFilesystem
 	pvc := &v1.PersistentVolumeClaim{
@@ -113,6 +114,9 @@ func CreatePersistentVolumeClaim(ctx context.Context, pvcs PersistentVolumeClaim
 	if storageClassName != "" {
 		pvc.Spec.StorageClassName = &storageClassName
 	}
+	if dataSource != nil {
+		pvc.Spec.DataSource = dataSource
+	}
 	AddOwnerRefToObject(pvc.GetObjectMeta(), &owner)
 	if _, err := pvcs.Create(ctx, pvc, metav1.CreateOptions{}); err != nil && !IsAlreadyExists(err) {
 		return errors.WithStack(err)
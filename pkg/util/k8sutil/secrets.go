@@ -25,6 +25,11 @@ package k8sutil
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/arangodb/kube-arangodb/pkg/util/errors"
 	core "k8s.io/api/core/v1"
@@ -374,3 +379,16 @@ func GetSecretAuthCredentials(secret *core.Secret) (string, string, error) {
 	}
 	return string(username), string(password), nil
 }
+
+// GetSecretHash returns a sha256 hash over the data of the given secret.
+func GetSecretHash(s *core.Secret) string {
+	rows := make([]string, 0, len(s.Data))
+	for k, v := range s.Data {
+		rows = append(rows, k+"="+hex.EncodeToString(v))
+	}
+	// Sort so we're not detecting order differences
+	sort.Strings(rows)
+	data := strings.Join(rows, "\n")
+	rawHash := sha256.Sum256([]byte(data))
+	return fmt.Sprintf("%0x", rawHash)
+}
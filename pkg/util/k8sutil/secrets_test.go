@@ -0,0 +1,36 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package k8sutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+)
+
+func TestGetSecretHash(t *testing.T) {
+	a := GetSecretHash(&core.Secret{Data: map[string][]byte{"tls.keyfile": []byte("a"), "ca.crt": []byte("b")}})
+	require.Len(t, a, 64)
+	require.Equal(t, a, GetSecretHash(&core.Secret{Data: map[string][]byte{"ca.crt": []byte("b"), "tls.keyfile": []byte("a")}}))
+	require.NotEqual(t, a, GetSecretHash(&core.Secret{Data: map[string][]byte{"tls.keyfile": []byte("b"), "ca.crt": []byte("b")}}))
+	require.NotEqual(t, a, GetSecretHash(&core.Secret{Data: map[string][]byte{"tls.keyfile": []byte("a")}}))
+}
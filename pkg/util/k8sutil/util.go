@@ -50,6 +50,8 @@ const (
 	LabelKeyArangoZone = "deployment.arangodb.com/zone"
 	// LabelKeyArangoTopology is the key of the label used to store the ArangoDeployment topology ID in
 	LabelKeyArangoTopology = "deployment.arangodb.com/topology"
+	// LabelKeyArangoNodeDrainPrepared is the key of the label used to mark pods of DBServers prepared for the node drain
+	LabelKeyArangoNodeDrainPrepared = "deployment.arangodb.com/node-drain-prepared"
 
 	// AppName is the fixed value for the "app" label
 	AppName = "arangodb"
@@ -0,0 +1,126 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package k8sutil
+
+import (
+	"context"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// VolumeSnapshotAPIGroup is the API group of the CSI VolumeSnapshot resources
+	VolumeSnapshotAPIGroup = "snapshot.storage.k8s.io"
+	// VolumeSnapshotKind is the kind of the CSI VolumeSnapshot resource
+	VolumeSnapshotKind = "VolumeSnapshot"
+)
+
+// VolumeSnapshotGVR defines the GroupVersionResource of the CSI VolumeSnapshot
+var VolumeSnapshotGVR = schema.GroupVersionResource{
+	Group:    VolumeSnapshotAPIGroup,
+	Version:  "v1",
+	Resource: "volumesnapshots",
+}
+
+// VolumeSnapshotStatus keeps the relevant part of the CSI VolumeSnapshot status
+type VolumeSnapshotStatus struct {
+	// Created is true when the snapshot has been cut on the storage backend
+	Created bool
+	// ReadyToUse is true when the snapshot can be used as a data source
+	ReadyToUse bool
+	// Error keeps the last error message reported by the snapshot controller
+	Error string
+}
+
+// CreateVolumeSnapshotName returns the name of the VolumeSnapshot for a member with
+// a given id created by the ArangoVolumeSnapshot with a given name.
+func CreateVolumeSnapshotName(snapshotName, role, id string) string {
+	return snapshotName + "-" + role + "-" + stripArangodPrefix(id)
+}
+
+// NewVolumeSnapshotDataSource returns a PVC data source pointing to the VolumeSnapshot with a given name.
+func NewVolumeSnapshotDataSource(name string) *core.TypedLocalObjectReference {
+	apiGroup := VolumeSnapshotAPIGroup
+	return &core.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     VolumeSnapshotKind,
+		Name:     name,
+	}
+}
+
+// CreateVolumeSnapshot creates a VolumeSnapshot of the given persistent volume claim.
+// If the VolumeSnapshot already exists, nil is returned.
+// If another error occurs, that error is returned.
+func CreateVolumeSnapshot(ctx context.Context, cli dynamic.Interface, name, ns, pvcName string, className *string, labels map[string]string, owner meta.OwnerReference) error {
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": pvcName,
+		},
+	}
+	if className != nil {
+		spec["volumeSnapshotClassName"] = *className
+	}
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": VolumeSnapshotGVR.GroupVersion().String(),
+			"kind":       VolumeSnapshotKind,
+			"spec":       spec,
+		},
+	}
+	obj.SetName(name)
+	obj.SetNamespace(ns)
+	obj.SetLabels(labels)
+	obj.SetOwnerReferences([]meta.OwnerReference{owner})
+
+	if _, err := cli.Resource(VolumeSnapshotGVR).Namespace(ns).Create(ctx, obj, meta.CreateOptions{}); err != nil && !IsAlreadyExists(err) {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// GetVolumeSnapshotStatus returns the status of the VolumeSnapshot with a given name.
+func GetVolumeSnapshotStatus(ctx context.Context, cli dynamic.Interface, name, ns string) (VolumeSnapshotStatus, error) {
+	obj, err := cli.Resource(VolumeSnapshotGVR).Namespace(ns).Get(ctx, name, meta.GetOptions{})
+	if err != nil {
+		return VolumeSnapshotStatus{}, errors.WithStack(err)
+	}
+
+	var status VolumeSnapshotStatus
+
+	if _, ok, _ := unstructured.NestedString(obj.Object, "status", "creationTime"); ok {
+		status.Created = true
+	}
+	if ready, ok, _ := unstructured.NestedBool(obj.Object, "status", "readyToUse"); ok {
+		status.ReadyToUse = ready
+	}
+	if message, ok, _ := unstructured.NestedString(obj.Object, "status", "error", "message"); ok {
+		status.Error = message
+	}
+
+	return status, nil
+}
@@ -0,0 +1,219 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TracerName is the name of the tracer used by the operator
+	TracerName = "github.com/arangodb/kube-arangodb"
+
+	AttributeDeployment attribute.Key = "arangodb.deployment"
+	AttributeNamespace  attribute.Key = "arangodb.namespace"
+	AttributeGroup      attribute.Key = "arangodb.group"
+	AttributeMember     attribute.Key = "arangodb.member"
+	AttributeAction     attribute.Key = "arangodb.action.id"
+	AttributeActionType attribute.Key = "arangodb.action.type"
+	AttributePlan       attribute.Key = "arangodb.plan"
+)
+
+// Config keeps the tracing settings
+type Config struct {
+	// Endpoint of the OTLP gRPC receiver (host:port). Tracing is disabled when empty.
+	Endpoint string
+	// Insecure disables TLS for the connection to the receiver
+	Insecure bool
+	// SampleRatio defines the fraction of traces which are sampled
+	SampleRatio float64
+	// ServiceName is reported as service.name resource attribute
+	ServiceName string
+	// ServiceVersion is reported as service.version resource attribute
+	ServiceVersion string
+	// InstanceID is reported as service.instance.id resource attribute
+	InstanceID string
+}
+
+var (
+	enabledLock sync.RWMutex
+	enabled     bool
+)
+
+// IsEnabled returns true when traces are exported
+func IsEnabled() bool {
+	enabledLock.RLock()
+	defer enabledLock.RUnlock()
+
+	return enabled
+}
+
+// Init configures the global tracer provider to export traces over OTLP.
+// Returned function flushes and stops the exporter.
+func Init(ctx context.Context, cfg Config) (func(ctx context.Context) error, error) {
+	if cfg.Endpoint == "" {
+		return func(ctx context.Context) error {
+			return nil
+		}, nil
+	}
+
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.Endpoint),
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptrace.New(ctx, otlptracegrpc.NewClient(opts...))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String(cfg.ServiceName),
+		semconv.ServiceVersionKey.String(cfg.ServiceVersion),
+		semconv.ServiceInstanceIDKey.String(cfg.InstanceID),
+	))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	enabledLock.Lock()
+	defer enabledLock.Unlock()
+
+	enabled = true
+
+	return provider.Shutdown, nil
+}
+
+// Start creates a span with given name and attributes
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends the span and records the error, if any
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Detach returns a background context which keeps the span of given context.
+// Can be used when operation should not inherit cancellation, but should be part of the trace.
+func Detach(ctx context.Context) context.Context {
+	return trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+}
+
+// FuncName returns the short name of the given function, e.g. createScaleMemberPlan
+func FuncName(f interface{}) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(f).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+
+	name := fn.Name()
+	if id := strings.LastIndex(name, "."); id >= 0 {
+		name = name[id+1:]
+	}
+
+	return name
+}
+
+// WrapTransport returns RoundTripper which creates span for each request.
+// When tracing is not enabled, given RoundTripper is returned.
+func WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	if !IsEnabled() {
+		return rt
+	}
+
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return &transport{
+		rt: rt,
+	}
+}
+
+type transport struct {
+	rt http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(TracerName).Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String(req.Method),
+			semconv.HTTPURLKey.String(req.URL.Redacted()),
+			semconv.NetPeerNameKey.String(req.URL.Hostname()),
+		))
+
+	// RoundTripper should not modify the original request
+	req = req.WithContext(ctx)
+	if req.Header != nil {
+		req.Header = req.Header.Clone()
+	} else {
+		req.Header = http.Header{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil {
+		End(span, err)
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(resp.StatusCode))
+	span.End()
+
+	return resp, nil
+}
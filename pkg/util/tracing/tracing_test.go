@@ -0,0 +1,88 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func testFunction() {}
+
+func Test_FuncName(t *testing.T) {
+	require.Equal(t, "testFunction", FuncName(testFunction))
+}
+
+func Test_WrapTransport_Disabled(t *testing.T) {
+	require.Equal(t, http.DefaultTransport, WrapTransport(http.DefaultTransport))
+}
+
+func Test_WrapTransport(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	enabledLock.Lock()
+	enabled = true
+	enabledLock.Unlock()
+
+	defer func() {
+		enabledLock.Lock()
+		enabled = false
+		enabledLock.Unlock()
+	}()
+
+	var traceParent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	ctx, span := Start(context.Background(), "parent", AttributeDeployment.String("test"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: WrapTransport(http.DefaultTransport)}).Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	End(span, nil)
+
+	require.NotEmpty(t, traceParent)
+	require.Empty(t, req.Header.Get("traceparent"), "original request should not be modified")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "HTTP GET", spans[0].Name())
+	require.Equal(t, "parent", spans[1].Name())
+	require.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Equal(t, spans[1].SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+}
@@ -0,0 +1,197 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/rs/zerolog"
+	admission "k8s.io/api/admission/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// maxReviewSize limits the size of the accepted AdmissionReview and ConversionReview requests.
+const maxReviewSize = 8 * 1024 * 1024
+
+// validateFunc rejects the request by returning an error.
+type validateFunc func(req *admission.AdmissionRequest) error
+
+// mutateFunc returns the defaulted spec of the object in the request.
+// The returned bool is false when the spec does not change.
+type mutateFunc func(req *admission.AdmissionRequest) (interface{}, bool, error)
+
+// patchOperation is a single JSON patch (RFC 6902) operation.
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// newValidateHandler creates a HTTP handler of the AdmissionReview using given validation.
+func newValidateHandler(log zerolog.Logger, validate validateFunc) http.HandlerFunc {
+	return newAdmissionHandler(log, func(req *admission.AdmissionRequest) *admission.AdmissionResponse {
+		if err := validate(req); err != nil {
+			return deny(req, err)
+		}
+		return allow(req)
+	})
+}
+
+// newMutateHandler creates a HTTP handler of the AdmissionReview using given mutation.
+func newMutateHandler(log zerolog.Logger, mutate mutateFunc) http.HandlerFunc {
+	return newAdmissionHandler(log, func(req *admission.AdmissionRequest) *admission.AdmissionResponse {
+		spec, changed, err := mutate(req)
+		if err != nil {
+			return deny(req, err)
+		}
+		if !changed {
+			return allow(req)
+		}
+		// "add" replaces the existing member, so it works for objects without the spec as well
+		patch, err := json.Marshal([]patchOperation{{Op: "add", Path: "/spec", Value: spec}})
+		if err != nil {
+			return deny(req, err)
+		}
+		resp := allow(req)
+		patchType := admission.PatchTypeJSONPatch
+		resp.Patch = patch
+		resp.PatchType = &patchType
+		return resp
+	})
+}
+
+// newAdmissionHandler decodes the AdmissionReview request, and encodes the response of the given function.
+func newAdmissionHandler(log zerolog.Logger, admit func(req *admission.AdmissionRequest) *admission.AdmissionResponse) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var review admission.AdmissionReview
+		if !readReview(w, r, &review) {
+			return
+		}
+		if review.Request == nil {
+			http.Error(w, "AdmissionReview request is missing", http.StatusBadRequest)
+			return
+		}
+
+		resp := admit(review.Request)
+		if !resp.Allowed {
+			log.Debug().
+				Str("kind", review.Request.Kind.Kind).
+				Str("namespace", review.Request.Namespace).
+				Str("name", review.Request.Name).
+				Str("operation", string(review.Request.Operation)).
+				Msg(resp.Result.Message)
+		}
+
+		review.Request = nil
+		review.Response = resp
+		writeReview(w, review)
+	}
+}
+
+// readReview decodes the review from the request body.
+// Returns false when the request is invalid, the error is already written to the response then.
+func readReview(w http.ResponseWriter, r *http.Request, review interface{}) bool {
+	if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		return false
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxReviewSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	if err := json.Unmarshal(body, review); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// writeReview encodes the review to the response.
+func writeReview(w http.ResponseWriter, review interface{}) {
+	data, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// allow accepts the request.
+func allow(req *admission.AdmissionRequest) *admission.AdmissionResponse {
+	return &admission.AdmissionResponse{
+		UID:     req.UID,
+		Allowed: true,
+	}
+}
+
+// deny rejects the request with the message of the given error.
+func deny(req *admission.AdmissionRequest, err error) *admission.AdmissionResponse {
+	return &admission.AdmissionResponse{
+		UID:     req.UID,
+		Allowed: false,
+		Result: &meta.Status{
+			Status:  meta.StatusFailure,
+			Message: err.Error(),
+			Reason:  meta.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+		},
+	}
+}
+
+// decodeObjects decodes the object and for updates the old object of the request.
+func decodeObjects(req *admission.AdmissionRequest, obj, old interface{}) error {
+	if err := decodeObject(req.Object, obj); err != nil {
+		return err
+	}
+	if req.Operation == admission.Update {
+		if err := decodeObject(req.OldObject, old); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeObject(raw runtime.RawExtension, obj interface{}) error {
+	if len(raw.Raw) == 0 {
+		return errors.Newf("object is missing in the request")
+	}
+	if err := json.Unmarshal(raw.Raw, obj); err != nil {
+		return errors.Newf("unable to decode object: %s", err.Error())
+	}
+	return nil
+}
+
+// immutableFieldsError returns an error listing the changed immutable fields, relative to `spec.`.
+func immutableFieldsError(fields []string) error {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = "spec." + f
+	}
+	return errors.Newf("immutable fields cannot be changed: %s", strings.Join(names, ", "))
+}
@@ -0,0 +1,121 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	admission "k8s.io/api/admission/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func serveAdmissionReview(t *testing.T, handler http.HandlerFunc, req *admission.AdmissionRequest) *admission.AdmissionResponse {
+	data, err := json.Marshal(admission.AdmissionReview{
+		TypeMeta: meta.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request:  req,
+	})
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var review admission.AdmissionReview
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &review))
+	require.Equal(t, "AdmissionReview", review.Kind)
+	require.NotNil(t, review.Response)
+	require.Equal(t, req.UID, review.Response.UID)
+	return review.Response
+}
+
+func Test_ValidateHandler(t *testing.T) {
+	handler := newValidateHandler(zerolog.Nop(), validateArangoDeployment)
+
+	t.Run("Allowed", func(t *testing.T) {
+		resp := serveAdmissionReview(t, handler, createRequest(t, newDeployment(api.DeploymentSpec{})))
+		require.True(t, resp.Allowed)
+	})
+
+	t.Run("Denied", func(t *testing.T) {
+		resp := serveAdmissionReview(t, handler, createRequest(t, newDeployment(api.DeploymentSpec{Mode: api.NewMode("Unknown")})))
+		require.False(t, resp.Allowed)
+		require.NotNil(t, resp.Result)
+		require.Equal(t, int32(http.StatusUnprocessableEntity), resp.Result.Code)
+		require.NotEmpty(t, resp.Result.Message)
+	})
+
+	t.Run("Invalid content type", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("{}")))
+		r.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+		handler(w, r)
+		require.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("Missing request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("{}")))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler(w, r)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func Test_MutateHandler(t *testing.T) {
+	handler := newMutateHandler(zerolog.Nop(), mutateArangoDeployment)
+
+	t.Run("Patched", func(t *testing.T) {
+		resp := serveAdmissionReview(t, handler, createRequest(t, newDeployment(api.DeploymentSpec{})))
+		require.True(t, resp.Allowed)
+		require.NotNil(t, resp.PatchType)
+		require.Equal(t, admission.PatchTypeJSONPatch, *resp.PatchType)
+
+		var patch []struct {
+			Op    string             `json:"op"`
+			Path  string             `json:"path"`
+			Value api.DeploymentSpec `json:"value"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Patch, &patch))
+		require.Len(t, patch, 1)
+		require.Equal(t, "add", patch[0].Op)
+		require.Equal(t, "/spec", patch[0].Path)
+		require.Equal(t, api.DeploymentModeCluster, patch[0].Value.GetMode())
+	})
+
+	t.Run("Not changed", func(t *testing.T) {
+		obj := newDeployment(api.DeploymentSpec{})
+		obj.Spec.SetDefaults(obj.GetName())
+
+		resp := serveAdmissionReview(t, handler, createRequest(t, obj))
+		require.True(t, resp.Allowed)
+		require.Nil(t, resp.PatchType)
+		require.Empty(t, resp.Patch)
+	})
+}
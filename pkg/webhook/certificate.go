@@ -0,0 +1,219 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"context"
+	"crypto/x509"
+	"time"
+
+	"github.com/arangodb-helper/go-certificates"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+const (
+	caTTL                  = time.Hour * 24 * 365 * 10 // 10 years
+	certificateTTL         = time.Hour * 24 * 365      // 1 year
+	certificateRenewBefore = time.Hour * 24 * 30       // 30 days
+	certificateECDSACurve  = "P256"
+)
+
+// webhookCertificate holds the PEM encoded CA and serving certificate of the webhooks.
+type webhookCertificate struct {
+	CACertificate string
+	CAKey         string
+	Certificate   string
+	Key           string
+}
+
+// loadWebhookCertificate loads the certificate from the secret.
+// Returns false when any of the keys is missing.
+func loadWebhookCertificate(s *core.Secret) (webhookCertificate, bool) {
+	var c webhookCertificate
+	for key, target := range map[string]*string{
+		constants.SecretCACertificate: &c.CACertificate,
+		constants.SecretCAKey:         &c.CAKey,
+		core.TLSCertKey:               &c.Certificate,
+		core.TLSPrivateKeyKey:         &c.Key,
+	} {
+		v, ok := s.Data[key]
+		if !ok || len(v) == 0 {
+			return webhookCertificate{}, false
+		}
+		*target = string(v)
+	}
+	return c, true
+}
+
+func (c webhookCertificate) secretData() map[string][]byte {
+	return map[string][]byte{
+		constants.SecretCACertificate: []byte(c.CACertificate),
+		constants.SecretCAKey:         []byte(c.CAKey),
+		core.TLSCertKey:               []byte(c.Certificate),
+		core.TLSPrivateKeyKey:         []byte(c.Key),
+	}
+}
+
+// isCAValid returns true when the CA can be parsed and does not expire soon.
+func (c webhookCertificate) isCAValid(now time.Time) bool {
+	ca, _, err := certificates.LoadFromPEM(c.CACertificate, c.CAKey)
+	if err != nil {
+		return false
+	}
+	return now.Add(certificateRenewBefore).Before(ca[0].NotAfter)
+}
+
+// isValid returns true when the certificate is signed by the CA for all hosts and does not expire soon.
+func (c webhookCertificate) isValid(hosts []string, now time.Time) bool {
+	ca, _, err := certificates.LoadFromPEM(c.CACertificate, c.CAKey)
+	if err != nil || !now.Add(certificateRenewBefore).Before(ca[0].NotAfter) {
+		return false
+	}
+	certs, _, err := certificates.LoadFromPEM(c.Certificate, c.Key)
+	if err != nil {
+		return false
+	}
+	return isCertificateValid(certs[0], ca[0], hosts, now)
+}
+
+func isCertificateValid(cert, ca *x509.Certificate, hosts []string, now time.Time) bool {
+	if !now.Add(certificateRenewBefore).Before(cert.NotAfter) {
+		return false
+	}
+	if err := cert.CheckSignatureFrom(ca); err != nil {
+		return false
+	}
+	for _, host := range hosts {
+		if err := cert.VerifyHostname(host); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// renew returns a new serving certificate for the given hosts.
+// The CA is kept, unless it is missing or expires soon.
+func (c webhookCertificate) renew(hosts []string, now time.Time) (webhookCertificate, error) {
+	result := webhookCertificate{
+		CACertificate: c.CACertificate,
+		CAKey:         c.CAKey,
+	}
+	if !c.isCAValid(now) {
+		options := certificates.CreateCertificateOptions{
+			CommonName: "ArangoDB Operator Webhook Root Certificate",
+			ValidFrom:  now,
+			ValidFor:   caTTL,
+			IsCA:       true,
+			ECDSACurve: certificateECDSACurve,
+		}
+		cert, key, err := certificates.CreateCertificate(options, nil)
+		if err != nil {
+			return webhookCertificate{}, errors.WithStack(err)
+		}
+		result.CACertificate, result.CAKey = cert, key
+	}
+
+	ca, err := certificates.LoadCAFromPEM(result.CACertificate, result.CAKey)
+	if err != nil {
+		return webhookCertificate{}, errors.WithStack(err)
+	}
+	options := certificates.CreateCertificateOptions{
+		CommonName: hosts[0],
+		Hosts:      hosts,
+		ValidFrom:  now,
+		ValidFor:   certificateTTL,
+		IsCA:       false,
+		ECDSACurve: certificateECDSACurve,
+	}
+	cert, key, err := certificates.CreateCertificate(options, &ca)
+	if err != nil {
+		return webhookCertificate{}, errors.WithStack(err)
+	}
+	result.Certificate, result.Key = cert, key
+	return result, nil
+}
+
+// ensureWebhookCertificate loads the certificate from the secret with given name.
+// The secret is created when it does not exist, and the certificate is renewed when it is not valid for the hosts
+// or expires soon. The secret is shared between the operator replicas, so the version stored by another replica wins.
+func ensureWebhookCertificate(ctx context.Context, secrets k8sutil.SecretInterface, secretName string, hosts []string) (webhookCertificate, error) {
+	now := time.Now()
+	s, err := secrets.Get(ctx, secretName, meta.GetOptions{})
+	if err != nil {
+		if !k8sutil.IsNotFound(err) {
+			return webhookCertificate{}, errors.WithStack(err)
+		}
+
+		c, err := webhookCertificate{}.renew(hosts, now)
+		if err != nil {
+			return webhookCertificate{}, err
+		}
+		secret := &core.Secret{
+			ObjectMeta: meta.ObjectMeta{
+				Name: secretName,
+			},
+			Data: c.secretData(),
+		}
+		if _, err := secrets.Create(ctx, secret, meta.CreateOptions{}); err != nil {
+			if k8sutil.IsAlreadyExists(err) {
+				return loadStoredWebhookCertificate(ctx, secrets, secretName)
+			}
+			return webhookCertificate{}, errors.WithStack(err)
+		}
+		return c, nil
+	}
+
+	current, _ := loadWebhookCertificate(s)
+	if current.isValid(hosts, now) {
+		return current, nil
+	}
+
+	c, err := current.renew(hosts, now)
+	if err != nil {
+		return webhookCertificate{}, err
+	}
+	s.Data = c.secretData()
+	if _, err := secrets.Update(ctx, s, meta.UpdateOptions{}); err != nil {
+		if k8sutil.IsConflict(err) {
+			return loadStoredWebhookCertificate(ctx, secrets, secretName)
+		}
+		return webhookCertificate{}, errors.WithStack(err)
+	}
+	return c, nil
+}
+
+// loadStoredWebhookCertificate loads the certificate stored by another operator replica.
+func loadStoredWebhookCertificate(ctx context.Context, secrets k8sutil.SecretInterface, secretName string) (webhookCertificate, error) {
+	s, err := secrets.Get(ctx, secretName, meta.GetOptions{})
+	if err != nil {
+		return webhookCertificate{}, errors.WithStack(err)
+	}
+	c, ok := loadWebhookCertificate(s)
+	if !ok {
+		return webhookCertificate{}, errors.Newf("Secret %s does not contain the webhook certificate", secretName)
+	}
+	return c, nil
+}
@@ -0,0 +1,77 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_EnsureWebhookCertificate(t *testing.T) {
+	ctx := context.Background()
+	secrets := fake.NewSimpleClientset().CoreV1().Secrets("default")
+	hosts := []string{"webhook.default.svc", "webhook.default", "webhook"}
+
+	created, err := ensureWebhookCertificate(ctx, secrets, "webhook-certificate", hosts)
+	require.NoError(t, err)
+	require.True(t, created.isValid(hosts, time.Now()))
+
+	t.Run("Stored certificate is reused", func(t *testing.T) {
+		c, err := ensureWebhookCertificate(ctx, secrets, "webhook-certificate", hosts)
+		require.NoError(t, err)
+		require.Equal(t, created, c)
+	})
+
+	t.Run("Certificate is renewed for new hosts with the same CA", func(t *testing.T) {
+		newHosts := []string{"other.default.svc"}
+		require.False(t, created.isValid(newHosts, time.Now()))
+
+		c, err := ensureWebhookCertificate(ctx, secrets, "webhook-certificate", newHosts)
+		require.NoError(t, err)
+		require.True(t, c.isValid(newHosts, time.Now()))
+		require.Equal(t, created.CACertificate, c.CACertificate)
+		require.NotEqual(t, created.Certificate, c.Certificate)
+
+		s, err := secrets.Get(ctx, "webhook-certificate", meta.GetOptions{})
+		require.NoError(t, err)
+		stored, ok := loadWebhookCertificate(s)
+		require.True(t, ok)
+		require.Equal(t, c, stored)
+	})
+
+	t.Run("Certificate expiring soon is not valid", func(t *testing.T) {
+		require.False(t, created.isValid(hosts, time.Now().Add(certificateTTL-certificateRenewBefore)))
+	})
+
+	t.Run("Certificate of other CA is not valid", func(t *testing.T) {
+		other, err := webhookCertificate{}.renew(hosts, time.Now())
+		require.NoError(t, err)
+
+		c := created
+		c.Certificate, c.Key = other.Certificate, other.Key
+		require.False(t, c.isValid(hosts, time.Now()))
+	})
+}
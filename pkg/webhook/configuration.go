@@ -0,0 +1,235 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"context"
+
+	admissionregistration "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	admissionregistrationv1 "k8s.io/client-go/kubernetes/typed/admissionregistration/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+const (
+	// webhookTimeoutSeconds is the time the API server waits for the webhook response
+	webhookTimeoutSeconds = 5
+	// labelNamespaceName is the label with the name of the namespace, set by the API server since Kubernetes 1.21
+	labelNamespaceName = "kubernetes.io/metadata.name"
+)
+
+// namespaceSelector returns the selector of the namespaces in which the resource is handled by the operator.
+func (c Config) namespaceSelector(r resource) (*meta.LabelSelector, error) {
+	if !r.namespaced {
+		return &meta.LabelSelector{}, nil
+	}
+
+	namespaces := []string{c.Namespace}
+	if r.watched {
+		if c.WatchNamespaceSelector != "" {
+			s, err := meta.ParseToLabelSelector(c.WatchNamespaceSelector)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Invalid namespace selector")
+			}
+			return s, nil
+		}
+		if len(c.WatchNamespaces) > 0 {
+			namespaces = c.WatchNamespaces
+		}
+	}
+
+	return &meta.LabelSelector{
+		MatchExpressions: []meta.LabelSelectorRequirement{
+			{
+				Key:      labelNamespaceName,
+				Operator: meta.LabelSelectorOpIn,
+				Values:   namespaces,
+			},
+		},
+	}, nil
+}
+
+// rules returns the rules matching creates and updates of the resource.
+func (r resource) rules() []admissionregistration.RuleWithOperations {
+	scope := admissionregistration.ClusterScope
+	if r.namespaced {
+		scope = admissionregistration.NamespacedScope
+	}
+	return []admissionregistration.RuleWithOperations{
+		{
+			Operations: []admissionregistration.OperationType{admissionregistration.Create, admissionregistration.Update},
+			Rule: admissionregistration.Rule{
+				APIGroups:   []string{r.group},
+				APIVersions: []string{r.version},
+				Resources:   []string{r.plural},
+				Scope:       &scope,
+			},
+		},
+	}
+}
+
+// clientConfig returns the client config of the webhook served on given path.
+func (c Config) clientConfig(path string, caBundle []byte) admissionregistration.WebhookClientConfig {
+	return admissionregistration.WebhookClientConfig{
+		Service: &admissionregistration.ServiceReference{
+			Namespace: c.Namespace,
+			Name:      c.ServiceName,
+			Path:      util.NewString(path),
+			Port:      util.NewInt32(c.ServicePort),
+		},
+		CABundle: caBundle,
+	}
+}
+
+// newValidatingWebhookConfiguration creates the configuration of the validating webhooks of given resources.
+// The failure policy is Ignore, so the resources can still be changed when the operator is not running.
+func newValidatingWebhookConfiguration(cfg Config, resources []resource, caBundle []byte) (*admissionregistration.ValidatingWebhookConfiguration, error) {
+	failurePolicy := admissionregistration.Ignore
+	matchPolicy := admissionregistration.Equivalent
+	sideEffects := admissionregistration.SideEffectClassNone
+
+	webhooks := make([]admissionregistration.ValidatingWebhook, 0, len(resources))
+	for _, r := range resources {
+		namespaceSelector, err := cfg.namespaceSelector(r)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, admissionregistration.ValidatingWebhook{
+			Name:                    r.webhookName(),
+			ClientConfig:            cfg.clientConfig(r.validatePath(), caBundle),
+			Rules:                   r.rules(),
+			FailurePolicy:           &failurePolicy,
+			MatchPolicy:             &matchPolicy,
+			NamespaceSelector:       namespaceSelector,
+			ObjectSelector:          &meta.LabelSelector{},
+			SideEffects:             &sideEffects,
+			TimeoutSeconds:          util.NewInt32(webhookTimeoutSeconds),
+			AdmissionReviewVersions: []string{"v1"},
+		})
+	}
+
+	return &admissionregistration.ValidatingWebhookConfiguration{
+		ObjectMeta: meta.ObjectMeta{
+			Name: cfg.configurationName(),
+		},
+		Webhooks: webhooks,
+	}, nil
+}
+
+// newMutatingWebhookConfiguration creates the configuration of the mutating webhooks of given resources.
+// Resources without defaults are skipped.
+func newMutatingWebhookConfiguration(cfg Config, resources []resource, caBundle []byte) (*admissionregistration.MutatingWebhookConfiguration, error) {
+	failurePolicy := admissionregistration.Ignore
+	matchPolicy := admissionregistration.Equivalent
+	sideEffects := admissionregistration.SideEffectClassNone
+	reinvocationPolicy := admissionregistration.NeverReinvocationPolicy
+
+	webhooks := make([]admissionregistration.MutatingWebhook, 0, len(resources))
+	for _, r := range resources {
+		if r.mutate == nil {
+			continue
+		}
+		namespaceSelector, err := cfg.namespaceSelector(r)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, admissionregistration.MutatingWebhook{
+			Name:                    r.webhookName(),
+			ClientConfig:            cfg.clientConfig(r.mutatePath(), caBundle),
+			Rules:                   r.rules(),
+			FailurePolicy:           &failurePolicy,
+			MatchPolicy:             &matchPolicy,
+			NamespaceSelector:       namespaceSelector,
+			ObjectSelector:          &meta.LabelSelector{},
+			SideEffects:             &sideEffects,
+			TimeoutSeconds:          util.NewInt32(webhookTimeoutSeconds),
+			AdmissionReviewVersions: []string{"v1"},
+			ReinvocationPolicy:      &reinvocationPolicy,
+		})
+	}
+
+	return &admissionregistration.MutatingWebhookConfiguration{
+		ObjectMeta: meta.ObjectMeta{
+			Name: cfg.configurationName(),
+		},
+		Webhooks: webhooks,
+	}, nil
+}
+
+// ensureValidatingWebhookConfiguration creates or updates the given configuration.
+func ensureValidatingWebhookConfiguration(ctx context.Context, cli admissionregistrationv1.ValidatingWebhookConfigurationInterface,
+	expected *admissionregistration.ValidatingWebhookConfiguration) error {
+	current, err := cli.Get(ctx, expected.GetName(), meta.GetOptions{})
+	if err != nil {
+		if !k8sutil.IsNotFound(err) {
+			return errors.WithStack(err)
+		}
+		if _, err := cli.Create(ctx, expected, meta.CreateOptions{}); err != nil && !k8sutil.IsAlreadyExists(err) {
+			return errors.WithStack(err)
+		}
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(current.Webhooks, expected.Webhooks) {
+		return nil
+	}
+	current.Webhooks = expected.Webhooks
+	if _, err := cli.Update(ctx, current, meta.UpdateOptions{}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// ensureMutatingWebhookConfiguration creates or updates the given configuration.
+func ensureMutatingWebhookConfiguration(ctx context.Context, cli admissionregistrationv1.MutatingWebhookConfigurationInterface,
+	expected *admissionregistration.MutatingWebhookConfiguration) error {
+	current, err := cli.Get(ctx, expected.GetName(), meta.GetOptions{})
+	if err != nil {
+		if !k8sutil.IsNotFound(err) {
+			return errors.WithStack(err)
+		}
+		if _, err := cli.Create(ctx, expected, meta.CreateOptions{}); err != nil && !k8sutil.IsAlreadyExists(err) {
+			return errors.WithStack(err)
+		}
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(current.Webhooks, expected.Webhooks) {
+		return nil
+	}
+	current.Webhooks = expected.Webhooks
+	if _, err := cli.Update(ctx, current, meta.UpdateOptions{}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// removeMutatingWebhookConfiguration removes the configuration with given name, if it exists.
+func removeMutatingWebhookConfiguration(ctx context.Context, cli admissionregistrationv1.MutatingWebhookConfigurationInterface, name string) error {
+	if err := cli.Delete(ctx, name, meta.DeleteOptions{}); err != nil && !k8sutil.IsNotFound(err) {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionregistration "k8s.io/api/admissionregistration/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_NamespaceSelector(t *testing.T) {
+	namespaces := func(names ...string) *meta.LabelSelector {
+		return &meta.LabelSelector{
+			MatchExpressions: []meta.LabelSelectorRequirement{
+				{Key: labelNamespaceName, Operator: meta.LabelSelectorOpIn, Values: names},
+			},
+		}
+	}
+
+	type testCase struct {
+		cfg      Config
+		resource resource
+		expected *meta.LabelSelector
+	}
+
+	testCases := map[string]testCase{
+		"Operator namespace by default": {
+			cfg:      Config{Namespace: "operator"},
+			resource: deploymentResource,
+			expected: namespaces("operator"),
+		},
+		"Watched namespaces": {
+			cfg:      Config{Namespace: "operator", WatchNamespaces: []string{"a", "b"}},
+			resource: deploymentReplicationResource,
+			expected: namespaces("a", "b"),
+		},
+		"Watched namespace selector": {
+			cfg:      Config{Namespace: "operator", WatchNamespaceSelector: "team=arango"},
+			resource: deploymentResource,
+			expected: &meta.LabelSelector{MatchLabels: map[string]string{"team": "arango"}, MatchExpressions: []meta.LabelSelectorRequirement{}},
+		},
+		"Backups in operator namespace": {
+			cfg:      Config{Namespace: "operator", WatchNamespaces: []string{"a", "b"}},
+			resource: backupResource,
+			expected: namespaces("operator"),
+		},
+		"Cluster scoped resource": {
+			cfg:      Config{Namespace: "operator", WatchNamespaces: []string{"a", "b"}},
+			resource: localStorageResource,
+			expected: &meta.LabelSelector{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s, err := tc.cfg.namespaceSelector(tc.resource)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, s)
+		})
+	}
+}
+
+func Test_RemoveMutatingWebhookConfiguration(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewSimpleClientset(&admissionregistration.MutatingWebhookConfiguration{
+		ObjectMeta: meta.ObjectMeta{Name: "webhook.operator"},
+	}).AdmissionregistrationV1().MutatingWebhookConfigurations()
+
+	require.NoError(t, removeMutatingWebhookConfiguration(ctx, cli, "webhook.operator"))
+	_, err := cli.Get(ctx, "webhook.operator", meta.GetOptions{})
+	require.Error(t, err)
+
+	t.Run("Missing configuration", func(t *testing.T) {
+		require.NoError(t, removeMutatingWebhookConfiguration(ctx, cli, "webhook.operator"))
+	})
+}
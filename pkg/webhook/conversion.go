@@ -0,0 +1,392 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/rs/zerolog"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	apiv2alpha1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v2alpha1"
+	"github.com/arangodb/kube-arangodb/pkg/apis/replication"
+	replicationApi "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	replicationApiv2alpha1 "github.com/arangodb/kube-arangodb/pkg/apis/replication/v2alpha1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+// legacyVersion is served by the CRDs of the deployment and replication APIs without Go types.
+// Its objects have the same fields as v1 objects.
+const legacyVersion = "v1alpha"
+
+// conversionFunc converts the JSON encoded object to the desired API version.
+type conversionFunc func(raw []byte, desiredAPIVersion string) (interface{}, error)
+
+// conversion describes the conversion of a single custom resource between its served versions.
+type conversion struct {
+	crdName, plural string
+
+	convert conversionFunc
+}
+
+func (c conversion) convertPath() string {
+	return "/convert/" + c.plural
+}
+
+var (
+	deploymentConversion = conversion{
+		crdName: deployment.ArangoDeploymentCRDName,
+		plural:  deployment.ArangoDeploymentResourcePlural,
+		convert: convertArangoDeployment,
+	}
+	memberConversion = conversion{
+		crdName: deployment.ArangoMemberCRDName,
+		plural:  deployment.ArangoMemberResourcePlural,
+		convert: convertArangoMember,
+	}
+	deploymentReplicationConversion = conversion{
+		crdName: replication.ArangoDeploymentReplicationCRDName,
+		plural:  replication.ArangoDeploymentReplicationResourcePlural,
+		convert: convertArangoDeploymentReplication,
+	}
+)
+
+// newConvertHandler creates a HTTP handler of the ConversionReview using given conversion.
+func newConvertHandler(log zerolog.Logger, convert conversionFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var review apiextensions.ConversionReview
+		if !readReview(w, r, &review) {
+			return
+		}
+		if review.Request == nil {
+			http.Error(w, "ConversionReview request is missing", http.StatusBadRequest)
+			return
+		}
+
+		resp := convertObjects(review.Request, convert)
+		if resp.Result.Status != meta.StatusSuccess {
+			log.Warn().
+				Str("desired-api-version", review.Request.DesiredAPIVersion).
+				Msg(resp.Result.Message)
+		}
+
+		review.Request = nil
+		review.Response = resp
+		writeReview(w, review)
+	}
+}
+
+// convertObjects converts all objects of the request. The conversion fails when any of the objects fails.
+func convertObjects(req *apiextensions.ConversionRequest, convert conversionFunc) *apiextensions.ConversionResponse {
+	resp := &apiextensions.ConversionResponse{
+		UID: req.UID,
+	}
+	for _, obj := range req.Objects {
+		converted, err := convert(obj.Raw, req.DesiredAPIVersion)
+		if err == nil {
+			var data []byte
+			if data, err = json.Marshal(converted); err == nil {
+				if data, err = keepUnknownFields(obj.Raw, data); err == nil {
+					resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: data})
+					continue
+				}
+			}
+		}
+		resp.ConvertedObjects = nil
+		resp.Result = meta.Status{
+			Status:  meta.StatusFailure,
+			Message: err.Error(),
+		}
+		return resp
+	}
+	resp.Result = meta.Status{
+		Status: meta.StatusSuccess,
+	}
+	return resp
+}
+
+// keepUnknownFields adds the fields of the original object, which are missing in the converted object.
+// The CRDs preserve fields unknown to their schema, while they are dropped by the conversion of the Go types.
+func keepUnknownFields(original, converted []byte) ([]byte, error) {
+	var src, dst map[string]interface{}
+	if err := json.Unmarshal(original, &src); err != nil {
+		return nil, errors.Newf("unable to decode object: %s", err.Error())
+	}
+	if err := json.Unmarshal(converted, &dst); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	mergeMissingFields(dst, src)
+	data, err := json.Marshal(dst)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return data, nil
+}
+
+// mergeMissingFields copies the fields of src missing in dst, recursively.
+// Items of lists are merged only when both lists have the same length, as the conversion keeps the order of the items.
+func mergeMissingFields(dst, src interface{}) {
+	switch s := src.(type) {
+	case map[string]interface{}:
+		d, ok := dst.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for k, v := range s {
+			if dv, ok := d[k]; ok {
+				mergeMissingFields(dv, v)
+			} else {
+				d[k] = v
+			}
+		}
+	case []interface{}:
+		d, ok := dst.([]interface{})
+		if !ok || len(d) != len(s) {
+			return
+		}
+		for i := range s {
+			mergeMissingFields(d[i], s[i])
+		}
+	}
+}
+
+// decodeAPIVersion returns the API version of the JSON encoded object.
+func decodeAPIVersion(raw []byte) (string, error) {
+	var t meta.TypeMeta
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return "", errors.Newf("unable to decode object: %s", err.Error())
+	}
+	return t.APIVersion, nil
+}
+
+func unsupportedVersionError(apiVersion string) error {
+	return errors.Newf("API version %s is not supported", apiVersion)
+}
+
+// convertArangoDeployment converts the ArangoDeployment through the hub version v1.
+func convertArangoDeployment(raw []byte, desiredAPIVersion string) (interface{}, error) {
+	apiVersion, err := decodeAPIVersion(raw)
+	if err != nil {
+		return nil, err
+	}
+	legacy := api.SchemeGroupVersion
+	legacy.Version = legacyVersion
+
+	var hub api.ArangoDeployment
+	switch apiVersion {
+	case api.SchemeGroupVersion.String(), legacy.String():
+		if err := decodeObject(runtime.RawExtension{Raw: raw}, &hub); err != nil {
+			return nil, err
+		}
+	case apiv2alpha1.SchemeGroupVersion.String():
+		var obj apiv2alpha1.ArangoDeployment
+		if err := decodeObject(runtime.RawExtension{Raw: raw}, &obj); err != nil {
+			return nil, err
+		}
+		if err := obj.ConvertTo(&hub); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, unsupportedVersionError(apiVersion)
+	}
+
+	switch desiredAPIVersion {
+	case api.SchemeGroupVersion.String(), legacy.String():
+		hub.APIVersion = desiredAPIVersion
+		return &hub, nil
+	case apiv2alpha1.SchemeGroupVersion.String():
+		var obj apiv2alpha1.ArangoDeployment
+		if err := obj.ConvertFrom(&hub); err != nil {
+			return nil, err
+		}
+		return &obj, nil
+	default:
+		return nil, unsupportedVersionError(desiredAPIVersion)
+	}
+}
+
+// convertArangoMember converts the ArangoMember through the hub version v1.
+func convertArangoMember(raw []byte, desiredAPIVersion string) (interface{}, error) {
+	apiVersion, err := decodeAPIVersion(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var hub api.ArangoMember
+	switch apiVersion {
+	case api.SchemeGroupVersion.String():
+		if err := decodeObject(runtime.RawExtension{Raw: raw}, &hub); err != nil {
+			return nil, err
+		}
+	case apiv2alpha1.SchemeGroupVersion.String():
+		var obj apiv2alpha1.ArangoMember
+		if err := decodeObject(runtime.RawExtension{Raw: raw}, &obj); err != nil {
+			return nil, err
+		}
+		if err := obj.ConvertTo(&hub); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, unsupportedVersionError(apiVersion)
+	}
+
+	switch desiredAPIVersion {
+	case api.SchemeGroupVersion.String():
+		return &hub, nil
+	case apiv2alpha1.SchemeGroupVersion.String():
+		var obj apiv2alpha1.ArangoMember
+		if err := obj.ConvertFrom(&hub); err != nil {
+			return nil, err
+		}
+		return &obj, nil
+	default:
+		return nil, unsupportedVersionError(desiredAPIVersion)
+	}
+}
+
+// convertArangoDeploymentReplication converts the ArangoDeploymentReplication through the hub version v1.
+func convertArangoDeploymentReplication(raw []byte, desiredAPIVersion string) (interface{}, error) {
+	apiVersion, err := decodeAPIVersion(raw)
+	if err != nil {
+		return nil, err
+	}
+	legacy := replicationApi.SchemeGroupVersion
+	legacy.Version = legacyVersion
+
+	var hub replicationApi.ArangoDeploymentReplication
+	switch apiVersion {
+	case replicationApi.SchemeGroupVersion.String(), legacy.String():
+		if err := decodeObject(runtime.RawExtension{Raw: raw}, &hub); err != nil {
+			return nil, err
+		}
+	case replicationApiv2alpha1.SchemeGroupVersion.String():
+		var obj replicationApiv2alpha1.ArangoDeploymentReplication
+		if err := decodeObject(runtime.RawExtension{Raw: raw}, &obj); err != nil {
+			return nil, err
+		}
+		if err := obj.ConvertTo(&hub); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, unsupportedVersionError(apiVersion)
+	}
+
+	switch desiredAPIVersion {
+	case replicationApi.SchemeGroupVersion.String(), legacy.String():
+		hub.APIVersion = desiredAPIVersion
+		return &hub, nil
+	case replicationApiv2alpha1.SchemeGroupVersion.String():
+		var obj replicationApiv2alpha1.ArangoDeploymentReplication
+		if err := obj.ConvertFrom(&hub); err != nil {
+			return nil, err
+		}
+		return &obj, nil
+	default:
+		return nil, unsupportedVersionError(desiredAPIVersion)
+	}
+}
+
+// newCustomResourceConversion creates the conversion of the CRD served by the webhook on given path.
+func newCustomResourceConversion(cfg Config, path string, caBundle []byte) *apiextensions.CustomResourceConversion {
+	return &apiextensions.CustomResourceConversion{
+		Strategy: apiextensions.WebhookConverter,
+		Webhook: &apiextensions.WebhookConversion{
+			ClientConfig: &apiextensions.WebhookClientConfig{
+				Service: &apiextensions.ServiceReference{
+					Namespace: cfg.Namespace,
+					Name:      cfg.ServiceName,
+					Path:      util.NewString(path),
+					Port:      util.NewInt32(cfg.ServicePort),
+				},
+				CABundle: caBundle,
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+}
+
+// getConversionService returns the Service of the conversion webhook, nil when the webhook conversion is not used.
+func getConversionService(c *apiextensions.CustomResourceConversion) *apiextensions.ServiceReference {
+	if c == nil || c.Strategy != apiextensions.WebhookConverter || c.Webhook == nil || c.Webhook.ClientConfig == nil {
+		return nil
+	}
+	return c.Webhook.ClientConfig.Service
+}
+
+// isOwnConversion returns true if the conversion is served by the webhooks of given configuration.
+func (c Config) isOwnConversion(conversion *apiextensions.CustomResourceConversion) bool {
+	s := getConversionService(conversion)
+	return s != nil && s.Namespace == c.Namespace && s.Name == c.ServiceName
+}
+
+// ensureCustomResourceConversion sets the conversion of the CRD with given name.
+// The conversion served by the webhooks of another operator is not changed.
+func ensureCustomResourceConversion(ctx context.Context, cli apiextensionsv1.CustomResourceDefinitionInterface, crdName string,
+	expected *apiextensions.CustomResourceConversion) error {
+	crd, err := cli.Get(ctx, crdName, meta.GetOptions{})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if equality.Semantic.DeepEqual(crd.Spec.Conversion, expected) {
+		return nil
+	}
+	if s, e := getConversionService(crd.Spec.Conversion), expected.Webhook.ClientConfig.Service; s != nil &&
+		(s.Namespace != e.Namespace || s.Name != e.Name) {
+		return errors.Newf("Conversion of %s is served by webhooks %s/%s of another operator", crdName, s.Namespace, s.Name)
+	}
+	crd.Spec.Conversion = expected
+	if _, err := cli.Update(ctx, crd, meta.UpdateOptions{}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// restoreCustomResourceConversion sets the conversion strategy of the CRD with given name back to None,
+// when the conversion is served by the webhooks of given configuration.
+func restoreCustomResourceConversion(ctx context.Context, cli apiextensionsv1.CustomResourceDefinitionInterface, crdName string, cfg Config) error {
+	crd, err := cli.Get(ctx, crdName, meta.GetOptions{})
+	if err != nil {
+		if k8sutil.IsNotFound(err) {
+			return nil
+		}
+		return errors.WithStack(err)
+	}
+	if !cfg.isOwnConversion(crd.Spec.Conversion) {
+		return nil
+	}
+	crd.Spec.Conversion = &apiextensions.CustomResourceConversion{
+		Strategy: apiextensions.NoneConverter,
+	}
+	if _, err := cli.Update(ctx, crd, meta.UpdateOptions{}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	apiv2alpha1 "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v2alpha1"
+	replicationApi "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	replicationApiv2alpha1 "github.com/arangodb/kube-arangodb/pkg/apis/replication/v2alpha1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+func serveConversionReview(t *testing.T, handler http.HandlerFunc, desiredAPIVersion string, objects ...interface{}) *apiextensions.ConversionResponse {
+	req := &apiextensions.ConversionRequest{
+		UID:               "uid",
+		DesiredAPIVersion: desiredAPIVersion,
+	}
+	for _, obj := range objects {
+		req.Objects = append(req.Objects, rawObject(t, obj))
+	}
+	data, err := json.Marshal(apiextensions.ConversionReview{
+		TypeMeta: meta.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+		Request:  req,
+	})
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var review apiextensions.ConversionReview
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &review))
+	require.NotNil(t, review.Response)
+	require.Equal(t, req.UID, review.Response.UID)
+	return review.Response
+}
+
+func decodeConverted(t *testing.T, resp *apiextensions.ConversionResponse, objects ...interface{}) {
+	require.Equal(t, meta.StatusSuccess, resp.Result.Status, resp.Result.Message)
+	require.Len(t, resp.ConvertedObjects, len(objects))
+	for i, obj := range objects {
+		require.NoError(t, json.Unmarshal(resp.ConvertedObjects[i].Raw, obj))
+	}
+}
+
+func Test_Convert_ArangoDeployment(t *testing.T) {
+	handler := newConvertHandler(zerolog.Nop(), convertArangoDeployment)
+
+	newHub := func(name string) *api.ArangoDeployment {
+		obj := newDeployment(api.DeploymentSpec{
+			Mode:          api.NewMode(api.DeploymentModeCluster),
+			StorageEngine: api.NewStorageEngine(api.StorageEngineRocksDB),
+			Image:         util.NewString("arangodb/arangodb:3.7.10"),
+		})
+		obj.TypeMeta = meta.TypeMeta{APIVersion: api.SchemeGroupVersion.String(), Kind: "ArangoDeployment"}
+		obj.Name = name
+		obj.Status.Phase = api.DeploymentPhaseRunning
+		return obj
+	}
+
+	t.Run("v1 to v2alpha1 and back", func(t *testing.T) {
+		first, second := newHub("first"), newHub("second")
+
+		var a, b apiv2alpha1.ArangoDeployment
+		decodeConverted(t, serveConversionReview(t, handler, apiv2alpha1.SchemeGroupVersion.String(), first, second), &a, &b)
+		require.Equal(t, apiv2alpha1.SchemeGroupVersion.String(), a.APIVersion)
+		require.Equal(t, "ArangoDeployment", a.Kind)
+		require.Equal(t, "first", a.Name)
+		require.Equal(t, "second", b.Name)
+		require.Equal(t, apiv2alpha1.StorageEngineRocksDB, a.Spec.GetStorageEngine())
+		require.Equal(t, apiv2alpha1.DeploymentPhaseRunning, a.Status.Phase)
+
+		var result api.ArangoDeployment
+		decodeConverted(t, serveConversionReview(t, handler, api.SchemeGroupVersion.String(), &a), &result)
+		require.Equal(t, *first, result)
+	})
+
+	t.Run("Legacy version", func(t *testing.T) {
+		legacy := newHub("legacy")
+		legacy.APIVersion = "database.arangodb.com/v1alpha"
+
+		var result api.ArangoDeployment
+		decodeConverted(t, serveConversionReview(t, handler, api.SchemeGroupVersion.String(), legacy), &result)
+		require.Equal(t, api.SchemeGroupVersion.String(), result.APIVersion)
+		require.Equal(t, legacy.Spec, result.Spec)
+	})
+
+	t.Run("Unknown fields", func(t *testing.T) {
+		obj := map[string]interface{}{
+			"apiVersion": api.SchemeGroupVersion.String(),
+			"kind":       "ArangoDeployment",
+			"metadata":   map[string]interface{}{"name": "unknown"},
+			"spec": map[string]interface{}{
+				"mode":    "Cluster",
+				"unknown": map[string]interface{}{"field": "value"},
+				"dbservers": map[string]interface{}{
+					"count":   3,
+					"unknown": true,
+				},
+			},
+			"status": map[string]interface{}{
+				"members": map[string]interface{}{
+					"dbservers": []interface{}{
+						map[string]interface{}{"id": "PRMR-1", "unknown": "member"},
+					},
+				},
+			},
+		}
+
+		var converted map[string]interface{}
+		decodeConverted(t, serveConversionReview(t, handler, apiv2alpha1.SchemeGroupVersion.String(), obj), &converted)
+		require.Equal(t, apiv2alpha1.SchemeGroupVersion.String(), converted["apiVersion"])
+
+		var result map[string]interface{}
+		decodeConverted(t, serveConversionReview(t, handler, api.SchemeGroupVersion.String(), converted), &result)
+		spec := result["spec"].(map[string]interface{})
+		require.Equal(t, "Cluster", spec["mode"])
+		require.Equal(t, map[string]interface{}{"field": "value"}, spec["unknown"])
+		require.Equal(t, true, spec["dbservers"].(map[string]interface{})["unknown"])
+		members := result["status"].(map[string]interface{})["members"].(map[string]interface{})["dbservers"].([]interface{})
+		require.Len(t, members, 1)
+		require.Equal(t, "member", members[0].(map[string]interface{})["unknown"])
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		resp := serveConversionReview(t, handler, "database.arangodb.com/v3", newHub("first"))
+		require.Equal(t, meta.StatusFailure, resp.Result.Status)
+		require.Contains(t, resp.Result.Message, "database.arangodb.com/v3")
+		require.Empty(t, resp.ConvertedObjects)
+	})
+}
+
+func Test_Convert_ArangoMember(t *testing.T) {
+	handler := newConvertHandler(zerolog.Nop(), convertArangoMember)
+
+	member := &apiv2alpha1.ArangoMember{
+		TypeMeta:   meta.TypeMeta{APIVersion: apiv2alpha1.SchemeGroupVersion.String(), Kind: "ArangoMember"},
+		ObjectMeta: meta.ObjectMeta{Name: "example-prmr-abcdef", Namespace: "default"},
+		Spec: apiv2alpha1.ArangoMemberSpec{
+			Group:         apiv2alpha1.ServerGroupDBServers,
+			ID:            "PRMR-abcdef",
+			DeploymentUID: "uid",
+		},
+	}
+
+	var hub api.ArangoMember
+	decodeConverted(t, serveConversionReview(t, handler, api.SchemeGroupVersion.String(), member), &hub)
+	require.Equal(t, api.SchemeGroupVersion.String(), hub.APIVersion)
+	require.Equal(t, api.ServerGroupDBServers, hub.Spec.Group)
+	require.Equal(t, "PRMR-abcdef", hub.Spec.ID)
+
+	var result apiv2alpha1.ArangoMember
+	decodeConverted(t, serveConversionReview(t, handler, apiv2alpha1.SchemeGroupVersion.String(), &hub), &result)
+	require.Equal(t, *member, result)
+
+	t.Run("Legacy version is not served", func(t *testing.T) {
+		resp := serveConversionReview(t, handler, "database.arangodb.com/v1alpha", member)
+		require.Equal(t, meta.StatusFailure, resp.Result.Status)
+	})
+}
+
+func Test_Convert_ArangoDeploymentReplication(t *testing.T) {
+	handler := newConvertHandler(zerolog.Nop(), convertArangoDeploymentReplication)
+
+	hub := &replicationApi.ArangoDeploymentReplication{
+		TypeMeta:   meta.TypeMeta{APIVersion: replicationApi.SchemeGroupVersion.String(), Kind: "ArangoDeploymentReplication"},
+		ObjectMeta: meta.ObjectMeta{Name: "replication", Namespace: "default"},
+		Spec: replicationApi.DeploymentReplicationSpec{
+			Source:      replicationApi.EndpointSpec{DeploymentName: util.NewString("dc1")},
+			Destination: replicationApi.EndpointSpec{DeploymentName: util.NewString("dc2")},
+		},
+	}
+
+	var obj replicationApiv2alpha1.ArangoDeploymentReplication
+	decodeConverted(t, serveConversionReview(t, handler, replicationApiv2alpha1.SchemeGroupVersion.String(), hub), &obj)
+	require.Equal(t, replicationApiv2alpha1.SchemeGroupVersion.String(), obj.APIVersion)
+	require.Equal(t, "dc1", obj.Spec.Source.GetDeploymentName())
+
+	var result replicationApi.ArangoDeploymentReplication
+	decodeConverted(t, serveConversionReview(t, handler, replicationApi.SchemeGroupVersion.String(), &obj), &result)
+	require.Equal(t, *hub, result)
+}
+
+func Test_ConvertHandler_InvalidObject(t *testing.T) {
+	resp := convertObjects(&apiextensions.ConversionRequest{
+		UID:               "uid",
+		DesiredAPIVersion: api.SchemeGroupVersion.String(),
+		Objects:           []runtime.RawExtension{{Raw: []byte("invalid")}},
+	}, convertArangoDeployment)
+	require.Equal(t, meta.StatusFailure, resp.Result.Status)
+	require.Empty(t, resp.ConvertedObjects)
+}
+
+func Test_EnsureCustomResourceConversion(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewSimpleClientset(&apiextensions.CustomResourceDefinition{
+		ObjectMeta: meta.ObjectMeta{Name: deploymentConversion.crdName},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Conversion: &apiextensions.CustomResourceConversion{Strategy: apiextensions.NoneConverter},
+		},
+	}).ApiextensionsV1().CustomResourceDefinitions()
+	cfg := Config{Namespace: "operator", ServiceName: "webhook", ServicePort: 443}
+
+	expected := newCustomResourceConversion(cfg, deploymentConversion.convertPath(), []byte("ca"))
+	require.NoError(t, ensureCustomResourceConversion(ctx, cli, deploymentConversion.crdName, expected))
+
+	crd, err := cli.Get(ctx, deploymentConversion.crdName, meta.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, apiextensions.WebhookConverter, crd.Spec.Conversion.Strategy)
+	require.Equal(t, "/convert/arangodeployments", *crd.Spec.Conversion.Webhook.ClientConfig.Service.Path)
+	require.Equal(t, []byte("ca"), crd.Spec.Conversion.Webhook.ClientConfig.CABundle)
+
+	t.Run("Missing CRD", func(t *testing.T) {
+		require.Error(t, ensureCustomResourceConversion(ctx, cli, memberConversion.crdName, expected))
+	})
+}
+
+func Test_RestoreCustomResourceConversion(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{Namespace: "operator", ServiceName: "webhook", ServicePort: 443}
+	other := Config{Namespace: "other", ServiceName: "webhook", ServicePort: 443}
+	cli := fake.NewSimpleClientset(
+		&apiextensions.CustomResourceDefinition{
+			ObjectMeta: meta.ObjectMeta{Name: deploymentConversion.crdName},
+			Spec: apiextensions.CustomResourceDefinitionSpec{
+				Conversion: newCustomResourceConversion(cfg, deploymentConversion.convertPath(), []byte("ca")),
+			},
+		},
+		&apiextensions.CustomResourceDefinition{
+			ObjectMeta: meta.ObjectMeta{Name: memberConversion.crdName},
+			Spec: apiextensions.CustomResourceDefinitionSpec{
+				Conversion: newCustomResourceConversion(other, memberConversion.convertPath(), []byte("ca")),
+			},
+		},
+	).ApiextensionsV1().CustomResourceDefinitions()
+
+	t.Run("Own conversion", func(t *testing.T) {
+		require.NoError(t, restoreCustomResourceConversion(ctx, cli, deploymentConversion.crdName, cfg))
+
+		crd, err := cli.Get(ctx, deploymentConversion.crdName, meta.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, apiextensions.NoneConverter, crd.Spec.Conversion.Strategy)
+		require.Nil(t, crd.Spec.Conversion.Webhook)
+	})
+
+	t.Run("Conversion of another operator", func(t *testing.T) {
+		require.NoError(t, restoreCustomResourceConversion(ctx, cli, memberConversion.crdName, cfg))
+
+		crd, err := cli.Get(ctx, memberConversion.crdName, meta.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, apiextensions.WebhookConverter, crd.Spec.Conversion.Strategy)
+
+		require.Error(t, ensureCustomResourceConversion(ctx, cli, memberConversion.crdName,
+			newCustomResourceConversion(cfg, memberConversion.convertPath(), []byte("ca"))))
+	})
+
+	t.Run("Missing CRD", func(t *testing.T) {
+		require.NoError(t, restoreCustomResourceConversion(ctx, cli, deploymentReplicationConversion.crdName, cfg))
+	})
+}
@@ -0,0 +1,261 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/apis/backup"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/apis/replication"
+	replicationApi "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	storageApi "github.com/arangodb/kube-arangodb/pkg/apis/storage/v1alpha"
+	admission "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// resource describes the admission of a single custom resource.
+type resource struct {
+	group, version, plural string
+	namespaced             bool
+	// watched is true for resources in the namespaces of the watch configuration,
+	// other namespaced resources are handled in the namespace of the operator only
+	watched bool
+
+	validate validateFunc
+	// mutate is nil for resources without defaults
+	mutate mutateFunc
+}
+
+// webhookName returns the name of the webhook, unique within the webhook configuration.
+func (r resource) webhookName() string {
+	return r.plural + "." + r.group
+}
+
+func (r resource) validatePath() string {
+	return "/validate/" + r.plural
+}
+
+func (r resource) mutatePath() string {
+	return "/mutate/" + r.plural
+}
+
+var (
+	deploymentResource = resource{
+		group:      deployment.ArangoDeploymentGroupName,
+		version:    api.ArangoDeploymentVersion,
+		plural:     deployment.ArangoDeploymentResourcePlural,
+		namespaced: true,
+		watched:    true,
+		validate:   validateArangoDeployment,
+		mutate:     mutateArangoDeployment,
+	}
+	backupResource = resource{
+		group:      backup.ArangoBackupGroupName,
+		version:    backupApi.ArangoBackupVersion,
+		plural:     backup.ArangoBackupResourcePlural,
+		namespaced: true,
+		validate:   validateArangoBackup,
+	}
+	backupPolicyResource = resource{
+		group:      backup.ArangoBackupGroupName,
+		version:    backupApi.ArangoBackupVersion,
+		plural:     backup.ArangoBackupPolicyResourcePlural,
+		namespaced: true,
+		validate:   validateArangoBackupPolicy,
+	}
+	deploymentReplicationResource = resource{
+		group:      replication.ArangoDeploymentReplicationGroupName,
+		version:    replicationApi.ArangoDeploymentReplicationVersion,
+		plural:     replication.ArangoDeploymentReplicationResourcePlural,
+		namespaced: true,
+		watched:    true,
+		validate:   validateArangoDeploymentReplication,
+		mutate:     mutateArangoDeploymentReplication,
+	}
+	localStorageResource = resource{
+		group:    storageApi.SchemeGroupVersion.Group,
+		version:  storageApi.SchemeGroupVersion.Version,
+		plural:   storageApi.ArangoLocalStorageResourcePlural,
+		validate: validateArangoLocalStorage,
+		mutate:   mutateArangoLocalStorage,
+	}
+)
+
+// validateArangoDeployment rejects invalid specs and changes of immutable fields.
+func validateArangoDeployment(req *admission.AdmissionRequest) error {
+	var obj, old api.ArangoDeployment
+	if err := decodeObjects(req, &obj, &old); err != nil {
+		return err
+	}
+
+	spec := obj.Spec.DeepCopy()
+	if req.Operation == admission.Update {
+		if equality.Semantic.DeepEqual(obj.Spec, old.Spec) {
+			// Metadata or status change
+			return nil
+		}
+		specBefore := old.Spec.DeepCopy()
+		specBefore.SetDefaults(old.GetName())
+		spec.SetDefaultsFrom(*specBefore)
+		spec.SetDefaults(obj.GetName())
+		if fields := specBefore.ResetImmutableFields(spec); len(fields) > 0 {
+			return immutableFieldsError(fields)
+		}
+	} else {
+		spec.SetDefaults(obj.GetName())
+	}
+	return spec.Validate()
+}
+
+// mutateArangoDeployment applies the defaults the operator would set on the spec.
+func mutateArangoDeployment(req *admission.AdmissionRequest) (interface{}, bool, error) {
+	var obj, old api.ArangoDeployment
+	if err := decodeObjects(req, &obj, &old); err != nil {
+		return nil, false, err
+	}
+	if obj.GetName() == "" {
+		// Name is not generated yet, defaults are applied by the operator
+		return nil, false, nil
+	}
+
+	spec := obj.Spec.DeepCopy()
+	if req.Operation == admission.Update {
+		spec.SetDefaultsFrom(old.Spec)
+	}
+	spec.SetDefaults(obj.GetName())
+	return spec, !equality.Semantic.DeepEqual(*spec, obj.Spec), nil
+}
+
+// validateArangoBackup rejects invalid specs and changes of the deployment or download of the backup.
+func validateArangoBackup(req *admission.AdmissionRequest) error {
+	var obj, old backupApi.ArangoBackup
+	if err := decodeObjects(req, &obj, &old); err != nil {
+		return err
+	}
+
+	if req.Operation == admission.Update {
+		if equality.Semantic.DeepEqual(obj.Spec, old.Spec) {
+			return nil
+		}
+		if fields := old.Spec.ResetImmutableFields(obj.Spec.DeepCopy()); len(fields) > 0 {
+			return immutableFieldsError(fields)
+		}
+	}
+	return obj.Spec.Validate()
+}
+
+// validateArangoBackupPolicy rejects invalid specs.
+func validateArangoBackupPolicy(req *admission.AdmissionRequest) error {
+	var obj, old backupApi.ArangoBackupPolicy
+	if err := decodeObjects(req, &obj, &old); err != nil {
+		return err
+	}
+
+	if req.Operation == admission.Update && equality.Semantic.DeepEqual(obj.Spec, old.Spec) {
+		return nil
+	}
+	return obj.Spec.Validate()
+}
+
+// validateArangoDeploymentReplication rejects invalid specs and changes of immutable fields.
+func validateArangoDeploymentReplication(req *admission.AdmissionRequest) error {
+	var obj, old replicationApi.ArangoDeploymentReplication
+	if err := decodeObjects(req, &obj, &old); err != nil {
+		return err
+	}
+
+	spec := obj.Spec.DeepCopy()
+	if req.Operation == admission.Update {
+		if equality.Semantic.DeepEqual(obj.Spec, old.Spec) {
+			return nil
+		}
+		specBefore := old.Spec.DeepCopy()
+		specBefore.SetDefaults()
+		spec.SetDefaults()
+		if specBefore.Operation != nil {
+			// The operator reverses the direction once the switchover or failover completes
+			if reversed := specBefore.Reversed(); equality.Semantic.DeepEqual(reversed.Source, spec.Source) &&
+				equality.Semantic.DeepEqual(reversed.Destination, spec.Destination) {
+				specBefore = &reversed
+			}
+		}
+		spec.SetDefaultsFrom(*specBefore)
+		if fields := specBefore.ResetImmutableFields(spec); len(fields) > 0 {
+			return immutableFieldsError(fields)
+		}
+	} else {
+		spec.SetDefaults()
+	}
+	return spec.Validate()
+}
+
+// mutateArangoDeploymentReplication applies the defaults the operator would set on the spec.
+func mutateArangoDeploymentReplication(req *admission.AdmissionRequest) (interface{}, bool, error) {
+	var obj, old replicationApi.ArangoDeploymentReplication
+	if err := decodeObjects(req, &obj, &old); err != nil {
+		return nil, false, err
+	}
+
+	spec := obj.Spec.DeepCopy()
+	if req.Operation == admission.Update {
+		spec.SetDefaultsFrom(old.Spec)
+	}
+	spec.SetDefaults()
+	return spec, !equality.Semantic.DeepEqual(*spec, obj.Spec), nil
+}
+
+// validateArangoLocalStorage rejects invalid specs and changes of immutable fields.
+func validateArangoLocalStorage(req *admission.AdmissionRequest) error {
+	var obj, old storageApi.ArangoLocalStorage
+	if err := decodeObjects(req, &obj, &old); err != nil {
+		return err
+	}
+
+	spec := obj.Spec.DeepCopy()
+	spec.SetDefaults(obj.GetName())
+	if req.Operation == admission.Update {
+		if equality.Semantic.DeepEqual(obj.Spec, old.Spec) {
+			return nil
+		}
+		specBefore := old.Spec.DeepCopy()
+		specBefore.SetDefaults(old.GetName())
+		if fields := specBefore.ResetImmutableFields(spec); len(fields) > 0 {
+			return immutableFieldsError(fields)
+		}
+	}
+	return spec.Validate()
+}
+
+// mutateArangoLocalStorage applies the defaults the operator would set on the spec.
+func mutateArangoLocalStorage(req *admission.AdmissionRequest) (interface{}, bool, error) {
+	var obj storageApi.ArangoLocalStorage
+	if err := decodeObject(req.Object, &obj); err != nil {
+		return nil, false, err
+	}
+	if obj.GetName() == "" {
+		return nil, false, nil
+	}
+
+	spec := obj.Spec.DeepCopy()
+	spec.SetDefaults(obj.GetName())
+	return spec, !equality.Semantic.DeepEqual(*spec, obj.Spec), nil
+}
@@ -0,0 +1,265 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	replicationApi "github.com/arangodb/kube-arangodb/pkg/apis/replication/v1"
+	storageApi "github.com/arangodb/kube-arangodb/pkg/apis/storage/v1alpha"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+	admission "k8s.io/api/admission/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func rawObject(t *testing.T, obj interface{}) runtime.RawExtension {
+	data, err := json.Marshal(obj)
+	require.NoError(t, err)
+	return runtime.RawExtension{Raw: data}
+}
+
+func createRequest(t *testing.T, obj interface{}) *admission.AdmissionRequest {
+	return &admission.AdmissionRequest{
+		UID:       "uid",
+		Operation: admission.Create,
+		Object:    rawObject(t, obj),
+	}
+}
+
+func updateRequest(t *testing.T, old, obj interface{}) *admission.AdmissionRequest {
+	return &admission.AdmissionRequest{
+		UID:       "uid",
+		Operation: admission.Update,
+		Object:    rawObject(t, obj),
+		OldObject: rawObject(t, old),
+	}
+}
+
+func newDeployment(spec api.DeploymentSpec) *api.ArangoDeployment {
+	return &api.ArangoDeployment{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      "example",
+			Namespace: "default",
+		},
+		Spec: spec,
+	}
+}
+
+func Test_Validate_ArangoDeployment(t *testing.T) {
+	t.Run("Valid create", func(t *testing.T) {
+		obj := newDeployment(api.DeploymentSpec{Mode: api.NewMode(api.DeploymentModeCluster)})
+		require.NoError(t, validateArangoDeployment(createRequest(t, obj)))
+	})
+
+	t.Run("Invalid create", func(t *testing.T) {
+		obj := newDeployment(api.DeploymentSpec{Mode: api.NewMode("Unknown")})
+		require.Error(t, validateArangoDeployment(createRequest(t, obj)))
+	})
+
+	t.Run("Immutable storage engine", func(t *testing.T) {
+		old := newDeployment(api.DeploymentSpec{StorageEngine: api.NewStorageEngine(api.StorageEngineMMFiles)})
+		obj := newDeployment(api.DeploymentSpec{StorageEngine: api.NewStorageEngine(api.StorageEngineRocksDB)})
+
+		err := validateArangoDeployment(updateRequest(t, old, obj))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "spec.storageEngine")
+	})
+
+	t.Run("Storage engine removed", func(t *testing.T) {
+		old := newDeployment(api.DeploymentSpec{StorageEngine: api.NewStorageEngine(api.StorageEngineMMFiles)})
+		obj := newDeployment(api.DeploymentSpec{Image: util.NewString("arangodb/arangodb:3.7.10")})
+
+		require.NoError(t, validateArangoDeployment(updateRequest(t, old, obj)))
+	})
+
+	t.Run("Metadata update of invalid spec", func(t *testing.T) {
+		old := newDeployment(api.DeploymentSpec{Mode: api.NewMode("Unknown")})
+		obj := old.DeepCopy()
+		obj.Labels = map[string]string{"a": "b"}
+
+		require.NoError(t, validateArangoDeployment(updateRequest(t, old, obj)))
+	})
+}
+
+func Test_Mutate_ArangoDeployment(t *testing.T) {
+	t.Run("Defaults on create", func(t *testing.T) {
+		obj := newDeployment(api.DeploymentSpec{})
+
+		spec, changed, err := mutateArangoDeployment(createRequest(t, obj))
+		require.NoError(t, err)
+		require.True(t, changed)
+		s := spec.(*api.DeploymentSpec)
+		require.Equal(t, api.DeploymentModeCluster, s.GetMode())
+		require.Equal(t, api.StorageEngineRocksDB, s.GetStorageEngine())
+	})
+
+	t.Run("Defaults from old spec on update", func(t *testing.T) {
+		old := newDeployment(api.DeploymentSpec{StorageEngine: api.NewStorageEngine(api.StorageEngineMMFiles)})
+		obj := newDeployment(api.DeploymentSpec{})
+
+		spec, changed, err := mutateArangoDeployment(updateRequest(t, old, obj))
+		require.NoError(t, err)
+		require.True(t, changed)
+		require.Equal(t, api.StorageEngineMMFiles, spec.(*api.DeploymentSpec).GetStorageEngine())
+	})
+
+	t.Run("No changes of defaulted spec", func(t *testing.T) {
+		obj := newDeployment(api.DeploymentSpec{})
+		obj.Spec.SetDefaults(obj.GetName())
+
+		_, changed, err := mutateArangoDeployment(createRequest(t, obj))
+		require.NoError(t, err)
+		require.False(t, changed)
+	})
+
+	t.Run("Generated name", func(t *testing.T) {
+		obj := newDeployment(api.DeploymentSpec{})
+		obj.Name = ""
+		obj.GenerateName = "example-"
+
+		_, changed, err := mutateArangoDeployment(createRequest(t, obj))
+		require.NoError(t, err)
+		require.False(t, changed)
+	})
+}
+
+func Test_Validate_ArangoBackup(t *testing.T) {
+	newBackup := func(deployment string) *backupApi.ArangoBackup {
+		return &backupApi.ArangoBackup{
+			ObjectMeta: meta.ObjectMeta{Name: "backup", Namespace: "default"},
+			Spec: backupApi.ArangoBackupSpec{
+				Deployment: backupApi.ArangoBackupSpecDeployment{Name: deployment},
+			},
+		}
+	}
+
+	t.Run("Valid create", func(t *testing.T) {
+		require.NoError(t, validateArangoBackup(createRequest(t, newBackup("example"))))
+	})
+
+	t.Run("Missing deployment", func(t *testing.T) {
+		require.Error(t, validateArangoBackup(createRequest(t, newBackup(""))))
+	})
+
+	t.Run("Immutable deployment", func(t *testing.T) {
+		err := validateArangoBackup(updateRequest(t, newBackup("example"), newBackup("other")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "spec.deployment.name")
+	})
+
+	t.Run("Upload added", func(t *testing.T) {
+		obj := newBackup("example")
+		obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{RepositoryURL: "s3://bucket"}
+
+		require.NoError(t, validateArangoBackup(updateRequest(t, newBackup("example"), obj)))
+	})
+}
+
+func Test_Validate_ArangoBackupPolicy(t *testing.T) {
+	newPolicy := func(schedule string) *backupApi.ArangoBackupPolicy {
+		return &backupApi.ArangoBackupPolicy{
+			ObjectMeta: meta.ObjectMeta{Name: "policy", Namespace: "default"},
+			Spec: backupApi.ArangoBackupPolicySpec{
+				Schedule: schedule,
+			},
+		}
+	}
+
+	require.NoError(t, validateArangoBackupPolicy(createRequest(t, newPolicy("*/15 * * * *"))))
+	require.Error(t, validateArangoBackupPolicy(createRequest(t, newPolicy("invalid"))))
+}
+
+func Test_ArangoLocalStorage(t *testing.T) {
+	newStorage := func(localPath ...string) *storageApi.ArangoLocalStorage {
+		return &storageApi.ArangoLocalStorage{
+			ObjectMeta: meta.ObjectMeta{Name: "storage"},
+			Spec: storageApi.LocalStorageSpec{
+				LocalPath: localPath,
+			},
+		}
+	}
+
+	t.Run("Valid create", func(t *testing.T) {
+		require.NoError(t, validateArangoLocalStorage(createRequest(t, newStorage("/data"))))
+	})
+
+	t.Run("Missing local path", func(t *testing.T) {
+		require.Error(t, validateArangoLocalStorage(createRequest(t, newStorage())))
+	})
+
+	t.Run("Immutable local path", func(t *testing.T) {
+		err := validateArangoLocalStorage(updateRequest(t, newStorage("/data"), newStorage("/other")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "spec.localPath")
+	})
+
+	t.Run("Default storage class name", func(t *testing.T) {
+		spec, changed, err := mutateArangoLocalStorage(createRequest(t, newStorage("/data")))
+		require.NoError(t, err)
+		require.True(t, changed)
+		require.Equal(t, "storage", spec.(*storageApi.LocalStorageSpec).StorageClass.Name)
+	})
+}
+
+func Test_Validate_ArangoDeploymentReplication(t *testing.T) {
+	newReplication := func(source, destination string, operation *replicationApi.OperationSpec) *replicationApi.ArangoDeploymentReplication {
+		return &replicationApi.ArangoDeploymentReplication{
+			ObjectMeta: meta.ObjectMeta{Name: "replication", Namespace: "default"},
+			Spec: replicationApi.DeploymentReplicationSpec{
+				Source: replicationApi.EndpointSpec{
+					DeploymentName: util.NewString(source),
+					Authentication: replicationApi.EndpointAuthenticationSpec{KeyfileSecretName: util.NewString(source + "-client")},
+				},
+				Destination: replicationApi.EndpointSpec{
+					DeploymentName: util.NewString(destination),
+					Authentication: replicationApi.EndpointAuthenticationSpec{KeyfileSecretName: util.NewString(destination + "-client")},
+				},
+				Operation: operation,
+			},
+		}
+	}
+	failover := &replicationApi.OperationSpec{Type: replicationApi.OperationTypeFailover, ID: "1"}
+
+	t.Run("Valid create", func(t *testing.T) {
+		require.NoError(t, validateArangoDeploymentReplication(createRequest(t, newReplication("dc1", "dc2", nil))))
+	})
+
+	t.Run("Immutable source", func(t *testing.T) {
+		err := validateArangoDeploymentReplication(updateRequest(t, newReplication("dc1", "dc2", nil), newReplication("dc3", "dc2", nil)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "spec.source.deploymentName")
+	})
+
+	t.Run("Reversed by the operator", func(t *testing.T) {
+		old := newReplication("dc1", "dc2", failover)
+		require.NoError(t, validateArangoDeploymentReplication(updateRequest(t, old, newReplication("dc2", "dc1", failover))))
+	})
+
+	t.Run("Reversed without operation", func(t *testing.T) {
+		old := newReplication("dc1", "dc2", nil)
+		require.Error(t, validateArangoDeploymentReplication(updateRequest(t, old, newReplication("dc2", "dc1", nil))))
+	})
+}
@@ -0,0 +1,397 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/equality"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/errors"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+const (
+	// refreshInterval is the interval of the certificate renewal and the webhook configurations check
+	refreshInterval = time.Hour
+)
+
+// Config settings for the webhook Server
+type Config struct {
+	Namespace   string // Namespace of the operator
+	Address     string // Address to listen on
+	ServiceName string // Name of the Service in front of the webhooks
+	ServicePort int32  // Port of the Service in front of the webhooks
+	SecretName  string // Name of secret containing the CA and the serving certificate, created when missing
+
+	EnableDeployment            bool // Serve webhooks of ArangoDeployments
+	EnableDeploymentReplication bool // Serve webhooks of ArangoDeploymentReplications
+	EnableStorage               bool // Serve webhooks of ArangoLocalStorages
+	EnableBackup                bool // Serve webhooks of ArangoBackups and ArangoBackupPolicies
+	EnableConversion            bool // Serve conversion webhooks and set them in the CustomResourceDefinitions
+	EnableMutation              bool // Serve mutating webhooks, which apply the defaults to the specs
+
+	WatchNamespaces        []string // Namespaces watched for ArangoDeployments and ArangoDeploymentReplications, namespace of the operator when empty
+	WatchNamespaceSelector string   // Label selector of namespaces watched for ArangoDeployments and ArangoDeploymentReplications
+}
+
+// Validate the webhook configuration.
+func (c Config) Validate() error {
+	if c.Namespace == "" {
+		return errors.Newf("Namespace of the webhooks cannot be empty")
+	}
+	if c.ServiceName == "" {
+		return errors.Newf("Service name of the webhooks cannot be empty")
+	}
+	if c.ServicePort <= 0 || c.ServicePort > 65535 {
+		return errors.Newf("Service port of the webhooks must be in range 1-65535, got %d", c.ServicePort)
+	}
+	if c.WatchNamespaceSelector != "" {
+		if len(c.WatchNamespaces) > 0 {
+			return errors.Newf("Watched namespaces and namespace selector cannot be used together")
+		}
+		if _, err := meta.ParseToLabelSelector(c.WatchNamespaceSelector); err != nil {
+			return errors.Wrapf(err, "Invalid namespace selector")
+		}
+	}
+	return nil
+}
+
+// configurationName returns the name of the webhook configurations, unique for the operator installation.
+func (c Config) configurationName() string {
+	return fmt.Sprintf("%s.%s", c.ServiceName, c.Namespace)
+}
+
+// secretName returns the name of the secret with the serving certificate.
+func (c Config) secretName() string {
+	if c.SecretName != "" {
+		return c.SecretName
+	}
+	return c.ServiceName + "-certificate"
+}
+
+// hosts returns the names of the Service, the first one is used by the API server.
+func (c Config) hosts() []string {
+	return []string{
+		fmt.Sprintf("%s.%s.svc", c.ServiceName, c.Namespace),
+		fmt.Sprintf("%s.%s", c.ServiceName, c.Namespace),
+		c.ServiceName,
+	}
+}
+
+// resources returns the resources of the enabled operators.
+func (c Config) resources() []resource {
+	var result []resource
+	if c.EnableDeployment {
+		result = append(result, deploymentResource)
+	}
+	if c.EnableDeploymentReplication {
+		result = append(result, deploymentReplicationResource)
+	}
+	if c.EnableStorage {
+		result = append(result, localStorageResource)
+	}
+	if c.EnableBackup {
+		result = append(result, backupResource, backupPolicyResource)
+	}
+	return result
+}
+
+// conversions returns the conversions of the resources of the enabled operators.
+func (c Config) conversions() []conversion {
+	if !c.EnableConversion {
+		return nil
+	}
+	return c.operatorConversions()
+}
+
+// operatorConversions returns the conversions of the resources of the enabled operators, also when the conversion is disabled.
+func (c Config) operatorConversions() []conversion {
+	var result []conversion
+	if c.EnableDeployment {
+		result = append(result, deploymentConversion, memberConversion)
+	}
+	if c.EnableDeploymentReplication {
+		result = append(result, deploymentReplicationConversion)
+	}
+	return result
+}
+
+// Dependencies of the webhook Server
+type Dependencies struct {
+	Log        zerolog.Logger
+	KubeCli    kubernetes.Interface
+	KubeExtCli apiextensionsclient.Interface
+}
+
+// Server serves the validating and mutating admission webhooks and the conversion webhooks of the operator resources.
+type Server struct {
+	cfg        Config
+	deps       Dependencies
+	httpServer *http.Server
+
+	mutex       sync.RWMutex
+	certificate webhookCertificate
+	keyPair     *tls.Certificate
+
+	stop    chan struct{}
+	running sync.WaitGroup
+}
+
+// NewServer creates a new webhook server, fetching/preparing its serving certificate.
+func NewServer(cfg Config, deps Dependencies) (*Server, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	s := &Server{
+		cfg:  cfg,
+		deps: deps,
+		stop: make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), k8sutil.GetRequestTimeout())
+	defer cancel()
+	if err := s.refreshCertificate(ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Build router
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(gin.Recovery())
+	for _, res := range cfg.resources() {
+		r.POST(res.validatePath(), gin.WrapF(newValidateHandler(deps.Log, res.validate)))
+		if cfg.EnableMutation && res.mutate != nil {
+			r.POST(res.mutatePath(), gin.WrapF(newMutateHandler(deps.Log, res.mutate)))
+		}
+	}
+	for _, c := range cfg.conversions() {
+		r.POST(c.convertPath(), gin.WrapF(newConvertHandler(deps.Log, c.convert)))
+	}
+
+	s.httpServer = &http.Server{
+		Addr:              cfg.Address,
+		Handler:           r,
+		ReadTimeout:       time.Second * 30,
+		ReadHeaderTimeout: time.Second * 15,
+		WriteTimeout:      time.Second * 30,
+		TLSNextProto:      make(map[string]func(*http.Server, *tls.Conn, http.Handler)),
+		TLSConfig: &tls.Config{
+			GetCertificate: s.getCertificate,
+		},
+	}
+
+	return s, nil
+}
+
+// Run the server until the program stops.
+func (s *Server) Run() error {
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	s.running.Add(1)
+	go s.run()
+	if len(s.cfg.conversions()) > 0 {
+		s.running.Add(1)
+		go s.watchCustomResourceDefinitions()
+	}
+
+	s.deps.Log.Info().Msgf("Serving webhooks on %s", s.httpServer.Addr)
+	if err := s.httpServer.ServeTLS(listener, "", ""); err != nil && err != http.ErrServerClosed {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Shutdown stops the server. The conversion of the CRDs is set back to None, unless another replica sets it again.
+func (s *Server) Shutdown(ctx context.Context) error {
+	close(s.stop)
+	s.running.Wait()
+
+	cli := s.deps.KubeExtCli.ApiextensionsV1().CustomResourceDefinitions()
+	for _, c := range s.cfg.conversions() {
+		if err := restoreCustomResourceConversion(ctx, cli, c.crdName, s.cfg); err != nil {
+			s.deps.Log.Warn().Err(err).Str("crd", c.crdName).Msg("Failed to restore conversion")
+		}
+	}
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// run registers the webhook configurations and keeps them and the certificate up to date.
+func (s *Server) run() {
+	defer s.running.Done()
+
+	for {
+		if err := s.refresh(); err != nil {
+			s.deps.Log.Warn().Err(err).Msg("Failed to refresh webhooks")
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-time.After(refreshInterval):
+		}
+	}
+}
+
+// watchCustomResourceDefinitions sets the conversion again when the CRDs are changed, e.g. by the upgrade of the CRD chart.
+func (s *Server) watchCustomResourceDefinitions() {
+	defer s.running.Done()
+
+	onChange := func(obj interface{}) {
+		crd, ok := obj.(*apiextensions.CustomResourceDefinition)
+		if !ok {
+			return
+		}
+		for _, c := range s.cfg.conversions() {
+			if c.crdName != crd.GetName() {
+				continue
+			}
+			expected := newCustomResourceConversion(s.cfg, c.convertPath(), s.caBundle())
+			if equality.Semantic.DeepEqual(crd.Spec.Conversion, expected) {
+				return
+			}
+			err := k8sutil.RunWithTimeout(context.Background(), func(ctxChild context.Context) error {
+				return ensureCustomResourceConversion(ctxChild, s.deps.KubeExtCli.ApiextensionsV1().CustomResourceDefinitions(), c.crdName, expected)
+			})
+			if err != nil {
+				s.deps.Log.Warn().Err(err).Str("crd", c.crdName).Msg("Failed to set conversion")
+			}
+			return
+		}
+	}
+
+	rw := k8sutil.NewResourceWatcher(s.deps.Log, s.deps.KubeExtCli.ApiextensionsV1().RESTClient(), "customresourcedefinitions", "",
+		&apiextensions.CustomResourceDefinition{}, cache.ResourceEventHandlerFuncs{
+			AddFunc: onChange,
+			UpdateFunc: func(_, newObj interface{}) {
+				onChange(newObj)
+			},
+		})
+	rw.Run(s.stop)
+}
+
+// caBundle returns the CA certificate of the webhooks.
+func (s *Server) caBundle() []byte {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return []byte(s.certificate.CACertificate)
+}
+
+func (s *Server) refresh() error {
+	ctx, cancel := context.WithTimeout(context.Background(), k8sutil.GetRequestTimeout())
+	defer cancel()
+
+	if err := s.refreshCertificate(ctx); err != nil {
+		return err
+	}
+
+	caBundle := s.caBundle()
+
+	resources := s.cfg.resources()
+	cli := s.deps.KubeCli.AdmissionregistrationV1()
+	validating, err := newValidatingWebhookConfiguration(s.cfg, resources, caBundle)
+	if err != nil {
+		return err
+	}
+	if err := ensureValidatingWebhookConfiguration(ctx, cli.ValidatingWebhookConfigurations(), validating); err != nil {
+		return err
+	}
+	if s.cfg.EnableMutation {
+		mutating, err := newMutatingWebhookConfiguration(s.cfg, resources, caBundle)
+		if err != nil {
+			return err
+		}
+		if err := ensureMutatingWebhookConfiguration(ctx, cli.MutatingWebhookConfigurations(), mutating); err != nil {
+			return err
+		}
+	} else if err := removeMutatingWebhookConfiguration(ctx, cli.MutatingWebhookConfigurations(), s.cfg.configurationName()); err != nil {
+		return err
+	}
+
+	crdCli := s.deps.KubeExtCli.ApiextensionsV1().CustomResourceDefinitions()
+	if !s.cfg.EnableConversion {
+		// Conversion could be enabled before
+		for _, c := range s.cfg.operatorConversions() {
+			if err := restoreCustomResourceConversion(ctx, crdCli, c.crdName, s.cfg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, c := range s.cfg.conversions() {
+		if err := ensureCustomResourceConversion(ctx, crdCli, c.crdName,
+			newCustomResourceConversion(s.cfg, c.convertPath(), caBundle)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshCertificate loads the serving certificate, renewing it when needed.
+func (s *Server) refreshCertificate(ctx context.Context) error {
+	c, err := ensureWebhookCertificate(ctx, s.deps.KubeCli.CoreV1().Secrets(s.cfg.Namespace), s.cfg.secretName(), s.cfg.hosts())
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.keyPair != nil && s.certificate == c {
+		return nil
+	}
+	keyPair, err := tls.X509KeyPair([]byte(c.Certificate), []byte(c.Key))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	s.certificate = c
+	s.keyPair = &keyPair
+	return nil
+}
+
+func (s *Server) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.keyPair, nil
+}
@@ -0,0 +1,156 @@
+//
+// DISCLAIMER
+//
+// Copyright 2021 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	driver "github.com/arangodb/go-driver"
+	"github.com/arangodb/go-driver/agency"
+	"github.com/spf13/cobra"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	extclient "github.com/arangodb/kube-arangodb/pkg/client"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/support"
+	"github.com/arangodb/kube-arangodb/pkg/util/arangod"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+var (
+	cmdSupportBundle = &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collect diagnostics of a deployment into a tar.gz archive",
+		Run:   cmdSupportBundleRun,
+	}
+
+	supportBundleOptions struct {
+		DeploymentName    string
+		Namespace         string
+		Output            string
+		LogLines          int64
+		OperatorPod       string
+		OperatorNamespace string
+	}
+)
+
+func init() {
+	cmdMain.AddCommand(cmdSupportBundle)
+	f := cmdSupportBundle.Flags()
+	f.StringVar(&supportBundleOptions.DeploymentName, "deployment-name", "", "Name of the deployment")
+	f.StringVar(&supportBundleOptions.Namespace, "namespace", "", "Namespace of the deployment (defaults to the namespace of the operator pod or 'default')")
+	f.StringVar(&supportBundleOptions.Output, "output", "", "Path of the archive to write (defaults to <deployment-name>-support.tar.gz)")
+	f.Int64Var(&supportBundleOptions.LogLines, "log-lines", support.DefaultLogLines, "Number of log lines collected per container")
+	f.StringVar(&supportBundleOptions.OperatorPod, "operator.pod-name", os.Getenv(constants.EnvOperatorPodName), "Name of the operator pod whose logs are collected")
+	f.StringVar(&supportBundleOptions.OperatorNamespace, "operator.namespace", os.Getenv(constants.EnvOperatorPodNamespace), "Namespace of the operator pod")
+}
+
+func cmdSupportBundleRun(cmd *cobra.Command, args []string) {
+	name := supportBundleOptions.DeploymentName
+	if name == "" {
+		cliLog.Fatal().Msg("--deployment-name is required")
+	}
+
+	namespace := supportBundleOptions.Namespace
+	if namespace == "" {
+		namespace = os.Getenv(constants.EnvOperatorPodNamespace)
+	}
+	if namespace == "" {
+		namespace = meta.NamespaceDefault
+	}
+
+	output := supportBundleOptions.Output
+	if output == "" {
+		output = fmt.Sprintf("%s-support.tar.gz", name)
+	}
+
+	kubecli, err := k8sutil.NewKubeClient()
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to create Kubernetes client")
+	}
+
+	extcli, err := extclient.NewClient()
+	if err != nil {
+		cliLog.Fatal().Err(err).Msg("Failed to create arango extension client")
+	}
+
+	ctx := context.Background()
+
+	depl, err := extcli.DatabaseV1().ArangoDeployments(namespace).Get(ctx, name, meta.GetOptions{})
+	if err != nil {
+		cliLog.Fatal().Err(err).Str("namespace", namespace).Str("name", name).Msg("Failed to get deployment")
+	}
+
+	c := support.Collector{
+		KubeCli:           kubecli,
+		CRCli:             extcli,
+		LogLines:          supportBundleOptions.LogLines,
+		OperatorPod:       supportBundleOptions.OperatorPod,
+		OperatorNamespace: supportBundleOptions.OperatorNamespace,
+	}
+
+	if depl.Spec.GetMode() == api.DeploymentModeCluster {
+		// The database client is only reachable from within the Kubernetes cluster.
+		// When it cannot be created, the agency and health parts are recorded as errors in the bundle.
+		if client, err := arangod.CreateArangodDatabaseClient(ctx, kubecli.CoreV1(), depl, false); err != nil {
+			cliLog.Warn().Err(err).Msg("Failed to create database client, skipping agency and health")
+		} else {
+			c.Agency = supportBundleAgencyFetcher(client)
+			c.Health = func(ctx context.Context) (driver.ClusterHealth, error) {
+				cluster, err := client.Cluster(ctx)
+				if err != nil {
+					return driver.ClusterHealth{}, err
+				}
+				return cluster.Health(ctx)
+			}
+		}
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		cliLog.Fatal().Err(err).Str("output", output).Msg("Failed to create output file")
+	}
+
+	if err := c.Collect(ctx, namespace, name, file); err != nil {
+		file.Close()
+		cliLog.Fatal().Err(err).Msg("Failed to collect support bundle")
+	}
+
+	if err := file.Close(); err != nil {
+		cliLog.Fatal().Err(err).Str("output", output).Msg("Failed to write output file")
+	}
+
+	cliLog.Info().Str("output", output).Msg("Support bundle written")
+}
+
+// supportBundleAgencyFetcher reads agency keys through the agency proxy of the coordinators.
+func supportBundleAgencyFetcher(client driver.Client) func(ctx context.Context, i interface{}, keyParts ...string) error {
+	return func(ctx context.Context, i interface{}, keyParts ...string) error {
+		a, err := agency.NewAgency(client.Connection())
+		if err != nil {
+			return err
+		}
+		return a.ReadKey(ctx, keyParts, i)
+	}
+}